@@ -0,0 +1,72 @@
+// Package buildinfo holds the version details of a lighthouse build. Commit
+// and Date are meant to be set with -ldflags, e.g.
+//
+//	go build -ldflags "-X github.com/yourusername/lighthouse/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//		-X github.com/yourusername/lighthouse/buildinfo.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/lighthouse
+//
+// When they aren't, the VCS details Go embeds in module builds are used.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at build time with -ldflags "-X"
+var (
+	Version = "0.1.0"
+	Commit  = ""
+	Date    = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	GoVersion string `json:"goVersion"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+// Get returns the build info, falling back to debug.ReadBuildInfo for the
+// commit and date when they weren't set with -ldflags
+func Get() Info {
+	bi, _ := debug.ReadBuildInfo()
+	return resolve(bi)
+}
+
+// resolve fills in Info from the ldflags variables and, where those are
+// empty, from bi, which may be nil
+func resolve(bi *debug.BuildInfo) Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+	}
+	if bi == nil {
+		return info
+	}
+
+	if bi.GoVersion != "" {
+		info.GoVersion = bi.GoVersion
+	}
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.Date == "" {
+				info.Date = setting.Value
+			}
+		case "vcs.modified":
+			// Only meaningful alongside the embedded revision
+			if Commit == "" {
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+	return info
+}
@@ -0,0 +1,45 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.22.0",
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2024-05-01T12:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	t.Run("falls back to embedded VCS details", func(t *testing.T) {
+		info := resolve(bi)
+		assert.Equal(t, Version, info.Version)
+		assert.Equal(t, "abc123", info.Commit)
+		assert.Equal(t, "2024-05-01T12:00:00Z", info.Date)
+		assert.Equal(t, "go1.22.0", info.GoVersion)
+		assert.True(t, info.Modified)
+	})
+
+	t.Run("ldflags take precedence", func(t *testing.T) {
+		defer func(commit, date string) { Commit, Date = commit, date }(Commit, Date)
+		Commit, Date = "def456", "2024-06-01T00:00:00Z"
+
+		info := resolve(bi)
+		assert.Equal(t, "def456", info.Commit)
+		assert.Equal(t, "2024-06-01T00:00:00Z", info.Date)
+		assert.False(t, info.Modified)
+	})
+
+	t.Run("no build info", func(t *testing.T) {
+		info := resolve(nil)
+		assert.Equal(t, Version, info.Version)
+		assert.Equal(t, runtime.Version(), info.GoVersion)
+	})
+}
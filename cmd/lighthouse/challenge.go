@@ -0,0 +1,88 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// claimChallengeTTL is how long a claim challenge stays valid
+const claimChallengeTTL = 5 * time.Minute
+
+// ErrChallengeNotFound is returned for a challenge that was never issued,
+// has expired or was already used
+var ErrChallengeNotFound = errors.New("unknown or expired challenge")
+
+// ChallengeStore issues single-use random challenges that project owners
+// sign with their auth key to prove ownership
+type ChallengeStore struct {
+	mu         sync.Mutex
+	challenges map[string]issuedChallenge
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// issuedChallenge records which project a challenge was issued for
+type issuedChallenge struct {
+	projectID string
+	expires   time.Time
+}
+
+// NewChallengeStore creates a store whose challenges expire after ttl
+func NewChallengeStore(ttl time.Duration) *ChallengeStore {
+	return &ChallengeStore{
+		challenges: make(map[string]issuedChallenge),
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+// Issue creates a new challenge for a project and returns it with its expiry
+func (s *ChallengeStore) Issue(projectID string) (string, time.Time, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+	challenge := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for c, issued := range s.challenges {
+		if now.After(issued.expires) {
+			delete(s.challenges, c)
+		}
+	}
+
+	expires := now.Add(s.ttl)
+	s.challenges[challenge] = issuedChallenge{projectID: projectID, expires: expires}
+	return challenge, expires, nil
+}
+
+// Consume checks that a challenge was issued for the project and has not
+// expired, and removes it so it cannot be replayed
+func (s *ChallengeStore) Consume(projectID, challenge string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issued, ok := s.challenges[challenge]
+	if !ok {
+		return ErrChallengeNotFound
+	}
+	delete(s.challenges, challenge)
+
+	if issued.projectID != projectID || s.now().After(issued.expires) {
+		return ErrChallengeNotFound
+	}
+	return nil
+}
+
+// claimMessage is what the project owner signs to authorize a claim. It
+// binds the challenge to the project so it can't be reused elsewhere.
+func claimMessage(projectID, challenge string) []byte {
+	return []byte("lighthouse claim " + projectID + " " + challenge)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"sync"
+)
+
+// ClaimTxJSON is a combined claim transaction ready to broadcast
+type ClaimTxJSON struct {
+	TxID string `json:"txid"`
+	Tx   string `json:"tx"`
+}
+
+// ClaimTxCache keeps each project's combined claim transaction until a new
+// pledge arrives for it, so downloads don't recombine every time
+type ClaimTxCache struct {
+	mu          sync.Mutex
+	entries     map[string]ClaimTxJSON
+	generations map[string]uint64
+}
+
+// NewClaimTxCache creates an empty cache
+func NewClaimTxCache() *ClaimTxCache {
+	return &ClaimTxCache{
+		entries:     make(map[string]ClaimTxJSON),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Get returns the cached transaction for a project, if any, and the
+// project's current generation to pass to Put
+func (c *ClaimTxCache) Get(projectID string) (ClaimTxJSON, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[projectID]
+	return entry, c.generations[projectID], ok
+}
+
+// Put caches a transaction combined at the given generation. It is dropped
+// if a pledge arrived while the transaction was being built.
+func (c *ClaimTxCache) Put(projectID string, generation uint64, entry ClaimTxJSON) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[projectID] == generation {
+		c.entries[projectID] = entry
+	}
+}
+
+// Invalidate forgets a project's transaction after its pledges change
+func (c *ClaimTxCache) Invalidate(projectID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, projectID)
+	c.generations[projectID]++
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimTxCache(t *testing.T) {
+	cache := NewClaimTxCache()
+	entry := ClaimTxJSON{TxID: "aa", Tx: "0100"}
+
+	_, generation, ok := cache.Get("project")
+	assert.False(t, ok)
+	cache.Put("project", generation, entry)
+
+	cached, _, ok := cache.Get("project")
+	assert.True(t, ok)
+	assert.Equal(t, entry, cached)
+
+	// A new pledge drops the cached transaction
+	cache.Invalidate("project")
+	_, generation, ok = cache.Get("project")
+	assert.False(t, ok)
+
+	// A transaction combined before a pledge arrived is not cached
+	cache.Invalidate("project")
+	cache.Put("project", generation, entry)
+	_, _, ok = cache.Get("project")
+	assert.False(t, ok)
+
+	// Other projects are unaffected
+	_, generation, _ = cache.Get("other")
+	cache.Put("other", generation, entry)
+	_, _, ok = cache.Get("other")
+	assert.True(t, ok)
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/lighthouse/core"
+)
+
+// Doctor check results, from best to worst
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// DoctorCheck is one line of a doctor report
+type DoctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// DoctorReport is the health of a campaign directory: a project file and
+// the pledges collected for it
+type DoctorReport struct {
+	Dir          string        `json:"dir"`
+	ProjectFile  string        `json:"projectFile"`
+	ProjectID    string        `json:"projectId,omitempty"`
+	Title        string        `json:"title,omitempty"`
+	Goal         uint64        `json:"goal"`
+	Pledged      uint64        `json:"pledged"`
+	PledgeFiles  int           `json:"pledgeFiles"`
+	Loaded       int           `json:"loaded"`
+	Accepted     int           `json:"accepted"`
+	Duplicates   int           `json:"duplicates"`
+	Conflicts    int           `json:"conflicts"`
+	BelowMinimum int           `json:"belowMinimum"`
+	Rejected     int           `json:"rejected"`
+	Expired      bool          `json:"expired"`
+	CanClaim     bool          `json:"canClaim"`
+	Checks       []DoctorCheck `json:"checks"`
+	Problems     []string      `json:"problems,omitempty"`
+}
+
+// Failed reports whether any check failed, i.e. claiming would fail
+func (r *DoctorReport) Failed() bool {
+	for _, check := range r.Checks {
+		if check.Status == checkFail {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DoctorReport) add(name, status, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, DoctorCheck{Name: name, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+// doctorCmd diagnoses a campaign directory before claiming
+func doctorCmd() *cobra.Command {
+	var projectFile string
+
+	cmd := &cobra.Command{
+		Use:   "doctor [dir]",
+		Short: "Check a campaign directory before claiming",
+		Long: `Check the project file and every .pledge file in a directory: whether the
+project loads, how many pledges load and are accepted, pledges that
+commit the same UTXO, pledges below the minimum, the total against the
+goal and the expiry. Finally the claim transaction is built, without
+writing or broadcasting it.
+
+Exits non-zero if claiming would fail.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			report, err := diagnose(dir, projectFile)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if err := printJSON(report); err != nil {
+					return err
+				}
+			} else {
+				printDoctorReport(report)
+			}
+
+			if report.Failed() {
+				return errors.New("claiming would fail")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&projectFile, "project", "", "Project file (default: the only .lighthouse file in the directory)")
+
+	return cmd
+}
+
+// diagnose checks the project and pledges in dir. It only returns an error
+// if there is no single project file to check; everything else is reported.
+func diagnose(dir, projectFile string) (*DoctorReport, error) {
+	if projectFile == "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.lighthouse"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		if len(files) != 1 {
+			return nil, fmt.Errorf("found %d project files in %s, choose one with --project", len(files), dir)
+		}
+		projectFile = files[0]
+	}
+
+	report := &DoctorReport{Dir: dir, ProjectFile: projectFile}
+
+	data, err := ioutil.ReadFile(projectFile)
+	if err != nil {
+		report.add("project", checkFail, "failed to read %s: %v", projectFile, err)
+		return report, nil
+	}
+	project, err := core.LoadProject(data)
+	if err != nil {
+		report.add("project", checkFail, "failed to load %s: %v", projectFile, err)
+		return report, nil
+	}
+	report.ProjectID = project.ID()
+	report.Title = project.Title()
+	report.Goal = project.GoalAmount()
+	report.add("project", checkOK, "%s (%s, %s)", project.Title(), project.ID(), project.Network())
+
+	// Pledges
+	report.PledgeFiles = countPledgeFiles([]string{dir})
+	pledges, loadErrs := core.LoadPledgesFromDir(dir, runtime.NumCPU())
+	report.Loaded = len(pledges)
+	for _, err := range loadErrs {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	switch {
+	case report.PledgeFiles == 0:
+		report.add("pledge files", checkFail, "no pledge files found")
+	case len(loadErrs) > 0:
+		report.add("pledge files", checkWarn, "%d of %d load cleanly", report.Loaded, report.PledgeFiles)
+	default:
+		report.add("pledge files", checkOK, "%d of %d load cleanly", report.Loaded, report.PledgeFiles)
+	}
+
+	contract := core.NewContract(project)
+	for _, pledge := range pledges {
+		err := contract.AddPledge(pledge)
+		switch {
+		case err == nil:
+			continue
+		case errors.Is(err, core.ErrDuplicatePledge):
+			report.Duplicates++
+			continue
+		case errors.Is(err, core.ErrConflictingInputs):
+			// Counted per UTXO below
+		case errors.Is(err, core.ErrPledgeBelowMinimum):
+			report.BelowMinimum++
+		default:
+			report.Rejected++
+		}
+		report.Problems = append(report.Problems, fmt.Sprintf("pledge %s: %v", pledge.ID(), err))
+	}
+	report.Accepted = len(contract.Pledges())
+	report.Pledged = contract.TotalPledged()
+
+	if report.Rejected > 0 {
+		report.add("pledges", checkWarn, "%d accepted, %d rejected, %d duplicate copies", report.Accepted, report.Rejected, report.Duplicates)
+	} else {
+		report.add("pledges", checkOK, "%d accepted, %d duplicate copies", report.Accepted, report.Duplicates)
+	}
+
+	report.Conflicts = len(core.FindConflictingPledges(pledges))
+	if report.Conflicts > 0 {
+		report.add("conflicts", checkWarn, "%d UTXOs committed by more than one pledge, only one of each is used", report.Conflicts)
+	} else {
+		report.add("conflicts", checkOK, "no UTXO is committed by more than one pledge")
+	}
+
+	if report.BelowMinimum > 0 {
+		report.add("minimum", checkWarn, "%d pledges below the %s BSV minimum", report.BelowMinimum, core.SatoshisToBSV(project.MinPledgeAmount()))
+	} else {
+		report.add("minimum", checkOK, "no pledges below the %s BSV minimum", core.SatoshisToBSV(project.MinPledgeAmount()))
+	}
+
+	// Funding and expiry
+	if contract.GoalReached() {
+		report.add("goal", checkOK, "%s of %s BSV pledged", core.SatoshisToBSV(report.Pledged), core.SatoshisToBSV(report.Goal))
+	} else {
+		report.add("goal", checkFail, "%s of %s BSV pledged, %s BSV short", core.SatoshisToBSV(report.Pledged), core.SatoshisToBSV(report.Goal), core.SatoshisToBSV(contract.Remaining()))
+	}
+
+	report.Expired = project.IsExpired()
+	switch {
+	case report.Expired:
+		report.add("expiry", checkFail, "expired %s", project.Expires().Format(time.RFC3339))
+	case project.Expires().IsZero():
+		report.add("expiry", checkOK, "no expiry")
+	default:
+		report.add("expiry", checkOK, "expires %s", project.Expires().Format(time.RFC3339))
+	}
+
+	// Building the transaction catches anything the checks above miss
+	preview, err := contract.ClaimPreview()
+	if err != nil {
+		report.add("claim", checkFail, "%v", err)
+	} else {
+		report.CanClaim = true
+		report.add("claim", checkOK, "%d inputs, %s BSV fee", preview.InputCount, core.SatoshisToBSV(preview.Fee))
+	}
+
+	return report, nil
+}
+
+// printDoctorReport prints a report as a checklist
+func printDoctorReport(report *DoctorReport) {
+	fmt.Printf("Checking %s\n\n", report.ProjectFile)
+	for _, check := range report.Checks {
+		fmt.Printf("[%-4s] %-12s %s\n", strings.ToUpper(check.Status), check.Name, check.Detail)
+	}
+
+	if len(report.Problems) > 0 {
+		fmt.Printf("\nProblems:\n")
+		for _, problem := range report.Problems {
+			fmt.Printf("  %s\n", problem)
+		}
+	}
+
+	if report.CanClaim && !report.Failed() {
+		fmt.Printf("\nReady to claim\n")
+	}
+}
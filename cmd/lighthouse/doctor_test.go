@@ -0,0 +1,104 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/lighthouse/core"
+)
+
+func TestDiagnose(t *testing.T) {
+	dir := t.TempDir()
+	project, err := core.NewProject("Doctor Test", "Testing diagnosis", 1000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+
+	writePledge := func(pledge *core.Pledge) {
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pledge.ID()+".pledge"), data, 0644))
+	}
+	checkStatus := func(report *DoctorReport, name string) string {
+		for _, check := range report.Checks {
+			if check.Name == name {
+				return check.Status
+			}
+		}
+		t.Fatalf("no %q check", name)
+		return ""
+	}
+
+	// Pledged under a lower minimum, which doesn't change the project ID
+	small := newSignedPledge(t, project, 20000, 20000)
+	require.NoError(t, project.SetMinPledgeAmount(50000))
+	data, err := project.Serialize()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "doctor.lighthouse"), data, 0644))
+
+	t.Run("no pledges", func(t *testing.T) {
+		report, err := diagnose(dir, "")
+		require.NoError(t, err)
+		assert.Equal(t, project.ID(), report.ProjectID)
+		assert.Equal(t, checkOK, checkStatus(report, "project"))
+		assert.Equal(t, checkFail, checkStatus(report, "pledge files"))
+		assert.Equal(t, checkFail, checkStatus(report, "claim"))
+		assert.True(t, report.Failed())
+	})
+
+	writePledge(small)
+	writePledge(newSignedPledge(t, project, 600000, 600000))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pledge"), []byte("not a pledge"), 0644))
+
+	t.Run("short of goal", func(t *testing.T) {
+		report, err := diagnose(dir, "")
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.PledgeFiles)
+		assert.Equal(t, 2, report.Loaded)
+		assert.Equal(t, 1, report.Accepted)
+		assert.Equal(t, 1, report.BelowMinimum)
+		assert.Equal(t, uint64(600000), report.Pledged)
+		assert.Len(t, report.Problems, 2)
+		assert.Equal(t, checkWarn, checkStatus(report, "pledge files"))
+		assert.Equal(t, checkWarn, checkStatus(report, "minimum"))
+		assert.Equal(t, checkFail, checkStatus(report, "goal"))
+		assert.False(t, report.CanClaim)
+		assert.True(t, report.Failed())
+	})
+
+	writePledge(newSignedPledge(t, project, 400000, 400000))
+
+	t.Run("claimable", func(t *testing.T) {
+		report, err := diagnose(dir, "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Accepted)
+		assert.Equal(t, uint64(1000000), report.Pledged)
+		assert.Equal(t, checkOK, checkStatus(report, "goal"))
+		assert.Equal(t, checkOK, checkStatus(report, "expiry"))
+		assert.Equal(t, checkOK, checkStatus(report, "claim"))
+		assert.True(t, report.CanClaim)
+		assert.False(t, report.Failed())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := filepath.Join(t.TempDir(), "expired.lighthouse")
+		project.SetExpiry(time.Now().Add(-time.Hour))
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(expired, data, 0644))
+
+		report, err := diagnose(dir, expired)
+		require.NoError(t, err)
+		assert.True(t, report.Expired)
+		assert.Equal(t, checkFail, checkStatus(report, "expiry"))
+		assert.True(t, report.Failed())
+	})
+
+	t.Run("several projects", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "other.lighthouse"), data, 0644))
+		_, err := diagnose(dir, "")
+		assert.ErrorContains(t, err, "found 2 project files")
+	})
+}
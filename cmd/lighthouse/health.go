@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// healthStatsTTL is how long data directory counts are reused between
+// health probes
+const healthStatsTTL = 5 * time.Second
+
+// HealthStatus is the /health response
+type HealthStatus struct {
+	Status       string `json:"status"`
+	Service      string `json:"service"`
+	Version      string `json:"version"`
+	DataDir      string `json:"dataDir,omitempty"`
+	ProjectCount int    `json:"projectCount"`
+	PledgeCount  int    `json:"pledgeCount"`
+	ScanError    string `json:"scanError,omitempty"`
+}
+
+// HealthChecker reports server health with data directory counts, cached
+// so frequent probes don't glob the directory every time
+type HealthChecker struct {
+	store ProjectStore
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu      sync.Mutex
+	status  HealthStatus
+	expires time.Time
+}
+
+// NewHealthChecker creates a checker that rescans the store at most once per ttl
+func NewHealthChecker(store ProjectStore, ttl time.Duration) *HealthChecker {
+	return &HealthChecker{
+		store: store,
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+// Status returns the current health. A failed scan is reported in the
+// status rather than marking the server unhealthy.
+func (h *HealthChecker) Status() HealthStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	if now.Before(h.expires) {
+		return h.status
+	}
+
+	status := HealthStatus{
+		Status:  "healthy",
+		Service: "lighthouse-server",
+		Version: version,
+	}
+	// Only stores backed by a directory have one to report
+	if store, ok := h.store.(interface{ Dir() string }); ok {
+		status.DataDir = store.Dir()
+	}
+	projects, pledges, err := h.store.Counts()
+	if err != nil {
+		status.ScanError = err.Error()
+	} else {
+		status.ProjectCount = projects
+		status.PledgeCount = pledges
+	}
+
+	h.status = status
+	h.expires = now.Add(h.ttl)
+	return status
+}
+
+// Health check handler
+func healthHandler(health *HealthChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(health.Status())
+	}
+}
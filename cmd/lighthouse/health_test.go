@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.lighthouse", "a1.pledge", "a2.pledge", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644))
+	}
+
+	now := time.Unix(1700000000, 0)
+	health := NewHealthChecker(NewFileStore(dir), 5*time.Second)
+	health.now = func() time.Time { return now }
+
+	get := func() HealthStatus {
+		rec := httptest.NewRecorder()
+		healthHandler(health)(rec, httptest.NewRequest("GET", "/health", nil))
+		var status HealthStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		return status
+	}
+
+	status := get()
+	assert.Equal(t, "healthy", status.Status)
+	assert.Equal(t, version, status.Version)
+	assert.Equal(t, dir, status.DataDir)
+	assert.Equal(t, 1, status.ProjectCount)
+	assert.Equal(t, 2, status.PledgeCount)
+	assert.Empty(t, status.ScanError)
+
+	// Counts are cached until the TTL passes
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a3.pledge"), []byte("x"), 0644))
+	assert.Equal(t, 2, get().PledgeCount)
+	now = now.Add(6 * time.Second)
+	assert.Equal(t, 3, get().PledgeCount)
+
+	t.Run("scan errors stay healthy", func(t *testing.T) {
+		health := NewHealthChecker(NewFileStore(filepath.Join(dir, "missing")), time.Second)
+		status := health.Status()
+		assert.Equal(t, "healthy", status.Status)
+		assert.NotEmpty(t, status.ScanError)
+	})
+
+	t.Run("memory store has no data dir", func(t *testing.T) {
+		status := NewHealthChecker(NewMemoryStore(), time.Second).Status()
+		assert.Empty(t, status.DataDir)
+		assert.Empty(t, status.ScanError)
+	})
+}
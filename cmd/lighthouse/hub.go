@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/yourusername/lighthouse/core"
+)
+
+// maxSubscribersPerProject caps live status subscriptions for one project
+const maxSubscribersPerProject = 100
+
+// ErrTooManySubscribers is returned when a project has no subscriber slots left
+var ErrTooManySubscribers = errors.New("too many subscribers for project")
+
+// ErrHubClosed is returned when subscribing after the hub has been closed
+var ErrHubClosed = errors.New("server is shutting down")
+
+// StatusHub is an in-memory pub/sub of contract status updates keyed by project ID
+type StatusHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan core.ContractStatus]struct{}
+	limit       int
+	closed      bool
+}
+
+// NewStatusHub creates a hub allowing up to limit subscribers per project
+func NewStatusHub(limit int) *StatusHub {
+	return &StatusHub{
+		subscribers: make(map[string]map[chan core.ContractStatus]struct{}),
+		limit:       limit,
+	}
+}
+
+// Subscribe registers for status updates on a project. The returned function
+// unsubscribes and must be called when the subscriber goes away.
+func (h *StatusHub) Subscribe(projectID string) (<-chan core.ContractStatus, func(), error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, ErrHubClosed
+	}
+	subs := h.subscribers[projectID]
+	if len(subs) >= h.limit {
+		return nil, nil, ErrTooManySubscribers
+	}
+	if subs == nil {
+		subs = make(map[chan core.ContractStatus]struct{})
+		h.subscribers[projectID] = subs
+	}
+
+	// Buffer one update so a publish never waits on a slow client
+	ch := make(chan core.ContractStatus, 1)
+	subs[ch] = struct{}{}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := subs[ch]; !ok {
+			return // Already unsubscribed
+		}
+		delete(subs, ch)
+		close(ch)
+		if len(subs) == 0 {
+			delete(h.subscribers, projectID)
+		}
+	}
+	return ch, unsubscribe, nil
+}
+
+// Publish sends a status update to every subscriber of the project. A
+// subscriber that hasn't consumed the previous update only gets the latest.
+func (h *StatusHub) Publish(projectID string, status core.ContractStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[projectID] {
+		select {
+		case <-ch: // Drop the stale update
+		default:
+		}
+		ch <- status
+	}
+}
+
+// Close ends every subscription by closing its channel, so subscribers can
+// disconnect cleanly. Later calls to Subscribe fail with ErrHubClosed.
+func (h *StatusHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for projectID, subs := range h.subscribers {
+		for ch := range subs {
+			delete(subs, ch)
+			close(ch)
+		}
+		delete(h.subscribers, projectID)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/lighthouse/core"
+)
+
+func TestStatusHub(t *testing.T) {
+	t.Run("publishes latest status to subscribers", func(t *testing.T) {
+		hub := NewStatusHub(2)
+		updates, unsubscribe, err := hub.Subscribe("project")
+		require.NoError(t, err)
+		defer unsubscribe()
+
+		// A slow subscriber only sees the most recent update
+		hub.Publish("project", core.ContractStatus{PledgeCount: 1})
+		hub.Publish("project", core.ContractStatus{PledgeCount: 2})
+		hub.Publish("other", core.ContractStatus{PledgeCount: 3})
+
+		assert.Equal(t, 2, (<-updates).PledgeCount)
+		select {
+		case status := <-updates:
+			t.Fatalf("unexpected update: %+v", status)
+		default:
+		}
+	})
+
+	t.Run("caps subscribers per project", func(t *testing.T) {
+		hub := NewStatusHub(1)
+		_, unsubscribe, err := hub.Subscribe("project")
+		require.NoError(t, err)
+
+		_, _, err = hub.Subscribe("project")
+		assert.ErrorIs(t, err, ErrTooManySubscribers)
+
+		_, unsubscribeOther, err := hub.Subscribe("other")
+		require.NoError(t, err)
+		unsubscribeOther()
+
+		// Unsubscribing frees the slot and is safe to repeat
+		unsubscribe()
+		unsubscribe()
+		_, unsubscribe, err = hub.Subscribe("project")
+		require.NoError(t, err)
+		unsubscribe()
+	})
+
+	t.Run("close ends subscriptions", func(t *testing.T) {
+		hub := NewStatusHub(2)
+		updates, unsubscribe, err := hub.Subscribe("project")
+		require.NoError(t, err)
+
+		hub.Close()
+		_, ok := <-updates
+		assert.False(t, ok)
+
+		// Unsubscribing after close is safe, and new subscribers are refused
+		unsubscribe()
+		_, _, err = hub.Subscribe("project")
+		assert.ErrorIs(t, err, ErrHubClosed)
+	})
+}
+
+func TestProjectSubscribe(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	project, err := core.NewProject("Subscribe Test", "Testing live updates", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(project))
+
+	hub := NewStatusHub(maxSubscribersPerProject)
+	server := httptest.NewServer(projectHandler(store, hub, NewChallengeStore(claimChallengeTTL), NewClaimTxCache()))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/projects/" + project.ID() + "/subscribe"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var msg struct {
+		Status core.ContractStatus `json:"status"`
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	// Current status arrives on connect
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, project.ID(), msg.Status.ProjectID)
+	assert.Equal(t, 0, msg.Status.PledgeCount)
+
+	// Accepted pledges are pushed
+	hub.Publish(project.ID(), core.ContractStatus{ProjectID: project.ID(), PledgeCount: 1})
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, 1, msg.Status.PledgeCount)
+
+	t.Run("unknown project", func(t *testing.T) {
+		_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/api/projects/"+strings.Repeat("0", 64)+"/subscribe", nil)
+		assert.Error(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, 404, resp.StatusCode)
+	})
+}
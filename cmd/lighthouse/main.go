@@ -1,14 +1,23 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/yourusername/lighthouse/buildinfo"
+	"github.com/yourusername/lighthouse/core"
 )
 
 var (
-	version = "0.1.0"
+	version = buildinfo.Version
+
+	// jsonOutput makes commands emit structured JSON instead of text
+	jsonOutput bool
+
+	// network selects mainnet or testnet for addresses and new projects
+	network string
 )
 
 func main() {
@@ -19,13 +28,24 @@ func main() {
 		
 Create projects, make pledges, and claim funds trustlessly when funding goals are met.`,
 		Version: version,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if network != core.NetworkMainnet && network != core.NetworkTestnet {
+				return fmt.Errorf("unsupported network %q (use %s or %s)", network, core.NetworkMainnet, core.NetworkTestnet)
+			}
+			return nil
+		},
 	}
 
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output results as JSON")
+	rootCmd.PersistentFlags().StringVar(&network, "network", core.NetworkMainnet, "Network to use (mainnet or testnet)")
+
 	// Add commands
 	rootCmd.AddCommand(
 		projectCmd(),
 		pledgeCmd(),
 		serverCmd(),
+		doctorCmd(),
+		versionCmd(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -44,9 +64,18 @@ func projectCmd() *cobra.Command {
 
 	cmd.AddCommand(
 		projectCreateCmd(),
+		projectCloneCmd(),
 		projectViewCmd(),
+		projectVerifyCmd(),
+		projectSignCmd(),
+		projectUpdateCmd(),
+		projectImportCmd(),
 		projectStatusCmd(),
+		projectWatchCmd(),
+		projectStatsCmd(),
+		projectExportPledgesCmd(),
 		projectClaimCmd(),
+		projectQRCmd(),
 	)
 
 	return cmd
@@ -59,12 +88,17 @@ func pledgeCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pledge",
 		Short: "Manage pledges to projects",
-		Long:  "Create, view, and revoke pledges to crowdfunding projects",
+		Long:  "Create, view, verify, and revoke pledges to crowdfunding projects",
 	}
 
 	cmd.AddCommand(
 		pledgeCreateCmd(),
 		pledgeViewCmd(),
+		pledgeVerifyCmd(),
+		pledgeStatusCmd(),
+		pledgeConflictsCmd(),
+		pledgeExportCmd(),
+		pledgeImportCmd(),
 		pledgeRevokeCmd(),
 	)
 
@@ -74,3 +108,10 @@ func pledgeCmd() *cobra.Command {
 // Command implementations are in pledge.go
 
 // serverCmd is now implemented in server.go
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/lighthouse/core"
+)
+
+// MemoryStore keeps projects and pledges in memory, for tests and
+// coordinators that don't need them to outlive the process. It is safe
+// for concurrent use.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	projects  map[string][]byte
+	pledges   map[string][]byte
+	byProject map[string][]string // project ID -> pledge IDs
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		projects:  make(map[string][]byte),
+		pledges:   make(map[string][]byte),
+		byProject: make(map[string][]string),
+	}
+}
+
+// Save stores a project, replacing any with the same ID. Projects are kept
+// serialized so callers can't change a stored project through their copy.
+func (s *MemoryStore) Save(project *core.Project) error {
+	data, err := project.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize project: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects[project.ID()] = data
+	return nil
+}
+
+// Load returns the project with the given ID
+func (s *MemoryStore) Load(id string) (*core.Project, error) {
+	s.mu.RLock()
+	data, ok := s.projects[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrProjectNotFound
+	}
+
+	return core.LoadProject(data)
+}
+
+// List returns all stored projects, ordered by ID like the file store
+func (s *MemoryStore) List() ([]*core.Project, error) {
+	s.mu.RLock()
+	ids := sortedKeys(s.projects)
+	data := make([][]byte, len(ids))
+	for i, id := range ids {
+		data[i] = s.projects[id]
+	}
+	s.mu.RUnlock()
+
+	var projects []*core.Project
+	for i, id := range ids {
+		project, err := core.LoadProject(data[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load project %s: %w", id, err)
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// SavePledge stores a pledge unless one with the same ID is already stored
+func (s *MemoryStore) SavePledge(pledge *core.Pledge) error {
+	data, err := pledge.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize pledge: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pledges[pledge.ID()]; !ok {
+		s.pledges[pledge.ID()] = data
+		s.byProject[pledge.ProjectID()] = append(s.byProject[pledge.ProjectID()], pledge.ID())
+	}
+	return nil
+}
+
+// LoadPledges returns all stored pledges for a project, ordered by pledge ID
+func (s *MemoryStore) LoadPledges(projectID string) ([]*core.Pledge, error) {
+	s.mu.RLock()
+	ids := append([]string(nil), s.byProject[projectID]...)
+	sort.Strings(ids)
+	data := make([][]byte, len(ids))
+	for i, id := range ids {
+		data[i] = s.pledges[id]
+	}
+	s.mu.RUnlock()
+
+	var pledges []*core.Pledge
+	for i, id := range ids {
+		pledge, err := core.LoadPledge(data[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pledge %s: %w", id, err)
+		}
+		pledges = append(pledges, pledge)
+	}
+
+	return pledges, nil
+}
+
+// Counts returns how many projects and pledges are stored
+func (s *MemoryStore) Counts() (projects, pledges int, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.projects), len(s.pledges), nil
+}
+
+// sortedKeys returns a map's keys in ascending order
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/lighthouse/core"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+
+	project, err := core.NewProject("Memory Test", "Testing the memory store", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(project))
+
+	t.Run("load", func(t *testing.T) {
+		loaded, err := store.Load(project.ID())
+		require.NoError(t, err)
+		assert.Equal(t, project.ID(), loaded.ID())
+		assert.Equal(t, "Memory Test", loaded.Title())
+
+		_, err = store.Load("missing")
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("stored copy is independent", func(t *testing.T) {
+		loaded, err := store.Load(project.ID())
+		require.NoError(t, err)
+		require.NoError(t, loaded.SetMinPledgeAmount(70000))
+
+		again, err := store.Load(project.ID())
+		require.NoError(t, err)
+		assert.NotEqual(t, uint64(70000), again.MinPledgeAmount())
+	})
+
+	t.Run("list and counts", func(t *testing.T) {
+		projects, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		assert.Equal(t, project.ID(), projects[0].ID())
+
+		pledges, err := store.LoadPledges(project.ID())
+		require.NoError(t, err)
+		assert.Empty(t, pledges)
+
+		projectCount, pledgeCount, err := store.Counts()
+		require.NoError(t, err)
+		assert.Equal(t, 1, projectCount)
+		assert.Equal(t, 0, pledgeCount)
+	})
+}
+
+func TestMemoryStoreConcurrent(t *testing.T) {
+	store := NewMemoryStore()
+
+	const writers = 8
+	const perWriter = 10
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				project, err := core.NewProject(fmt.Sprintf("Project %d-%d", w, i), "Testing concurrent saves", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.NoError(t, store.Save(project))
+				_, err = store.Load(project.ID())
+				assert.NoError(t, err)
+			}
+		}(w)
+		// Readers run alongside the writers
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				_, err := store.List()
+				assert.NoError(t, err)
+				_, _, err = store.Counts()
+				assert.NoError(t, err)
+				_, err = store.LoadPledges("any")
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	projects, err := store.List()
+	require.NoError(t, err)
+	assert.Len(t, projects, writers*perWriter)
+}
+
+func TestNewStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	store, err := newStore(StoreFile, dir)
+	require.NoError(t, err)
+	assert.IsType(t, &FileStore{}, store)
+	assert.DirExists(t, dir)
+
+	store, err = newStore(StoreMemory, "")
+	require.NoError(t, err)
+	assert.IsType(t, &MemoryStore{}, store)
+
+	_, err = newStore("redis", dir)
+	assert.ErrorContains(t, err, "unknown store")
+}
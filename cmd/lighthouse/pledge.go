@@ -2,10 +2,16 @@ package main
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/go-sdk/script"
@@ -15,17 +21,61 @@ import (
 	"github.com/yourusername/lighthouse/core"
 )
 
+// PledgeJSON is the machine-readable form of a pledge
+type PledgeJSON struct {
+	ID        string   `json:"id"`
+	ProjectID string   `json:"projectId"`
+	Amount    uint64   `json:"amount"`
+	TxID      string   `json:"txid,omitempty"`
+	Inputs    []string `json:"inputs"`
+	Timelock  uint32   `json:"timelock,omitempty"`
+	// Time is omitted, and NoTimestamp set, for pledges that didn't record one
+	Time        *time.Time `json:"time,omitempty"`
+	NoTimestamp bool       `json:"noTimestamp,omitempty"`
+	// Warnings lists fields this version doesn't know, for pledge view
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// newPledgeJSON builds the JSON representation of a pledge
+func newPledgeJSON(pledge *core.Pledge) PledgeJSON {
+	result := PledgeJSON{
+		ID:        pledge.ID(),
+		ProjectID: pledge.ProjectID(),
+		Amount:    pledge.Amount(),
+		Inputs:    []string{},
+		Timelock:  pledge.Timelock(),
+	}
+	if t := pledge.Time(); t.IsZero() {
+		result.NoTimestamp = true
+	} else {
+		result.Time = &t
+	}
+	if tx := pledge.Transaction(); tx != nil {
+		result.TxID = tx.TxID().String()
+		for _, input := range tx.Inputs {
+			result.Inputs = append(result.Inputs, fmt.Sprintf("%s:%d",
+				hex.EncodeToString(input.SourceTXID[:]), input.SourceTxOutIndex))
+		}
+	}
+	return result
+}
+
 // pledgeCreateCmd creates a new pledge
 func pledgeCreateCmd() *cobra.Command {
 	var (
-		amount    float64
+		amount    string
 		message   string
 		name      string
 		email     string
 		refund    string
 		wif       string
 		utxos     []string
+		utxoFile  string
+		feeRate   uint64
+		change    string
 		output    string
+		timelock  uint32
+		encrypt   bool
 	)
 
 	cmd := &cobra.Command{
@@ -47,8 +97,18 @@ func pledgeCreateCmd() *cobra.Command {
 				return fmt.Errorf("failed to load project: %w", err)
 			}
 			
+			if project.Network() != network {
+				return fmt.Errorf("project is on %s but --network is %s", project.Network(), network)
+			}
+			if err := project.CheckAcceptingPledges(); err != nil {
+				return err
+			}
+			
 			// Convert BSV to satoshis
-			amountSatoshis := uint64(amount * 100000000)
+			amountSatoshis, err := core.BSVToSatoshis(amount)
+			if err != nil {
+				return fmt.Errorf("invalid amount: %w", err)
+			}
 			
 			// Parse WIF private key
 			if wif == "" {
@@ -60,12 +120,29 @@ func pledgeCreateCmd() *cobra.Command {
 				return fmt.Errorf("invalid WIF private key: %w", err)
 			}
 			
-			// Parse UTXOs
+			// Gather the available UTXOs
+			if utxoFile != "" {
+				walletUTXOs, err := readWalletUTXOs(utxoFile)
+				if err != nil {
+					return err
+				}
+				utxos = append(utxos, walletUTXOs...)
+			}
 			if len(utxos) == 0 {
-				return fmt.Errorf("at least one UTXO is required (--utxo)")
+				return fmt.Errorf("at least one UTXO is required (--utxo or --utxo-file)")
+			}
+			
+			// Every UTXO must belong to the --wif key
+			address, err := script.NewAddressFromPublicKey(privKey.PubKey(), network == core.NetworkMainnet)
+			if err != nil {
+				return fmt.Errorf("failed to create address: %w", err)
+			}
+			lockingScriptHex, err := createP2PKHLockingScriptHex(address.AddressString)
+			if err != nil {
+				return fmt.Errorf("failed to create locking script: %w", err)
 			}
 			
-			var txUTXOs []*transaction.UTXO
+			var available []*transaction.UTXO
 			for _, utxoStr := range utxos {
 				// Expected format: txid:vout:satoshis
 				parts := strings.Split(utxoStr, ":")
@@ -83,41 +160,76 @@ func pledgeCreateCmd() *cobra.Command {
 					return fmt.Errorf("invalid satoshis in UTXO: %s", parts[2])
 				}
 				
-				// Get the locking script for our address
-				pubKey := privKey.PubKey()
-				address, err := script.NewAddressFromPublicKey(pubKey, true) // mainnet
-				if err != nil {
-					return fmt.Errorf("failed to create address: %w", err)
-				}
-				lockingScriptHex := createP2PKHLockingScriptHex(address.AddressString)
-				
 				utxo, err := transaction.NewUTXO(txid, uint32(vout), lockingScriptHex, satoshis)
 				if err != nil {
 					return fmt.Errorf("failed to create UTXO: %w", err)
 				}
 				
-				txUTXOs = append(txUTXOs, utxo)
+				available = append(available, utxo)
 			}
 			
-			// Create the pledge
-			pledge, err := core.NewPledge(project, amountSatoshis, txUTXOs)
+			// Pick enough UTXOs to cover the pledge and its share of the
+			// fee: the claim overhead here, its own inputs in SelectUTXOs
+			overhead, err := core.ClaimOverheadFee(project, feeRate)
+			if err != nil {
+				return err
+			}
+			txUTXOs, err := core.SelectUTXOs(available, amountSatoshis+overhead, feeRate)
+			if err != nil {
+				return err
+			}
+			
+			// Return any excess as change, unless it's too small to be worth
+			// an output, in which case it goes towards the claim fee
+			if change == "" {
+				change = address.AddressString
+			}
+			pledge, err := core.NewPledgeWithChange(project, amountSatoshis, txUTXOs, change, feeRate)
+			if errors.Is(err, core.ErrDustChange) || errors.Is(err, core.ErrInsufficientFunds) {
+				pledge, err = core.NewPledgeWithFeeRate(project, amountSatoshis, txUTXOs, feeRate)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to create pledge: %w", err)
 			}
 			
 			// Set optional fields
 			if message != "" {
-				pledge.SetMemo(message)
+				if err := pledge.SetMemo(message); err != nil {
+					return fmt.Errorf("invalid message: %w", err)
+				}
 			}
 			if refund != "" {
-				pledge.SetRefundAddress(refund)
+				if err := pledge.SetRefundAddress(refund); err != nil {
+					return err
+				}
 			}
 			if name != "" || email != "" {
-				pledge.SetContactInfo(name, email)
+				if encrypt {
+					if len(project.AuthKey()) == 0 {
+						return fmt.Errorf("project has no auth key to encrypt contact info to")
+					}
+					authKey, err := ec.ParsePubKey(project.AuthKey())
+					if err != nil {
+						return fmt.Errorf("invalid project auth key: %w", err)
+					}
+					if err := pledge.SetEncryptedContact(name, email, authKey); err != nil {
+						return fmt.Errorf("failed to set contact info: %w", err)
+					}
+				} else if err := pledge.SetContactInfo(name, email); err != nil {
+					return fmt.Errorf("invalid contact info: %w", err)
+				}
+			}
+			// The lock time is covered by the signature, so set it before signing
+			if timelock > 0 {
+				pledge.SetTimelock(timelock)
 			}
 			
 			// Sign the pledge
-			if err := pledge.Sign([]*ec.PrivateKey{privKey}); err != nil {
+			keys := make([]*ec.PrivateKey, len(txUTXOs))
+			for i := range keys {
+				keys[i] = privKey
+			}
+			if err := pledge.Sign(keys); err != nil {
 				return fmt.Errorf("failed to sign pledge: %w", err)
 			}
 			
@@ -134,39 +246,51 @@ func pledgeCreateCmd() *cobra.Command {
 			}
 			
 			// Write to file
-			if err := ioutil.WriteFile(output, pledgeData, 0644); err != nil {
+			if err := core.AtomicWriteFile(output, pledgeData, 0644); err != nil {
 				return fmt.Errorf("failed to write pledge file: %w", err)
 			}
 			
 			fmt.Printf("Pledge created successfully!\n")
 			fmt.Printf("File: %s\n", output)
 			fmt.Printf("ID: %s\n", pledge.ID())
-			fmt.Printf("Amount: %.8f BSV (%d satoshis)\n", amount, amountSatoshis)
+			fmt.Printf("Amount: %s BSV (%d satoshis)\n", core.SatoshisToBSV(amountSatoshis), amountSatoshis)
+			fmt.Printf("Inputs: %d of %d available UTXOs\n", len(txUTXOs), len(available))
+			if changeTx := pledge.ChangeTransaction(); changeTx != nil {
+				returned := changeTx.Outputs[1].Satoshis
+				fmt.Printf("Change: %s BSV to %s\n", core.SatoshisToBSV(returned), change)
+				fmt.Printf("Change transaction: %s (broadcast with the claim)\n", changeTx.TxID())
+			}
 			fmt.Printf("Project: %s\n", project.Title())
 			
 			return nil
 		},
 	}
 
-	cmd.Flags().Float64VarP(&amount, "amount", "a", 0, "Pledge amount in BSV (required)")
+	cmd.Flags().StringVarP(&amount, "amount", "a", "", "Pledge amount in BSV (required)")
 	cmd.Flags().StringVarP(&message, "message", "m", "", "Optional message to project creator")
 	cmd.Flags().StringVar(&name, "name", "", "Your name (optional)")
 	cmd.Flags().StringVar(&email, "email", "", "Your email (optional)")
+	cmd.Flags().BoolVar(&encrypt, "encrypt-contact", false, "Encrypt name and email so only the project owner can read them")
 	cmd.Flags().StringVar(&refund, "refund", "", "Refund address if project fails")
 	cmd.Flags().StringVarP(&wif, "wif", "w", "", "Private key in WIF format (required)")
-	cmd.Flags().StringSliceVarP(&utxos, "utxo", "u", []string{}, "UTXOs to use (format: txid:vout:satoshis)")
+	cmd.Flags().StringSliceVarP(&utxos, "utxo", "u", []string{}, "Available UTXOs (format: txid:vout:satoshis); enough are selected to cover the pledge")
+	cmd.Flags().StringVar(&utxoFile, "utxo-file", "", "JSON file listing available UTXOs ([{\"txid\", \"vout\", \"satoshis\"}])")
+	cmd.Flags().StringVar(&change, "change-address", "", "Address for change from larger UTXOs (default: the --wif key's address)")
+	cmd.Flags().Uint64Var(&feeRate, "fee-rate", core.DefaultFeeRate, "Fee rate in satoshis per kilobyte for this pledge's share of the claim fee (at most 1000)")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output filename")
+	cmd.Flags().Uint32Var(&timelock, "timelock", 0, "Block height before which the claim can't be mined (nLockTime); all pledges to a project must agree. It doesn't lock your coins: spend the pledged inputs to take a pledge back")
 
 	cmd.MarkFlagRequired("amount")
 	cmd.MarkFlagRequired("wif")
-	cmd.MarkFlagRequired("utxo")
 
 	return cmd
 }
 
 // pledgeViewCmd displays pledge details
 func pledgeViewCmd() *cobra.Command {
-	return &cobra.Command{
+	var authWIF string
+
+	cmd := &cobra.Command{
 		Use:   "view [pledge-file]",
 		Short: "View pledge details",
 		Args:  cobra.ExactArgs(1),
@@ -180,16 +304,49 @@ func pledgeViewCmd() *cobra.Command {
 			}
 			
 			// Load the pledge
-			pledge, err := core.LoadPledge(data)
+			result, err := core.LoadPledgeWithWarnings(data)
 			if err != nil {
 				return fmt.Errorf("failed to load pledge: %w", err)
 			}
+			pledge := result.Pledge
+			
+			if jsonOutput {
+				pledgeJSON := newPledgeJSON(pledge)
+				pledgeJSON.Warnings = result.Warnings
+				return printJSON(pledgeJSON)
+			}
+			for _, warning := range result.Warnings {
+				fmt.Fprintf(os.Stderr, "Warning: %s (written by a newer version?)\n", warning)
+			}
 			
 			// Display pledge details
 			fmt.Printf("Pledge ID: %s\n", pledge.ID())
 			fmt.Printf("Project ID: %s\n", pledge.ProjectID())
-			fmt.Printf("Amount: %.8f BSV (%d satoshis)\n", 
-				float64(pledge.Amount())/100000000, pledge.Amount())
+			fmt.Printf("Amount: %s BSV (%d satoshis)\n", 
+				core.SatoshisToBSV(pledge.Amount()), pledge.Amount())
+			if t := pledge.Time(); t.IsZero() {
+				fmt.Printf("Time: unknown\n")
+			} else {
+				fmt.Printf("Time: %s\n", t.Format(time.RFC3339))
+			}
+			if timelock := pledge.Timelock(); timelock > 0 {
+				fmt.Printf("Timelock: not claimable before block %d\n", timelock)
+			}
+			if pledge.HasEncryptedContact() {
+				if authWIF == "" {
+					fmt.Printf("Contact: encrypted (use --auth-wif to decrypt)\n")
+				} else {
+					authKey, err := ec.PrivateKeyFromWif(authWIF)
+					if err != nil {
+						return fmt.Errorf("invalid auth key WIF: %w", err)
+					}
+					name, email, err := pledge.DecryptContact(authKey)
+					if err != nil {
+						return err
+					}
+					fmt.Printf("Contact: %s <%s>\n", name, email)
+				}
+			}
 			
 			// Display transaction details
 			if tx := pledge.Transaction(); tx != nil {
@@ -205,6 +362,351 @@ func pledgeViewCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&authWIF, "auth-wif", "", "Project auth key in WIF format, to decrypt encrypted contact info")
+
+	return cmd
+}
+
+// VerifyCheckJSON is the machine-readable result of one pledge verification check
+type VerifyCheckJSON struct {
+	Check  string `json:"check"`
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// pledgeVerifyCmd checks a pledge against a project without building a contract
+func pledgeVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify [pledge-file] [project-file]",
+		Short: "Check that a pledge is valid for a project",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pledgeData, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read pledge file: %w", err)
+			}
+			pledge, err := core.LoadPledge(pledgeData)
+			if err != nil {
+				return fmt.Errorf("failed to load pledge: %w", err)
+			}
+			
+			projectData, err := ioutil.ReadFile(args[1])
+			if err != nil {
+				return fmt.Errorf("failed to read project file: %w", err)
+			}
+			project, err := core.LoadProject(projectData)
+			if err != nil {
+				return fmt.Errorf("failed to load project: %w", err)
+			}
+			
+			checks := []struct {
+				name string
+				run  func() error
+			}{
+				{"pledge is for this project", func() error {
+					if pledge.ProjectID() != project.ID() {
+						return fmt.Errorf("pledge is for project %s", pledge.ProjectID())
+					}
+					return nil
+				}},
+				{"pledge is valid", pledge.Validate},
+				{"inputs signed with SIGHASH_ANYONECANPAY", pledge.CheckSigHash},
+				{"signatures are valid", pledge.VerifySignatures},
+				{"outputs match project", func() error { return pledge.CheckOutputs(project) }},
+			}
+			
+			var results []VerifyCheckJSON
+			passed := true
+			for _, check := range checks {
+				result := VerifyCheckJSON{Check: check.name, Passed: true}
+				if err := check.run(); err != nil {
+					result.Passed = false
+					result.Error = err.Error()
+					passed = false
+				}
+				results = append(results, result)
+			}
+			
+			if jsonOutput {
+				if err := printJSON(results); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("Pledge: %s\n", pledge.ID())
+				fmt.Printf("Project: %s (%s)\n", project.Title(), project.ID())
+				for _, result := range results {
+					if result.Passed {
+						fmt.Printf("  PASS  %s\n", result.Check)
+					} else {
+						fmt.Printf("  FAIL  %s: %s\n", result.Check, result.Error)
+					}
+				}
+			}
+			
+			if !passed {
+				return fmt.Errorf("pledge failed verification")
+			}
+			if !jsonOutput {
+				fmt.Printf("Result: PASS\n")
+			}
+			return nil
+		},
+	}
+}
+
+// PledgeStatusJSON is the machine-readable result of pledge status
+type PledgeStatusJSON struct {
+	PledgeID string             `json:"pledgeId"`
+	Valid    bool               `json:"valid"`
+	Error    string             `json:"error,omitempty"`
+	Inputs   []core.InputStatus `json:"inputs,omitempty"`
+}
+
+// pledgeStatusCmd reports whether a pledge's inputs are still unspent
+func pledgeStatusCmd() *cobra.Command {
+	var (
+		offline bool
+		apiURL  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status [pledge-file]",
+		Short: "Check whether a pledge's inputs are still unspent",
+		Long: `Look up each input of a pledge on WhatsOnChain and report whether the
+coins it commits are still unspent. If any have been spent the pledge can
+never be claimed. With --offline only the local validity checks are run.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read pledge file: %w", err)
+			}
+			pledge, err := core.LoadPledge(data)
+			if err != nil {
+				return fmt.Errorf("failed to load pledge: %w", err)
+			}
+
+			result := PledgeStatusJSON{PledgeID: pledge.ID(), Valid: true}
+			if err := pledge.Validate(); err != nil {
+				result.Valid = false
+				result.Error = err.Error()
+			}
+
+			if result.Valid && !offline {
+				if apiURL == "" && pledge.Network() == core.NetworkTestnet {
+					apiURL = core.WhatsOnChainTestnetAPI
+				}
+				result.Inputs, err = pledge.CheckInputs(core.NewWhatsOnChainUTXOChecker(apiURL))
+				if err != nil {
+					return fmt.Errorf("failed to check pledge inputs: %w", err)
+				}
+			}
+
+			var spent int
+			for _, input := range result.Inputs {
+				if !input.Unspent {
+					spent++
+				}
+			}
+
+			if jsonOutput {
+				if err := printJSON(result); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("Pledge: %s\n", pledge.ID())
+				if result.Valid {
+					fmt.Printf("  PASS  pledge is valid\n")
+				} else {
+					fmt.Printf("  FAIL  pledge is valid: %s\n", result.Error)
+				}
+				for _, input := range result.Inputs {
+					if input.Unspent {
+						fmt.Printf("  unspent  %s\n", input.Outpoint)
+					} else {
+						fmt.Printf("  SPENT    %s\n", input.Outpoint)
+					}
+				}
+			}
+
+			if !result.Valid {
+				return fmt.Errorf("pledge is invalid")
+			}
+			if spent > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: %d of %d inputs have been spent; this pledge can no longer be claimed\n", spent, len(result.Inputs))
+				return fmt.Errorf("pledge inputs have been spent")
+			}
+			if !jsonOutput {
+				if offline {
+					fmt.Printf("Result: valid (inputs not checked in offline mode)\n")
+				} else {
+					fmt.Printf("Result: all inputs unspent\n")
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&offline, "offline", false, "Only run local validation, without looking up inputs")
+	cmd.Flags().StringVar(&apiURL, "api-url", "", "WhatsOnChain API base URL (default: chosen by the pledge's network)")
+
+	return cmd
+}
+
+// PledgeConflictJSON is the machine-readable form of a double-committed UTXO
+type PledgeConflictJSON struct {
+	Outpoint  string   `json:"outpoint"`
+	PledgeIDs []string `json:"pledgeIds"`
+}
+
+// pledgeConflictsCmd finds pledges in a directory that spend the same UTXO
+func pledgeConflictsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "conflicts [dir]",
+		Short: "Find pledges that commit the same funds",
+		Long: `Scan every .pledge file in a directory for UTXOs spent by more than one
+pledge. Only one pledge per UTXO can be claimed, so conflicting pledges
+should be resolved with their pledgers before claiming.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			pledges, loadErrs := core.LoadPledgesFromDir(dir, runtime.NumCPU())
+			for _, err := range loadErrs {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+
+			conflicts := core.FindConflictingPledges(pledges)
+			outpoints := make([]string, 0, len(conflicts))
+			for outpoint := range conflicts {
+				outpoints = append(outpoints, outpoint)
+			}
+			sort.Strings(outpoints)
+
+			if jsonOutput {
+				results := make([]PledgeConflictJSON, len(outpoints))
+				for i, outpoint := range outpoints {
+					results[i] = PledgeConflictJSON{Outpoint: outpoint, PledgeIDs: conflicts[outpoint]}
+				}
+				if err := printJSON(results); err != nil {
+					return err
+				}
+			} else {
+				amounts := make(map[string]uint64)
+				for _, pledge := range pledges {
+					amounts[pledge.ID()] = pledge.Amount()
+				}
+
+				fmt.Printf("Scanned %d pledges\n", len(pledges))
+				for _, outpoint := range outpoints {
+					fmt.Printf("\nUTXO %s is spent by %d pledges:\n", outpoint, len(conflicts[outpoint]))
+					for _, id := range conflicts[outpoint] {
+						fmt.Printf("  %s (%s BSV)\n", id, core.SatoshisToBSV(amounts[id]))
+					}
+				}
+			}
+
+			if len(conflicts) > 0 {
+				return fmt.Errorf("found %d UTXOs committed by more than one pledge", len(conflicts))
+			}
+			if !jsonOutput {
+				fmt.Printf("No conflicting pledges found\n")
+			}
+			return nil
+		},
+	}
+}
+
+// pledgeExportCmd converts a pledge file to ASCII-armored text
+func pledgeExportCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export [pledge-file]",
+		Short: "Export a pledge as armored text for chat or email",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read pledge file: %w", err)
+			}
+			
+			// Refuse to armor something that isn't a pledge
+			if _, err := core.LoadPledge(data); err != nil {
+				return fmt.Errorf("failed to load pledge: %w", err)
+			}
+			
+			armored := core.ArmorPledge(data)
+			if output == "" {
+				fmt.Print(armored)
+				return nil
+			}
+			if err := core.AtomicWriteFile(output, []byte(armored), 0644); err != nil {
+				return fmt.Errorf("failed to write armored pledge: %w", err)
+			}
+			fmt.Printf("Pledge exported to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
+
+// pledgeImportCmd converts an armored pledge back to a binary pledge file
+func pledgeImportCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "import [armored-file]",
+		Short: "Import an armored pledge (reads stdin if no file or \"-\" is given)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				text []byte
+				err  error
+			)
+			if len(args) == 0 || args[0] == "-" {
+				text, err = ioutil.ReadAll(os.Stdin)
+			} else {
+				text, err = ioutil.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read armored pledge: %w", err)
+			}
+			
+			data, err := core.DeArmorPledge(string(text))
+			if err != nil {
+				return err
+			}
+			pledge, err := core.LoadPledge(data)
+			if err != nil {
+				return fmt.Errorf("failed to load pledge: %w", err)
+			}
+			
+			if output == "" {
+				output = fmt.Sprintf("%s.pledge", pledge.ID()[:8])
+			}
+			if err := core.AtomicWriteFile(output, data, 0644); err != nil {
+				return fmt.Errorf("failed to write pledge file: %w", err)
+			}
+			
+			fmt.Printf("Pledge imported!\n")
+			fmt.Printf("File: %s\n", output)
+			fmt.Printf("ID: %s\n", pledge.ID())
+			fmt.Printf("Amount: %s BSV\n", core.SatoshisToBSV(pledge.Amount()))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output filename (default: first 8 characters of the pledge ID)")
+
+	return cmd
 }
 
 // pledgeRevokeCmd revokes a pledge
@@ -234,6 +736,10 @@ func pledgeRevokeCmd() *cobra.Command {
 				return fmt.Errorf("failed to load pledge: %w", err)
 			}
 			
+			if pledge.Network() != "" && pledge.Network() != network {
+				return fmt.Errorf("pledge is on %s but --network is %s", pledge.Network(), network)
+			}
+			
 			// Parse WIF private key
 			if wif == "" {
 				return fmt.Errorf("private key (--wif) is required")
@@ -260,7 +766,7 @@ func pledgeRevokeCmd() *cobra.Command {
 			
 			// Add output back to our address
 			pubKey := privKey.PubKey()
-			address, err := script.NewAddressFromPublicKey(pubKey, true) // mainnet
+			address, err := script.NewAddressFromPublicKey(pubKey, network == core.NetworkMainnet)
 			if err != nil {
 				return fmt.Errorf("failed to create address: %w", err)
 			}
@@ -275,16 +781,19 @@ func pledgeRevokeCmd() *cobra.Command {
 				return fmt.Errorf("failed to create locking script: %w", err)
 			}
 			
-			// Calculate total input (would need to look up UTXOs in real implementation)
-			totalAmount := pledge.Amount()
-			fee := uint64(1000) // Simple fixed fee for now
+			// Reclaim everything the pledge inputs hold
+			totalAmount := pledge.InputTotal()
+			refundOutput := &transaction.TransactionOutput{
+				Satoshis:      totalAmount,
+				LockingScript: lockingScript,
+			}
+			revokeTx.AddOutput(refundOutput)
 			
-			if totalAmount > fee {
-				revokeTx.AddOutput(&transaction.TransactionOutput{
-					Satoshis:      totalAmount - fee,
-					LockingScript: lockingScript,
-				})
+			fee := core.EstimateFee(revokeTx, core.DefaultFeeRate)
+			if totalAmount <= fee {
+				return fmt.Errorf("pledge inputs of %d satoshis do not cover fee of %d satoshis", totalAmount, fee)
 			}
+			refundOutput.Satoshis = totalAmount - fee
 			
 			// Sign the revocation transaction
 			// In a real implementation, we'd need to sign properly
@@ -296,7 +805,7 @@ func pledgeRevokeCmd() *cobra.Command {
 				output = fmt.Sprintf("%s-revoke.tx", pledgeFile)
 			}
 			
-			if err := ioutil.WriteFile(output, []byte(txHex), 0644); err != nil {
+			if err := core.AtomicWriteFile(output, []byte(txHex), 0644); err != nil {
 				return fmt.Errorf("failed to write transaction: %w", err)
 			}
 			
@@ -321,12 +830,48 @@ func pledgeRevokeCmd() *cobra.Command {
 	return cmd
 }
 
+// walletUTXO is one entry of a --utxo-file wallet listing
+type walletUTXO struct {
+	TxID     string `json:"txid"`
+	Vout     uint32 `json:"vout"`
+	Satoshis uint64 `json:"satoshis"`
+}
+
+// readWalletUTXOs reads a JSON array of UTXOs and returns them in the
+// txid:vout:satoshis form accepted by --utxo
+func readWalletUTXOs(path string) ([]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read UTXO file: %w", err)
+	}
+
+	var wallet []walletUTXO
+	if err := json.Unmarshal(data, &wallet); err != nil {
+		return nil, fmt.Errorf("invalid UTXO file: %w", err)
+	}
+
+	utxos := make([]string, 0, len(wallet))
+	for i, utxo := range wallet {
+		if utxo.TxID == "" {
+			return nil, fmt.Errorf("invalid UTXO file: entry %d has no txid", i)
+		}
+		utxos = append(utxos, fmt.Sprintf("%s:%d:%d", utxo.TxID, utxo.Vout, utxo.Satoshis))
+	}
+	return utxos, nil
+}
+
 // createP2PKHLockingScriptHex creates a P2PKH locking script for an address
-func createP2PKHLockingScriptHex(address string) string {
-	// This is a simplified version - in production, use proper script building
+func createP2PKHLockingScriptHex(address string) (string, error) {
+	addr, err := script.NewAddressFromString(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address: %w", err)
+	}
+
 	// P2PKH script: OP_DUP OP_HASH160 <pubKeyHash> OP_EQUALVERIFY OP_CHECKSIG
-	
-	// For now, return a dummy script
-	// In real implementation, extract the pubkey hash from the address
-	return "76a914" + strings.Repeat("00", 20) + "88ac"
-}
\ No newline at end of file
+	lockingScript, err := p2pkh.Lock(addr)
+	if err != nil {
+		return "", err
+	}
+
+	return lockingScript.String(), nil
+}
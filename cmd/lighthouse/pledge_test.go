@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateP2PKHLockingScriptHex(t *testing.T) {
+	t.Run("script round-trips to address", func(t *testing.T) {
+		address := "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"
+
+		scriptHex, err := createP2PKHLockingScriptHex(address)
+		require.NoError(t, err)
+
+		scriptBytes, err := hex.DecodeString(scriptHex)
+		require.NoError(t, err)
+		require.Len(t, scriptBytes, 25)
+
+		// OP_DUP OP_HASH160 <20 bytes> OP_EQUALVERIFY OP_CHECKSIG
+		assert.Equal(t, "76a914", scriptHex[:6])
+		assert.Equal(t, "751e76e8199196d454941c45d1b3a323f1433bd6", scriptHex[6:46])
+		assert.Equal(t, "88ac", scriptHex[len(scriptHex)-4:])
+
+		decoded, err := script.NewAddressFromPublicKeyHash(scriptBytes[3:23], true)
+		require.NoError(t, err)
+		assert.Equal(t, address, decoded.AddressString)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := createP2PKHLockingScriptHex("invalid-address")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid address")
+	})
+}
+
+func TestReadWalletUTXOs(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "utxos.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("valid wallet", func(t *testing.T) {
+		utxos, err := readWalletUTXOs(write(t, `[
+			{"txid": "aa", "vout": 0, "satoshis": 5000},
+			{"txid": "bb", "vout": 3, "satoshis": 120000}
+		]`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aa:0:5000", "bb:3:120000"}, utxos)
+	})
+
+	t.Run("missing txid", func(t *testing.T) {
+		_, err := readWalletUTXOs(write(t, `[{"vout": 1, "satoshis": 5000}]`))
+		assert.ErrorContains(t, err, "entry 0 has no txid")
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		_, err := readWalletUTXOs(write(t, "aa:0:5000"))
+		assert.ErrorContains(t, err, "invalid UTXO file")
+	})
+}
@@ -1,23 +1,115 @@
 package main
 
 import (
+	"bytes"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
 
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/spf13/cobra"
 	"github.com/yourusername/lighthouse/core"
 )
 
+// ProjectJSON is the machine-readable form of a project, with the files
+// written when it was created
+type ProjectJSON struct {
+	core.ProjectJSON
+	File        string `json:"file,omitempty"`
+	AuthKeyFile string `json:"authKeyFile,omitempty"`
+}
+
+// ProjectStatusJSON is the machine-readable funding status of a project
+type ProjectStatusJSON struct {
+	ProjectID   string  `json:"projectId"`
+	Title       string  `json:"title"`
+	Goal        uint64  `json:"goal"`
+	Pledged     uint64  `json:"pledged"`
+	Progress    float64 `json:"progress"`
+	Remaining   uint64  `json:"remaining"`
+	PledgeCount int     `json:"pledgeCount"`
+	CanClaim    bool    `json:"canClaim"`
+	IsExpired   bool    `json:"isExpired"`
+	Status      string  `json:"status"`
+	Duplicates  int     `json:"skippedDuplicates"`
+
+	// Verified and VerifiedProgress are only set with --verify
+	Verified         *uint64  `json:"verified,omitempty"`
+	VerifiedProgress *float64 `json:"verifiedProgress,omitempty"`
+
+	Milestones []MilestoneStatusJSON `json:"milestones,omitempty"`
+}
+
+// MilestoneStatusJSON is a project milestone and whether it has been reached
+type MilestoneStatusJSON struct {
+	Threshold   uint64 `json:"threshold"`
+	Description string `json:"description"`
+	Reached     bool   `json:"reached"`
+}
+
+// newProjectStatusJSON summarises a contract's funding, without the
+// verified totals
+func newProjectStatusJSON(project *core.Project, contract *core.Contract, duplicates int) ProjectStatusJSON {
+	status := contract.GetStatus()
+	var milestones []MilestoneStatusJSON
+	for _, m := range project.Milestones() {
+		milestones = append(milestones, MilestoneStatusJSON{
+			Threshold:   m.Threshold,
+			Description: m.Description,
+			Reached:     m.Threshold <= status.TotalPledged,
+		})
+	}
+	return ProjectStatusJSON{
+		ProjectID:   status.ProjectID,
+		Title:       project.Title(),
+		Goal:        status.GoalAmount,
+		Pledged:     status.TotalPledged,
+		Progress:    status.Progress,
+		Remaining:   status.Remaining,
+		PledgeCount: status.PledgeCount,
+		CanClaim:    status.CanClaim,
+		IsExpired:   status.IsExpired,
+		Status:      status.Status,
+		Duplicates:  duplicates,
+		Milestones:  milestones,
+	}
+}
+
+// newProjectJSON builds the JSON representation of a project
+func newProjectJSON(project *core.Project) ProjectJSON {
+	return ProjectJSON{ProjectJSON: project.JSON()}
+}
+
 // projectCreateCmd creates a new project
 func projectCreateCmd() *cobra.Command {
 	var (
-		goal        float64
+		goal        string
 		address     string
 		description string
-		minPledge   float64
+		minPledge   string
+		minPercent  float64
 		expiry      int
 		output      string
+		payouts     []string
+		coverFile   string
+		coverURL    string
+		category    string
+		tags        []string
+		milestones  []string
+		links       []string
+		dataOutput  string
+		compress    bool
+		generateKey bool
+		printKey    bool
+		authWIF     string
+		force       bool
 	)
 
 	cmd := &cobra.Command{
@@ -27,26 +119,120 @@ func projectCreateCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			title := args[0]
 			
+			if expiry < 0 {
+				return fmt.Errorf("expiry must not be negative: %d", expiry)
+			}
+			if generateKey && authWIF != "" {
+				return fmt.Errorf("use either --generate-auth-key or --auth-wif, not both")
+			}
+			if printKey && !generateKey {
+				return fmt.Errorf("--print-auth-key requires --generate-auth-key")
+			}
+			
 			// Convert BSV to satoshis
-			goalSatoshis := uint64(goal * 100000000)
-			minPledgeSatoshis := uint64(minPledge * 100000000)
+			var goalSatoshis uint64
+			if goal != "" {
+				var err error
+				goalSatoshis, err = core.BSVToSatoshis(goal)
+				if err != nil {
+					return fmt.Errorf("invalid goal: %w", err)
+				}
+			}
+			minPledgeSatoshis, err := core.BSVToSatoshis(minPledge)
+			if err != nil {
+				return fmt.Errorf("invalid minimum pledge: %w", err)
+			}
 			
 			// Create the project
-			project, err := core.NewProject(title, description, goalSatoshis, address)
-			if err != nil {
-				return fmt.Errorf("failed to create project: %w", err)
+			var project *core.Project
+			if len(payouts) > 0 {
+				if goal != "" || address != "" {
+					return fmt.Errorf("use either --goal/--address or --payout, not both")
+				}
+				
+				outputs, err := parsePayouts(payouts)
+				if err != nil {
+					return err
+				}
+				project, err = core.NewProjectWithOutputs(title, description, outputs)
+				if err != nil {
+					return fmt.Errorf("failed to create project: %w", err)
+				}
+				if project.Network() != network {
+					return fmt.Errorf("payout addresses are for %s but --network is %s", project.Network(), network)
+				}
+				goalSatoshis = project.GoalAmount()
+			} else {
+				if goalSatoshis == 0 || address == "" {
+					return fmt.Errorf("--goal and --address are required unless --payout is given")
+				}
+				
+				project, err = core.NewProject(title, description, goalSatoshis, address, network)
+				if err != nil {
+					return fmt.Errorf("failed to create project: %w", err)
+				}
 			}
 			
 			// Set minimum pledge if different from default
-			if minPledgeSatoshis > 0 && minPledgeSatoshis != project.MinPledgeAmount() {
-				// We'd need to add a method to update this in the project
-				// For now, the default is used
+			if cmd.Flags().Changed("min-pledge-percent") {
+				if err := project.SetMinPledgePercent(minPercent); err != nil {
+					return fmt.Errorf("invalid minimum pledge: %w", err)
+				}
+			} else if minPledgeSatoshis > 0 && minPledgeSatoshis != project.MinPledgeAmount() {
+				if err := project.SetMinPledgeAmount(minPledgeSatoshis); err != nil {
+					return fmt.Errorf("invalid minimum pledge: %w", err)
+				}
 			}
 			
-			// Serialize the project
-			data, err := project.Serialize()
-			if err != nil {
-				return fmt.Errorf("failed to serialize project: %w", err)
+			// Set expiry if requested
+			if expiry > 0 {
+				project.SetExpiry(time.Now().Add(time.Duration(expiry) * 24 * time.Hour))
+			}
+			
+			// Attach cover image if given
+			if coverFile != "" {
+				image, err := ioutil.ReadFile(coverFile)
+				if err != nil {
+					return fmt.Errorf("failed to read cover image: %w", err)
+				}
+				if err := project.SetCoverImage(image); err != nil {
+					return fmt.Errorf("invalid cover image: %w", err)
+				}
+			}
+			if coverURL != "" {
+				if err := project.SetCoverImageURL(coverURL); err != nil {
+					return err
+				}
+			}
+			
+			if category != "" {
+				project.SetCategory(category)
+			}
+			if len(tags) > 0 {
+				project.SetTags(tags...)
+			}
+			if len(milestones) > 0 {
+				parsed, err := parseMilestones(milestones)
+				if err != nil {
+					return err
+				}
+				if err := project.SetMilestones(parsed...); err != nil {
+					return fmt.Errorf("invalid milestones: %w", err)
+				}
+			}
+			for _, link := range links {
+				label, linkURL, ok := strings.Cut(link, "=")
+				if !ok {
+					return fmt.Errorf("invalid link %q: expected label=url", link)
+				}
+				if err := project.AddLink(label, linkURL); err != nil {
+					return err
+				}
+			}
+			if dataOutput != "" {
+				if err := project.AddDataOutput([]byte(dataOutput)); err != nil {
+					return fmt.Errorf("invalid data output: %w", err)
+				}
 			}
 			
 			// Determine output filename
@@ -54,31 +240,357 @@ func projectCreateCmd() *cobra.Command {
 				output = fmt.Sprintf("%s.lighthouse", sanitizeFilename(title))
 			}
 			
+			// Another project with the same title would otherwise be lost
+			if !force {
+				if _, err := os.Stat(output); err == nil {
+					return fmt.Errorf("%s already exists; use --force to overwrite it or -o %s to save alongside it", output, availableFilename(output))
+				}
+			}
+			
+			// Set the owner auth key so later updates and claims can be authorized
+			var authKey *ec.PrivateKey
+			authKeyFile := ""
+			if generateKey {
+				authKey, err = ec.NewPrivateKey()
+				if err != nil {
+					return fmt.Errorf("failed to generate auth key: %w", err)
+				}
+				if !printKey {
+					// Save the key before the project so a project is never
+					// written whose key was lost
+					authKeyFile = strings.TrimSuffix(output, ".lighthouse") + ".authkey"
+					if err := writeAuthKeyFile(authKeyFile, authKeyWIF(authKey, project.Network())); err != nil {
+						return err
+					}
+				}
+			} else if authWIF != "" {
+				authKey, err = ec.PrivateKeyFromWif(authWIF)
+				if err != nil {
+					return fmt.Errorf("invalid auth key WIF: %w", err)
+				}
+			}
+			if authKey != nil {
+				project.SetAuthKey(authKey.PubKey().Compressed())
+				if err := project.SignAuth(authKey); err != nil {
+					return fmt.Errorf("failed to sign project: %w", err)
+				}
+			}
+			
+			// Serialize the project
+			serialize := project.Serialize
+			if compress {
+				serialize = project.SerializeCompressed
+			}
+			data, err := serialize()
+			if err != nil {
+				return fmt.Errorf("failed to serialize project: %w", err)
+			}
+			
 			// Write to file
-			if err := ioutil.WriteFile(output, data, 0644); err != nil {
+			if err := core.AtomicWriteFile(output, data, 0644); err != nil {
 				return fmt.Errorf("failed to write project file: %w", err)
 			}
 			
+			if printKey {
+				fmt.Fprintf(os.Stderr, "Warning: this is the only copy of the project auth key. Store it securely; anyone with it can update and claim the project.\n")
+				fmt.Fprintf(os.Stderr, "Auth key (WIF): %s\n", authKeyWIF(authKey, project.Network()))
+			}
+			
+			if jsonOutput {
+				result := newProjectJSON(project)
+				result.File = output
+				result.AuthKeyFile = authKeyFile
+				return printJSON(result)
+			}
+			
 			fmt.Printf("Project created successfully!\n")
 			fmt.Printf("File: %s\n", output)
 			fmt.Printf("ID: %s\n", project.ID())
-			fmt.Printf("Goal: %.8f BSV (%d satoshis)\n", goal, goalSatoshis)
-			fmt.Printf("Address: %s\n", address)
-			fmt.Printf("Minimum pledge: %.8f BSV\n", minPledge)
+			fmt.Printf("Goal: %s BSV (%d satoshis)\n", core.SatoshisToBSV(goalSatoshis), goalSatoshis)
+			if len(payouts) > 0 {
+				for _, payout := range payouts {
+					fmt.Printf("Payout: %s\n", payout)
+				}
+			} else {
+				fmt.Printf("Address: %s\n", address)
+			}
+			fmt.Printf("Minimum pledge: %s BSV\n", core.SatoshisToBSV(project.MinPledgeAmount()))
+			if expires := project.Expires(); !expires.IsZero() {
+				fmt.Printf("Expires: %s\n", expires.Format(time.RFC1123))
+			}
+			if authKeyFile != "" {
+				fmt.Printf("Auth key: %s (keep this file private)\n", authKeyFile)
+			}
 			
 			return nil
 		},
 	}
 
-	cmd.Flags().Float64VarP(&goal, "goal", "g", 0, "Funding goal in BSV (required)")
-	cmd.Flags().StringVarP(&address, "address", "a", "", "BSV address to receive funds (required)")
+	cmd.Flags().StringVarP(&goal, "goal", "g", "", "Funding goal in BSV (required unless --payout is used)")
+	cmd.Flags().StringVarP(&address, "address", "a", "", "BSV address to receive funds (required unless --payout is used)")
+	cmd.Flags().StringSliceVar(&payouts, "payout", []string{}, "Payout output as address:amount in BSV (repeatable, goal is their sum)")
 	cmd.Flags().StringVarP(&description, "description", "d", "", "Project description")
-	cmd.Flags().Float64VarP(&minPledge, "min-pledge", "m", 0.0001, "Minimum pledge amount in BSV")
+	cmd.Flags().StringVarP(&minPledge, "min-pledge", "m", "0.0001", "Minimum pledge amount in BSV")
+	cmd.Flags().Float64Var(&minPercent, "min-pledge-percent", 0, "Minimum pledge as a percentage of the goal (0-100]")
 	cmd.Flags().IntVarP(&expiry, "expiry", "e", 0, "Days until project expires (0 = no expiry)")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output filename (default: title.lighthouse)")
+	cmd.Flags().StringVar(&coverFile, "cover", "", "Cover image file (JPEG, PNG, GIF or WebP, max 1MB)")
+	cmd.Flags().StringVar(&coverURL, "cover-url", "", "URL of a cover image hosted elsewhere (http, https or ipfs), instead of --cover")
+	cmd.Flags().StringVar(&category, "category", "", "Project category")
+	cmd.Flags().StringSliceVar(&tags, "tag", []string{}, "Project tag (repeatable)")
+	cmd.Flags().StringArrayVar(&milestones, "milestone", nil, "Stretch goal as amount:description, amount in BSV (repeatable)")
+	cmd.Flags().StringArrayVar(&links, "link", nil, "Website or social link as label=url, http(s) only (repeatable)")
+	cmd.Flags().StringVar(&dataOutput, "data", "", "Text, such as a content hash, to anchor in an OP_RETURN output of the claim (max 80 bytes)")
+	cmd.Flags().BoolVar(&compress, "compress", false, "Gzip the project file (useful with a cover image)")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the output file if it exists")
+	cmd.Flags().BoolVar(&generateKey, "generate-auth-key", false, "Generate a project auth key and save it next to the project as .authkey")
+	cmd.Flags().BoolVar(&printKey, "print-auth-key", false, "With --generate-auth-key, print the key instead of saving it")
+	cmd.Flags().StringVar(&authWIF, "auth-wif", "", "Use an existing private key in WIF format as the project auth key")
+	cmd.MarkFlagsMutuallyExclusive("min-pledge", "min-pledge-percent")
+	cmd.MarkFlagsMutuallyExclusive("cover", "cover-url")
 
-	cmd.MarkFlagRequired("goal")
-	cmd.MarkFlagRequired("address")
+	return cmd
+}
+
+// authKeyWIF encodes an auth key in WIF for the project's network
+func authKeyWIF(key *ec.PrivateKey, network string) string {
+	if network == core.NetworkTestnet {
+		return key.WifPrefix(0xef)
+	}
+	return key.Wif()
+}
+
+// writeAuthKeyFile saves a WIF private key readable only by the owner. It
+// refuses to overwrite an existing file, which may hold another project's key.
+func writeAuthKeyFile(path, wif string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create auth key file: %w", err)
+	}
+	if _, err := f.WriteString(wif + "\n"); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write auth key file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write auth key file: %w", err)
+	}
+	return nil
+}
+
+// parsePayouts parses address:amount payout flags into project outputs
+func parsePayouts(payouts []string) ([]core.ProjectOutput, error) {
+	var outputs []core.ProjectOutput
+	for _, payout := range payouts {
+		parts := strings.Split(payout, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid payout format: %s (expected address:amount)", payout)
+		}
+		
+		amount, err := core.BSVToSatoshis(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount in payout: %w", err)
+		}
+		
+		outputs = append(outputs, core.ProjectOutput{
+			Address: parts[0],
+			Amount:  amount,
+		})
+	}
+	return outputs, nil
+}
+
+// parseMilestones parses amount:description milestone flags, amounts in BSV
+func parseMilestones(flags []string) ([]core.Milestone, error) {
+	var milestones []core.Milestone
+	for _, flag := range flags {
+		parts := strings.SplitN(flag, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid milestone format: %s (expected amount:description)", flag)
+		}
+
+		threshold, err := core.BSVToSatoshis(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount in milestone: %w", err)
+		}
+
+		milestones = append(milestones, core.Milestone{
+			Threshold:   threshold,
+			Description: parts[1],
+		})
+	}
+	return milestones, nil
+}
+
+// projectCloneCmd creates a new project using an existing one as a template
+func projectCloneCmd() *cobra.Command {
+	var (
+		title       string
+		address     string
+		expiry      int
+		output      string
+		generateKey bool
+		force       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clone [project-file]",
+		Short: "Create a new project using an existing one as a template",
+		Long: `Create a new project copying the title, description, goal, minimum pledge,
+outputs and other details of an existing one.
+
+The creation time is reset and the expiry and auth key are dropped. The
+project ID covers the title and outputs, so give the clone a new --title or
+--address to tell it apart from the original.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if expiry < 0 {
+				return fmt.Errorf("expiry must not be negative: %d", expiry)
+			}
+			
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read project file: %w", err)
+			}
+			
+			original, err := core.LoadProject(data)
+			if err != nil {
+				return fmt.Errorf("failed to load project: %w", err)
+			}
+			
+			project := original.Clone()
+			if title != "" {
+				if err := project.SetTitle(title); err != nil {
+					return fmt.Errorf("invalid title: %w", err)
+				}
+			}
+			if address != "" {
+				if err := project.SetPayoutAddress(address); err != nil {
+					return fmt.Errorf("invalid address: %w", err)
+				}
+			}
+			if project.ID() == original.ID() {
+				return fmt.Errorf("clone would have the same ID as the original; use --title or --address to change it")
+			}
+			
+			if expiry > 0 {
+				project.SetExpiry(time.Now().Add(time.Duration(expiry) * 24 * time.Hour))
+			}
+			
+			if output == "" {
+				output = fmt.Sprintf("%s.lighthouse", sanitizeFilename(project.Title()))
+			}
+			if !force {
+				if _, err := os.Stat(output); err == nil {
+					return fmt.Errorf("%s already exists; use --force to overwrite it or -o %s to save alongside it", output, availableFilename(output))
+				}
+			}
+			
+			authKeyFile := ""
+			if generateKey {
+				authKey, err := ec.NewPrivateKey()
+				if err != nil {
+					return fmt.Errorf("failed to generate auth key: %w", err)
+				}
+				authKeyFile = strings.TrimSuffix(output, ".lighthouse") + ".authkey"
+				if err := writeAuthKeyFile(authKeyFile, authKeyWIF(authKey, project.Network())); err != nil {
+					return err
+				}
+				project.SetAuthKey(authKey.PubKey().Compressed())
+				if err := project.SignAuth(authKey); err != nil {
+					return fmt.Errorf("failed to sign project: %w", err)
+				}
+			}
+			
+			projectData, err := project.Serialize()
+			if err != nil {
+				return fmt.Errorf("failed to serialize project: %w", err)
+			}
+			if err := core.AtomicWriteFile(output, projectData, 0644); err != nil {
+				return fmt.Errorf("failed to write project file: %w", err)
+			}
+			
+			if jsonOutput {
+				result := newProjectJSON(project)
+				result.File = output
+				result.AuthKeyFile = authKeyFile
+				return printJSON(result)
+			}
+			
+			fmt.Printf("Project cloned!\n")
+			fmt.Printf("File: %s\n", output)
+			fmt.Printf("ID: %s\n", project.ID())
+			fmt.Printf("Title: %s\n", project.Title())
+			fmt.Printf("Goal: %s BSV\n", core.SatoshisToBSV(project.GoalAmount()))
+			if expires := project.Expires(); !expires.IsZero() {
+				fmt.Printf("Expires: %s\n", expires.Format(time.RFC1123))
+			}
+			if authKeyFile != "" {
+				fmt.Printf("Auth key: %s (keep this file private)\n", authKeyFile)
+			}
+			
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Title for the new project")
+	cmd.Flags().StringVarP(&address, "address", "a", "", "BSV address to receive funds (single-output projects only)")
+	cmd.Flags().IntVarP(&expiry, "expiry", "e", 0, "Days until the new project expires (0 = no expiry)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output filename (default: title.lighthouse)")
+	cmd.Flags().BoolVar(&generateKey, "generate-auth-key", false, "Generate a project auth key and save it next to the project as .authkey")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the output file if it exists")
+
+	return cmd
+}
+
+// projectImportCmd converts a project file from the original Lighthouse app
+func projectImportCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "import [legacy-project-file]",
+		Short: "Import a project file from the original Lighthouse app",
+		Long: `Import a project file from the original bitcoinj-based Lighthouse app.
+
+Fields with no equivalent here, such as PKI signatures, are dropped with a
+warning. Legacy pledges cannot be imported; pledgers must pledge again.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read legacy project file: %w", err)
+			}
+			
+			project, warnings, err := core.ImportLegacyProjectWithWarnings(data)
+			if err != nil {
+				return fmt.Errorf("failed to import project: %w", err)
+			}
+			for _, warning := range warnings {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+			}
+			
+			projectData, err := project.Serialize()
+			if err != nil {
+				return fmt.Errorf("failed to serialize project: %w", err)
+			}
+			
+			if output == "" {
+				output = fmt.Sprintf("%s.lighthouse", sanitizeFilename(project.Title()))
+			}
+			if err := core.AtomicWriteFile(output, projectData, 0644); err != nil {
+				return fmt.Errorf("failed to write project file: %w", err)
+			}
+			
+			fmt.Printf("Project imported!\n")
+			fmt.Printf("File: %s\n", output)
+			fmt.Printf("ID: %s\n", project.ID())
+			fmt.Printf("Title: %s\n", project.Title())
+			fmt.Printf("Goal: %s BSV\n", core.SatoshisToBSV(project.GoalAmount()))
+			
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output filename (default: title.lighthouse)")
 
 	return cmd
 }
@@ -104,18 +616,45 @@ func projectViewCmd() *cobra.Command {
 				return fmt.Errorf("failed to load project: %w", err)
 			}
 			
+			if jsonOutput {
+				return printJSON(newProjectJSON(project))
+			}
+			
 			// Display project details
 			fmt.Printf("Project: %s\n", project.Title())
 			fmt.Printf("ID: %s\n", project.ID())
 			fmt.Printf("Description: %s\n", project.Description())
-			fmt.Printf("Goal: %.8f BSV (%d satoshis)\n", 
-				float64(project.GoalAmount())/100000000, project.GoalAmount())
-			fmt.Printf("Minimum pledge: %.8f BSV\n", 
-				float64(project.MinPledgeAmount())/100000000)
+			fmt.Printf("Goal: %s BSV (%d satoshis)\n", 
+				core.SatoshisToBSV(project.GoalAmount()), project.GoalAmount())
+			fmt.Printf("Minimum pledge: %s BSV\n", 
+				core.SatoshisToBSV(project.MinPledgeAmount()))
+			if expires := project.Expires(); !expires.IsZero() {
+				fmt.Printf("Expires: %s\n", expires.Format(time.RFC1123))
+			}
+			if coverURL := project.CoverImageRef(); coverURL != "" {
+				fmt.Printf("Cover image: %s\n", coverURL)
+			}
+			if category := project.Category(); category != "" {
+				fmt.Printf("Category: %s\n", category)
+			}
+			if tags := project.Tags(); len(tags) > 0 {
+				fmt.Printf("Tags: %s\n", strings.Join(tags, ", "))
+			}
+			if links := project.Links(); len(links) > 0 {
+				fmt.Printf("Links:\n")
+				for _, link := range links {
+					fmt.Printf("  %s: %s\n", link.Label, link.URL)
+				}
+			}
 			
-			if project.IsExpired() {
+			switch {
+			case project.Status() == core.ProjectCancelled:
+				fmt.Printf("Status: CANCELLED\n")
+			case project.IsExpired():
 				fmt.Printf("Status: EXPIRED\n")
-			} else {
+			case project.Status() == core.ProjectPaused:
+				fmt.Printf("Status: Paused (not taking pledges)\n")
+			default:
 				fmt.Printf("Status: Active\n")
 			}
 			
@@ -124,13 +663,293 @@ func projectViewCmd() *cobra.Command {
 	}
 }
 
+// ProjectOutputJSON is the machine-readable form of a decoded project output
+type ProjectOutputJSON struct {
+	Index    int    `json:"index"`
+	Amount   uint64 `json:"amount"`
+	Address  string `json:"address,omitempty"`
+	Script   string `json:"script"`
+	Standard bool   `json:"standard"`
+}
+
+// projectVerifyCmd decodes a project's output scripts so pledgers can check
+// where the funds will go
+func projectVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify [project-file]",
+		Short: "Show the addresses a project pays to",
+		Long: `Decode each output script in a project back to the address it pays and
+flag any output that is not a standard P2PKH script. Check these
+addresses against the ones the campaign publishes before pledging.
+
+A signed project, made with "project sign", is rejected if it was changed
+after its creator signed it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read project file: %w", err)
+			}
+
+			signed := true
+			project, err := core.LoadSignedProject(data)
+			if errors.Is(err, core.ErrNotSignedProject) {
+				signed = false
+				project, err = core.LoadProject(data)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to load project: %w", err)
+			}
+
+			outputs, err := project.Outputs()
+			if err != nil {
+				return err
+			}
+			addresses, err := project.OutputAddresses()
+			if err != nil {
+				return err
+			}
+
+			results := make([]ProjectOutputJSON, len(outputs))
+			nonStandard := 0
+			for i, out := range outputs {
+				results[i] = ProjectOutputJSON{
+					Index:    i,
+					Amount:   out.Satoshis,
+					Address:  addresses[i],
+					Script:   hex.EncodeToString(*out.LockingScript),
+					Standard: addresses[i] != "",
+				}
+				if !results[i].Standard {
+					nonStandard++
+				}
+			}
+
+			if jsonOutput {
+				if err := printJSON(results); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("Project: %s\n", project.Title())
+				fmt.Printf("ID: %s\n", project.ID())
+				fmt.Printf("Network: %s\n", project.Network())
+				if signed {
+					fmt.Printf("Signed: yes, by auth key %s\n\n", hex.EncodeToString(project.AuthKey()))
+				} else {
+					fmt.Printf("Signed: no\n\n")
+				}
+				for _, out := range results {
+					if out.Standard {
+						fmt.Printf("Output %d: %s BSV to %s\n", out.Index, core.SatoshisToBSV(out.Amount), out.Address)
+					} else {
+						fmt.Printf("Output %d: %s BSV to NON-STANDARD script %s\n", out.Index, core.SatoshisToBSV(out.Amount), out.Script)
+					}
+				}
+			}
+
+			if nonStandard > 0 {
+				return fmt.Errorf("%d of %d outputs are not standard P2PKH scripts", nonStandard, len(results))
+			}
+			return nil
+		},
+	}
+}
+
+// projectSignCmd bundles a project with its creator's signature
+func projectSignCmd() *cobra.Command {
+	var (
+		authWIF string
+		output  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sign [project-file]",
+		Short: "Sign a project for distribution (requires the project auth key)",
+		Long: `Write a signed copy of a project: the project file, a signature over it by
+the project's auth key, and the auth public key. "project verify" checks
+the signature, so pledgers can tell the file hasn't been changed since
+its creator signed it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectFile := args[0]
+
+			data, err := ioutil.ReadFile(projectFile)
+			if err != nil {
+				return fmt.Errorf("failed to read project file: %w", err)
+			}
+
+			project, err := core.LoadProject(data)
+			if err != nil {
+				return fmt.Errorf("failed to load project: %w", err)
+			}
+
+			authKey, err := ec.PrivateKeyFromWif(authWIF)
+			if err != nil {
+				return fmt.Errorf("invalid auth key WIF: %w", err)
+			}
+
+			signed, err := project.ExportSigned(authKey)
+			if err != nil {
+				return fmt.Errorf("failed to sign project: %w", err)
+			}
+
+			if output == "" {
+				output = projectFile + ".signed"
+			}
+			if err := core.AtomicWriteFile(output, signed, 0644); err != nil {
+				return fmt.Errorf("failed to write signed project: %w", err)
+			}
+
+			fmt.Printf("Project signed!\n")
+			fmt.Printf("File: %s\n", output)
+			fmt.Printf("ID: %s\n", project.ID())
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&authWIF, "auth-wif", "", "Project auth private key in WIF format (required)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output filename (default: the project file name plus .signed)")
+	cmd.MarkFlagRequired("auth-wif")
+
+	return cmd
+}
+
+// projectUpdateCmd amends an existing project, authorized by its auth key
+func projectUpdateCmd() *cobra.Command {
+	var (
+		description string
+		coverFile   string
+		minPledge   string
+		status      string
+		authWIF     string
+		output      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update [project-file]",
+		Short: "Update project details (requires the project auth key)",
+		Long: `Update the description, cover image, minimum pledge or status of a project.
+
+The change is signed with the project's auth key. The project ID only covers
+the title, network and outputs, so existing pledges still count toward it.
+
+A paused project takes no new pledges but can still be claimed. A cancelled
+project rejects all pledges and can't be claimed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectFile := args[0]
+			
+			if description == "" && coverFile == "" && minPledge == "" && status == "" {
+				return fmt.Errorf("nothing to update: use --description, --cover, --min-pledge or --status")
+			}
+			
+			data, err := ioutil.ReadFile(projectFile)
+			if err != nil {
+				return fmt.Errorf("failed to read project file: %w", err)
+			}
+			
+			project, err := core.LoadProject(data)
+			if err != nil {
+				return fmt.Errorf("failed to load project: %w", err)
+			}
+			
+			if len(project.AuthKey()) == 0 {
+				return fmt.Errorf("project has no auth key, so updates cannot be authorized")
+			}
+			
+			authKey, err := ec.PrivateKeyFromWif(authWIF)
+			if err != nil {
+				return fmt.Errorf("invalid auth key WIF: %w", err)
+			}
+			
+			// Apply the requested changes
+			if description != "" {
+				if err := project.SetDescription(description); err != nil {
+					return fmt.Errorf("invalid description: %w", err)
+				}
+			}
+			if coverFile != "" {
+				image, err := ioutil.ReadFile(coverFile)
+				if err != nil {
+					return fmt.Errorf("failed to read cover image: %w", err)
+				}
+				if err := project.SetCoverImage(image); err != nil {
+					return fmt.Errorf("invalid cover image: %w", err)
+				}
+			}
+			if minPledge != "" {
+				minPledgeSatoshis, err := core.BSVToSatoshis(minPledge)
+				if err != nil {
+					return fmt.Errorf("invalid minimum pledge: %w", err)
+				}
+				if err := project.SetMinPledgeAmount(minPledgeSatoshis); err != nil {
+					return fmt.Errorf("invalid minimum pledge: %w", err)
+				}
+			}
+			if status != "" {
+				projectStatus, err := core.ParseProjectStatus(status)
+				if err != nil {
+					return err
+				}
+				project.SetStatus(projectStatus)
+			}
+			
+			// Sign the updated project so the edit is provably from the owner
+			if err := project.SignAuth(authKey); err != nil {
+				return fmt.Errorf("failed to sign update: %w", err)
+			}
+			
+			updated, err := project.Serialize()
+			if err != nil {
+				return fmt.Errorf("failed to serialize project: %w", err)
+			}
+			
+			if output == "" {
+				output = projectFile
+			}
+			if err := core.AtomicWriteFile(output, updated, 0644); err != nil {
+				return fmt.Errorf("failed to write project file: %w", err)
+			}
+			
+			fmt.Printf("Project updated!\n")
+			fmt.Printf("File: %s\n", output)
+			fmt.Printf("ID: %s\n", project.ID())
+			
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&description, "description", "d", "", "New project description")
+	cmd.Flags().StringVar(&coverFile, "cover", "", "Cover image file (JPEG, PNG, GIF or WebP, max 1MB)")
+	cmd.Flags().StringVarP(&minPledge, "min-pledge", "m", "", "New minimum pledge amount in BSV")
+	cmd.Flags().StringVar(&status, "status", "", "New project status: active, paused or cancelled")
+	cmd.Flags().StringVar(&authWIF, "auth-wif", "", "Project auth private key in WIF format (required)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output filename (default: overwrite the project file)")
+	cmd.MarkFlagRequired("auth-wif")
+
+	return cmd
+}
+
 // projectStatusCmd shows project funding status
 func projectStatusCmd() *cobra.Command {
-	var pledgeDir string
+	var (
+		pledgeDirs   []string
+		saveContract string
+		verify       bool
+		apiURL       string
+	)
 	
 	cmd := &cobra.Command{
 		Use:   "status [project-file]",
 		Short: "Check project funding status",
+		Long: `Check project funding status.
+
+The pledged total counts every pledge that was accepted. With --verify,
+each pledge's signatures are also checked and its inputs looked up on
+the network, and the total of the pledges that pass is shown as well. An
+input passes only if the output it spends exists, holds the value and
+script the pledge records, and is unspent.`,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			projectFile := args[0]
@@ -147,140 +966,269 @@ func projectStatusCmd() *cobra.Command {
 				return fmt.Errorf("failed to load project: %w", err)
 			}
 			
-			// Create a contract
+			// Load pledges from each directory
+			pledgeDirs = defaultPledgeDirs(projectFile, pledgeDirs)
 			contract := core.NewContract(project)
-			
-			// Load pledges from directory
-			if pledgeDir == "" {
-				pledgeDir = filepath.Dir(projectFile)
+			_, skipped := addPledgeDirs(contract, pledgeDirs)
+			duplicates := 0
+			for _, n := range skipped {
+				duplicates += n
 			}
 			
-			pledgeFiles, err := filepath.Glob(filepath.Join(pledgeDir, "*.pledge"))
-			if err != nil {
-				return fmt.Errorf("failed to list pledge files: %w", err)
+			// Bundle the accepted pledges so claim can work from one file
+			if saveContract != "" {
+				contractData, err := contract.Serialize()
+				if err != nil {
+					return fmt.Errorf("failed to serialize contract: %w", err)
+				}
+				if err := core.AtomicWriteFile(saveContract, contractData, 0644); err != nil {
+					return fmt.Errorf("failed to write contract file: %w", err)
+				}
+				fmt.Fprintf(os.Stderr, "Contract saved to %s\n", saveContract)
 			}
 			
-			// Load each pledge
-			for _, pledgeFile := range pledgeFiles {
-				pledgeData, err := ioutil.ReadFile(pledgeFile)
-				if err != nil {
-					fmt.Printf("Warning: failed to read pledge file %s: %v\n", pledgeFile, err)
-					continue
+			// Display status
+			status := newProjectStatusJSON(project, contract, duplicates)
+			if verify {
+				if apiURL == "" && project.Network() == core.NetworkTestnet {
+					apiURL = core.WhatsOnChainTestnetAPI
 				}
-				
-				pledge, err := core.LoadPledge(pledgeData)
+				total, err := contract.VerifiedTotalPledged(core.NewWhatsOnChainUTXOChecker(apiURL))
 				if err != nil {
-					fmt.Printf("Warning: failed to load pledge from %s: %v\n", pledgeFile, err)
-					continue
-				}
-				
-				if err := contract.AddPledge(pledge); err != nil {
-					fmt.Printf("Warning: failed to add pledge from %s: %v\n", pledgeFile, err)
-					continue
+					return fmt.Errorf("failed to verify pledges: %w", err)
 				}
+				progress := float64(total) / float64(project.GoalAmount()) * 100
+				status.Verified, status.VerifiedProgress = &total, &progress
+			}
+			if jsonOutput {
+				return printJSON(status)
 			}
 			
-			// Display status
-			status := contract.GetStatus()
 			fmt.Printf("Project: %s\n", project.Title())
-			fmt.Printf("Goal: %.8f BSV\n", float64(status.GoalAmount)/100000000)
-			fmt.Printf("Pledged: %.8f BSV (%.1f%%)\n", 
-				float64(status.TotalPledged)/100000000, status.Progress)
+			fmt.Printf("Goal: %s BSV\n", core.SatoshisToBSV(status.Goal))
+			fmt.Printf("Pledged: %s BSV (%.1f%%)\n", 
+				core.SatoshisToBSV(status.Pledged), status.Progress)
+			if status.Verified != nil {
+				fmt.Printf("Verified: %s BSV (%.1f%%)\n", core.SatoshisToBSV(*status.Verified), *status.VerifiedProgress)
+			}
 			fmt.Printf("Pledges: %d\n", status.PledgeCount)
+			for _, dir := range pledgeDirs {
+				if skipped[dir] > 0 {
+					fmt.Printf("Skipped duplicates in %s: %d\n", dir, skipped[dir])
+				}
+			}
+			if status.Remaining > 0 {
+				fmt.Printf("Remaining: %s BSV\n", core.SatoshisToBSV(status.Remaining))
+			}
+			if len(status.Milestones) > 0 {
+				fmt.Printf("Milestones:\n")
+				for _, m := range status.Milestones {
+					state := "pending"
+					if m.Reached {
+						state = "reached"
+					}
+					fmt.Printf("  [%s] %s BSV: %s\n", state, core.SatoshisToBSV(m.Threshold), m.Description)
+				}
+			}
 			
-			if status.CanClaim {
+			if project.Status() == core.ProjectCancelled {
+				fmt.Printf("Status: CANCELLED\n")
+			} else if status.CanClaim {
 				fmt.Printf("Status: READY TO CLAIM! 🎉\n")
 			} else if status.IsExpired {
 				fmt.Printf("Status: EXPIRED\n")
+			} else if project.Status() == core.ProjectPaused {
+				fmt.Printf("Status: Paused, not taking pledges (%.1f%% funded)\n", contract.ProgressClamped())
 			} else {
-				fmt.Printf("Status: Active (%.1f%% funded)\n", status.Progress)
+				fmt.Printf("Status: Active (%.1f%% funded)\n", contract.ProgressClamped())
 			}
 			
 			return nil
 		},
 	}
 	
-	cmd.Flags().StringVarP(&pledgeDir, "pledge-dir", "p", "", "Directory containing pledge files (default: same as project)")
+	cmd.Flags().StringArrayVarP(&pledgeDirs, "pledge-dir", "p", nil, "Directory containing pledge files, repeatable (default: same as project)")
+	cmd.Flags().StringVar(&saveContract, "save-contract", "", "Save the project and accepted pledges to a .contract file")
+	cmd.Flags().BoolVar(&verify, "verify", false, "Also show the total of pledges with valid signatures and unspent inputs")
+	cmd.Flags().StringVar(&apiURL, "api-url", "", "WhatsOnChain API base URL for --verify (default: chosen by the project's network)")
 	
 	return cmd
 }
 
-// projectClaimCmd claims funds when goal is reached
-func projectClaimCmd() *cobra.Command {
+// projectExportPledgesCmd writes a project's pledges in a spreadsheet format
+func projectExportPledgesCmd() *cobra.Command {
 	var (
-		broadcast bool
-		pledgeDir string
-		output    string
+		pledgeDirs []string
+		format     string
+		output     string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "claim [project-file]",
-		Short: "Claim funds when funding goal is reached",
-		Args:  cobra.ExactArgs(1),
+		Use:   "export-pledges [project-file]",
+		Short: "Export a project's pledges for spreadsheets",
+		Long: `Export the accepted pledges for a project, oldest first, with their ID,
+amount, memo, contact details, refund address and timestamp.
+
+Encrypted contact details are left empty.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "csv" {
+				return fmt.Errorf("unsupported format %q (use csv)", format)
+			}
+
 			projectFile := args[0]
-			
-			// Read the project file
 			data, err := ioutil.ReadFile(projectFile)
 			if err != nil {
 				return fmt.Errorf("failed to read project file: %w", err)
 			}
-			
-			// Load the project
 			project, err := core.LoadProject(data)
 			if err != nil {
 				return fmt.Errorf("failed to load project: %w", err)
 			}
-			
-			// Create a contract
+
 			contract := core.NewContract(project)
-			
-			// Load pledges from directory
-			if pledgeDir == "" {
-				pledgeDir = filepath.Dir(projectFile)
+			addPledgeDirs(contract, defaultPledgeDirs(projectFile, pledgeDirs))
+
+			var buf bytes.Buffer
+			if err := core.PledgesToCSV(&buf, contract.PledgesByTime()); err != nil {
+				return fmt.Errorf("failed to write CSV: %w", err)
 			}
-			
-			pledgeFiles, err := filepath.Glob(filepath.Join(pledgeDir, "*.pledge"))
-			if err != nil {
-				return fmt.Errorf("failed to list pledge files: %w", err)
+
+			if output == "" {
+				_, err := os.Stdout.Write(buf.Bytes())
+				return err
 			}
+			if err := core.AtomicWriteFile(output, buf.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write export file: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Exported %d pledges to %s\n", len(contract.Pledges()), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&pledgeDirs, "pledge-dir", "p", nil, "Directory containing pledge files, repeatable (default: same as project)")
+	cmd.Flags().StringVar(&format, "format", "csv", "Export format (csv)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
+
+// projectClaimCmd claims funds when goal is reached
+func projectClaimCmd() *cobra.Command {
+	var (
+		broadcast     bool
+		broadcastURL  string
+		timeout       time.Duration
+		retries       int
+		pledgeDirs    []string
+		output        string
+		skipUTXOCheck bool
+		dryRun        bool
+		selectMinimal bool
+		force         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "claim [project-file|contract-file]",
+		Short: "Claim funds when funding goal is reached",
+		Long: `Claim funds when funding goal is reached.
+
+Pass either a project file, whose pledges are loaded from --pledge-dir, or a
+.contract file saved by "project status --save-contract".`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectFile := args[0]
 			
-			if len(pledgeFiles) == 0 {
-				return fmt.Errorf("no pledge files found in %s", pledgeDir)
+			if dryRun && broadcast {
+				return fmt.Errorf("--dry-run and --broadcast cannot be used together")
 			}
 			
-			// Load each pledge
-			fmt.Printf("Loading %d pledges...\n", len(pledgeFiles))
-			for _, pledgeFile := range pledgeFiles {
-				pledgeData, err := ioutil.ReadFile(pledgeFile)
+			var contract *core.Contract
+			if filepath.Ext(projectFile) == ".contract" {
+				data, err := ioutil.ReadFile(projectFile)
 				if err != nil {
-					fmt.Printf("Warning: failed to read pledge file %s: %v\n", pledgeFile, err)
-					continue
+					return fmt.Errorf("failed to read contract file: %w", err)
 				}
-				
-				pledge, err := core.LoadPledge(pledgeData)
+				contract, err = core.LoadContract(data)
 				if err != nil {
-					fmt.Printf("Warning: failed to load pledge from %s: %v\n", pledgeFile, err)
-					continue
+					return fmt.Errorf("failed to load contract: %w", err)
 				}
-				
-				if err := contract.AddPledge(pledge); err != nil {
-					fmt.Printf("Warning: failed to add pledge from %s: %v\n", pledgeFile, err)
-					continue
+				fmt.Printf("Loaded contract with %d pledges\n", len(contract.Pledges()))
+			} else {
+				var err error
+				contract, err = loadContractFromDirs(projectFile, pledgeDirs)
+				if err != nil {
+					return err
+				}
+			}
+			
+			// A mainnet lookup can't find testnet outputs, which would drop
+			// every pledge, so both endpoints follow the project's network
+			apiURL, defaultBroadcastURL := core.DefaultWhatsOnChainAPI, core.DefaultBroadcastURL
+			if contract.Project().Network() == core.NetworkTestnet {
+				apiURL, defaultBroadcastURL = core.WhatsOnChainTestnetAPI, core.TestnetBroadcastURL
+			}
+			if broadcastURL == "" {
+				broadcastURL = defaultBroadcastURL
+			}
+			
+			// Make sure no pledged coins were spent before we broadcast
+			if broadcast && !skipUTXOCheck {
+				fmt.Printf("Checking pledge inputs are unspent...\n")
+				invalidated, err := contract.ValidatePledges(core.NewWhatsOnChainUTXOChecker(apiURL))
+				if err != nil {
+					return fmt.Errorf("failed to verify pledge inputs: %w", err)
 				}
+				for _, id := range invalidated {
+					fmt.Printf("Warning: dropped pledge %s (inputs spent or invalid)\n", id)
+				}
+			}
+			
+			// Every pledge failing to load isn't the same as being under-funded
+			if len(contract.Pledges()) == 0 {
+				return fmt.Errorf("cannot claim: %w", core.ErrNoPledges)
 			}
 			
 			// Check if we can claim
-			if !contract.CanClaim() {
+			if !contract.GoalReached() {
 				status := contract.GetStatus()
-				return fmt.Errorf("cannot claim: only %.1f%% funded (%.8f/%.8f BSV)", 
+				return fmt.Errorf("cannot claim: only %.1f%% funded (%s/%s BSV)", 
 					status.Progress,
-					float64(status.TotalPledged)/100000000,
-					float64(status.GoalAmount)/100000000)
+					core.SatoshisToBSV(status.TotalPledged),
+					core.SatoshisToBSV(status.GoalAmount))
 			}
 			
-			// Combine the transaction
-			tx, err := contract.Combine()
+			// Pledgers of an expired project expect refunds, not a claim
+			if force {
+				contract.SetAllowExpiredClaim(true)
+			}
+			if !force && contract.Project().IsExpired() {
+				return fmt.Errorf("cannot claim: project expired on %s (use --force to claim anyway)",
+					contract.Project().Expires().Format(time.RFC1123))
+			}
+			if force && contract.Project().IsExpired() {
+				fmt.Fprintf(os.Stderr, "Warning: claiming a project that expired on %s\n", contract.Project().Expires().Format(time.RFC1123))
+			}
+			
+			// Spend only enough pledges to meet the goal with the fewest inputs
+			if selectMinimal {
+				contract.SetSelectMinimalPledges(true)
+			}
+			
+			if dryRun {
+				preview, err := contract.ClaimPreview()
+				if err != nil {
+					return fmt.Errorf("failed to combine transaction: %w", err)
+				}
+				if jsonOutput {
+					return printJSON(preview)
+				}
+				printClaimPreview(preview)
+				return nil
+			}
+			
+			// Combine the transaction, sorted so the same pledges always give the same txid
+			tx, err := contract.CombineSorted()
 			if err != nil {
 				return fmt.Errorf("failed to combine transaction: %w", err)
 			}
@@ -291,21 +1239,52 @@ func projectClaimCmd() *cobra.Command {
 				output = fmt.Sprintf("%s-claim.tx", projectFile)
 			}
 			
-			if err := ioutil.WriteFile(output, []byte(txHex), 0644); err != nil {
+			if err := core.AtomicWriteFile(output, []byte(txHex), 0644); err != nil {
 				return fmt.Errorf("failed to write transaction: %w", err)
 			}
 			
+			// An over-funded or --select-minimal claim spends only some pledges
+			claimed := contract.ClaimedPledges()
+			claimedTotal := uint64(0)
+			for _, pledge := range claimed {
+				claimedTotal += pledge.Amount()
+			}
+			
 			fmt.Printf("Claim transaction created!\n")
 			fmt.Printf("File: %s\n", output)
 			fmt.Printf("Transaction ID: %s\n", tx.TxID())
-			fmt.Printf("Total amount: %.8f BSV\n", float64(contract.TotalPledged())/100000000)
+			fmt.Printf("Pledges: %d of %d available (%d inputs)\n", len(claimed), len(contract.Pledges()), len(tx.Inputs))
+			fmt.Printf("Total amount: %s BSV\n", core.SatoshisToBSV(claimedTotal))
 			
 			if broadcast {
-				fmt.Printf("\nBroadcasting transaction...\n")
-				// TODO: Implement actual broadcasting
-				fmt.Printf("Broadcasting not yet implemented. Use a BSV node or service to broadcast the transaction.\n")
+				fmt.Printf("\nBroadcasting transaction to %s...\n", broadcastURL)
+				broadcaster := core.NewWhatsOnChainBroadcasterWithConfig(broadcastURL, core.BroadcasterConfig{
+					Timeout:    timeout,
+					MaxRetries: retries,
+				})
+				// Pledges with change spend their change transaction's output,
+				// so it has to reach the network first
+				for _, pledge := range claimed {
+					changeTx := pledge.ChangeTransaction()
+					if changeTx == nil {
+						continue
+					}
+					if _, err := broadcaster.Broadcast(changeTx); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: change transaction %s for pledge %s was not accepted (it may already be confirmed): %v\n", changeTx.TxID(), pledge.ID()[:8], err)
+					}
+				}
+				txid, err := broadcaster.Broadcast(tx)
+				if err != nil {
+					return fmt.Errorf("failed to broadcast transaction: %w", err)
+				}
+				fmt.Printf("Broadcast successful! TXID: %s\n", txid)
 			} else {
-				fmt.Printf("\nTo broadcast, use: lighthouse broadcast %s\n", output)
+				fmt.Printf("\nTo broadcast, re-run with --broadcast or submit %s to a BSV node\n", output)
+				for _, pledge := range claimed {
+					if changeTx := pledge.ChangeTransaction(); changeTx != nil {
+						fmt.Printf("Pledge %s spends change transaction %s, which must be broadcast first\n", pledge.ID()[:8], changeTx.TxID())
+					}
+				}
 			}
 			
 			return nil
@@ -313,29 +1292,206 @@ func projectClaimCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&broadcast, "broadcast", "b", false, "Broadcast the claim transaction")
-	cmd.Flags().StringVarP(&pledgeDir, "pledge-dir", "p", "", "Directory containing pledge files (default: same as project)")
+	cmd.Flags().StringVar(&broadcastURL, "broadcast-url", "", "Endpoint to submit the raw transaction to (default: WhatsOnChain for the project's network)")
+	cmd.Flags().DurationVar(&timeout, "broadcast-timeout", core.DefaultBroadcastTimeout, "Timeout for each broadcast attempt")
+	cmd.Flags().IntVar(&retries, "broadcast-retries", core.DefaultBroadcastRetries, "Retries after a network error or server error")
+	cmd.Flags().StringArrayVarP(&pledgeDirs, "pledge-dir", "p", nil, "Directory containing pledge files, repeatable (default: same as project)")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output transaction file (default: project-claim.tx)")
+	cmd.Flags().BoolVar(&skipUTXOCheck, "skip-utxo-check", false, "Do not check pledge inputs are unspent before broadcasting")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what the claim would spend and pay out without writing a transaction")
+	cmd.Flags().BoolVar(&force, "force", false, "Claim even if the project has expired")
+	cmd.Flags().BoolVar(&selectMinimal, "select-minimal", false, "Spend only the fewest pledges (by input count) needed to meet the goal")
 
 	return cmd
 }
 
-// sanitizeFilename removes invalid characters from filenames
-func sanitizeFilename(name string) string {
-	// Replace spaces with underscores
-	name = filepath.Clean(name)
+// printClaimPreview prints the breakdown of a claim transaction
+func printClaimPreview(preview *core.ClaimPreview) {
+	fmt.Printf("Claim preview (dry run, nothing written)\n")
+	fmt.Printf("Transaction ID: %s\n", preview.TxID)
+	fmt.Printf("Pledges: %d of %d available\n", preview.PledgeCount, preview.Available)
+	fmt.Printf("Inputs: %d totalling %s BSV\n", preview.InputCount, core.SatoshisToBSV(preview.TotalInputs))
+	fmt.Printf("Outputs:\n")
+	for i, out := range preview.Outputs {
+		destination := out.Address
+		if destination == "" {
+			destination = "script " + out.Script
+		}
+		fmt.Printf("  %d: %s BSV to %s\n", i, core.SatoshisToBSV(out.Amount), destination)
+	}
+	fmt.Printf("Total out: %s BSV\n", core.SatoshisToBSV(preview.TotalOutputs))
+	fmt.Printf("Fee: %d satoshis (~%d bytes)\n", preview.Fee, preview.Size)
+}
+
+// projectStatsCmd summarizes every project in a directory
+func projectStatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats [dir]",
+		Short: "Show aggregate funding stats for the projects in a directory",
+		Long: `Show aggregate funding stats for every .lighthouse project in a directory.
+
+Pledges are loaded from the same directory and matched to their projects.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) == 1 {
+				dir = args[0]
+			}
+			
+			projectFiles, err := filepath.Glob(filepath.Join(dir, "*.lighthouse"))
+			if err != nil {
+				return fmt.Errorf("failed to list projects: %w", err)
+			}
+			
+			pledges, loadErrs := core.LoadPledgesFromDir(dir, runtime.NumCPU())
+			for _, err := range loadErrs {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			
+			// Group pledges by project so each contract only sees its own
+			byProject := make(map[string][]*core.Pledge)
+			for _, pledge := range pledges {
+				byProject[pledge.ProjectID()] = append(byProject[pledge.ProjectID()], pledge)
+			}
+			
+			var contracts []*core.Contract
+			for _, file := range projectFiles {
+				data, err := ioutil.ReadFile(file)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to read %s: %v\n", file, err)
+					continue
+				}
+				project, err := core.LoadProject(data)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to load %s: %v\n", file, err)
+					continue
+				}
+				
+				contract, addErrs := core.BuildContract(project, byProject[project.ID()])
+				for _, err := range addErrs {
+					if !errors.Is(err, core.ErrDuplicatePledge) {
+						fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", file, err)
+					}
+				}
+				contracts = append(contracts, contract)
+			}
+			
+			stats := core.AggregateStatus(contracts)
+			if jsonOutput {
+				return printJSON(stats)
+			}
+			
+			if stats.ProjectCount == 0 {
+				fmt.Printf("No projects found in %s\n", dir)
+				return nil
+			}
+			
+			fmt.Printf("Projects: %d\n", stats.ProjectCount)
+			fmt.Printf("Pledges: %d\n", stats.PledgeCount)
+			fmt.Printf("Total goal: %s BSV\n", core.SatoshisToBSV(stats.TotalGoal))
+			fmt.Printf("Total pledged: %s BSV\n", core.SatoshisToBSV(stats.TotalPledged))
+			fmt.Printf("Claimable: %d\n", stats.Claimable)
+			fmt.Printf("Expired: %d\n", stats.Expired)
+			fmt.Printf("Average funding: %.1f%%\n", stats.AverageProgress)
+			
+			return nil
+		},
+	}
+}
+
+// loadContractFromDirs loads a project file and adds the pledge files in
+// pledgeDirs (default: the project's directory)
+func loadContractFromDirs(projectFile string, pledgeDirs []string) (*core.Contract, error) {
+	data, err := ioutil.ReadFile(projectFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
 	
-	// Remove or replace invalid characters
-	invalid := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|"}
-	for range invalid {
-		name = filepath.Base(name) // Get base name to remove path separators
+	project, err := core.LoadProject(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project: %w", err)
 	}
 	
-	// Replace spaces with underscores
-	for i := 0; i < len(name); i++ {
-		if name[i] == ' ' {
-			name = name[:i] + "_" + name[i+1:]
+	pledgeDirs = defaultPledgeDirs(projectFile, pledgeDirs)
+	found := countPledgeFiles(pledgeDirs)
+	if found == 0 {
+		return nil, fmt.Errorf("no pledge files found in %s", strings.Join(pledgeDirs, ", "))
+	}
+	
+	contract := core.NewContract(project)
+	added, duplicates := addPledgeDirs(contract, pledgeDirs)
+	for _, dir := range pledgeDirs {
+		if duplicates[dir] > 0 {
+			fmt.Printf("Skipped %d duplicate pledges in %s\n", duplicates[dir], dir)
 		}
 	}
+	fmt.Printf("Loaded %d of %d pledge files\n", added, found)
 	
+	return contract, nil
+}
+
+// countPledgeFiles counts the *.pledge files across dirs
+func countPledgeFiles(dirs []string) int {
+	count := 0
+	for _, dir := range dirs {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.pledge"))
+		count += len(files)
+	}
+	return count
+}
+
+// defaultPledgeDirs returns dirs, or the project file's directory if none
+// were given
+func defaultPledgeDirs(projectFile string, dirs []string) []string {
+	if len(dirs) == 0 {
+		return []string{filepath.Dir(projectFile)}
+	}
+	return dirs
+}
+
+// addPledgeDirs adds the pledges in dirs to a contract, warning about each
+// one skipped. Pledges arriving through several channels often turn up in
+// more than one directory, so duplicates are only counted, per directory.
+func addPledgeDirs(contract *core.Contract, dirs []string) (added int, duplicates map[string]int) {
+	added, errs := contract.AddPledgesFromDirs(dirs...)
+	duplicates = make(map[string]int)
+	for _, err := range errs {
+		var dirErr *core.PledgeDirError
+		if errors.Is(err, core.ErrDuplicatePledge) && errors.As(err, &dirErr) {
+			duplicates[dirErr.Dir]++
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return added, duplicates
+}
+
+// sanitizeFilename makes a title safe to use as a filename: path
+// separators, characters invalid on Windows, control characters and spaces
+// become underscores, and leading dots are dropped so the file isn't hidden
+func sanitizeFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == ' ' || unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		return "project"
+	}
 	return name
-}
\ No newline at end of file
+}
+
+// availableFilename suggests a name like "name-2.ext" that isn't taken yet
+func availableFilename(path string) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, n, ext)
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
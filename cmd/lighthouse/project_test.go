@@ -0,0 +1,213 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/lighthouse/core"
+)
+
+func TestParsePayouts(t *testing.T) {
+	t.Run("exact amounts", func(t *testing.T) {
+		outputs, err := parsePayouts([]string{
+			"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH:0.1",
+			"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6:0.00000001",
+		})
+		require.NoError(t, err)
+		require.Len(t, outputs, 2)
+		assert.Equal(t, uint64(10000000), outputs[0].Amount)
+		assert.Equal(t, uint64(1), outputs[1].Amount)
+	})
+
+	t.Run("invalid payouts", func(t *testing.T) {
+		_, err := parsePayouts([]string{"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"})
+		assert.Error(t, err)
+
+		_, err = parsePayouts([]string{"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH:0.000000001"})
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteAuthKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.authkey")
+	require.NoError(t, writeAuthKeyFile(path, "L1aW4aubDFB7yfras2S1mN3bqg9nwySY8nkoLmJebSLD5BWv3ENZ"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "L1aW4aubDFB7yfras2S1mN3bqg9nwySY8nkoLmJebSLD5BWv3ENZ\n", string(data))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// An existing key is never overwritten
+	assert.Error(t, writeAuthKeyFile(path, "other"))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "L1aW4aubDFB7yfras2S1mN3bqg9nwySY8nkoLmJebSLD5BWv3ENZ")
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Community Garden", "Community_Garden"},
+		{"Books/Records", "Books_Records"},
+		{`Back\Slash`, "Back_Slash"},
+		{"Phase 2: Launch", "Phase_2__Launch"},
+		{`What? "Why" <How> | *`, "What___Why___How_____"},
+		{"../../etc/passwd", "_.._etc_passwd"},
+		{".hidden", "hidden"},
+		{"  padded  ", "padded"},
+		{"tab\there", "tab_here"},
+		{"...", "project"},
+		{"", "project"},
+		{"Café ☕", "Café_☕"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := sanitizeFilename(tt.title)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, got, filepath.Base(got))
+		})
+	}
+}
+
+func TestAvailableFilename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Garden.lighthouse")
+	assert.Equal(t, filepath.Join(dir, "Garden-2.lighthouse"), availableFilename(path))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Garden-2.lighthouse"), nil, 0644))
+	assert.Equal(t, filepath.Join(dir, "Garden-3.lighthouse"), availableFilename(path))
+}
+
+func TestLoadContractFromDirs(t *testing.T) {
+	dir := t.TempDir()
+	project, err := core.NewProject("Load Test", "Testing pledge loading", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	data, err := project.Serialize()
+	require.NoError(t, err)
+	projectFile := filepath.Join(dir, "project.lighthouse")
+	require.NoError(t, os.WriteFile(projectFile, data, 0644))
+
+	t.Run("no pledge files", func(t *testing.T) {
+		_, err := loadContractFromDirs(projectFile, nil)
+		assert.ErrorContains(t, err, "no pledge files found")
+	})
+
+	t.Run("only invalid pledge files", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pledge"), []byte("not a pledge"), 0644))
+
+		contract, err := loadContractFromDirs(projectFile, nil)
+		require.NoError(t, err)
+		assert.Empty(t, contract.Pledges())
+
+		_, err = contract.Combine()
+		assert.ErrorIs(t, err, core.ErrNoPledges)
+	})
+}
+
+func TestProjectClaimCmdOverFunded(t *testing.T) {
+	dir := t.TempDir()
+	project, err := core.NewProject("Claim Command Test", "Testing over-funded claims", 1000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	data, err := project.Serialize()
+	require.NoError(t, err)
+	projectFile := filepath.Join(dir, "project.lighthouse")
+	require.NoError(t, os.WriteFile(projectFile, data, 0644))
+
+	writePledge := func(pledge *core.Pledge) {
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pledge.ID()+".pledge"), data, 0644))
+	}
+	writePledge(newSignedPledge(t, project, 600000, 600000))
+	writePledge(newSignedPledge(t, project, 400000, 400000))
+
+	// A pledge with change that the goal doesn't need
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+	require.NoError(t, err)
+	lockingScriptHex, err := createP2PKHLockingScriptHex(address.AddressString)
+	require.NoError(t, err)
+	txid := make([]byte, 32)
+	_, err = rand.Read(txid)
+	require.NoError(t, err)
+	utxo, err := transaction.NewUTXO(hex.EncodeToString(txid), 0, lockingScriptHex, 5000000)
+	require.NoError(t, err)
+	unneeded, err := core.NewPledgeWithChange(project, 300000, []*transaction.UTXO{utxo}, address.AddressString, core.DefaultFeeRate)
+	require.NoError(t, err)
+	require.NoError(t, unneeded.Sign([]*ec.PrivateKey{key}))
+	writePledge(unneeded)
+
+	var mu sync.Mutex
+	var broadcast []*transaction.Transaction
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			TxHex string `json:"txhex"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		tx, err := transaction.NewTransactionFromHex(body.TxHex)
+		require.NoError(t, err)
+		mu.Lock()
+		broadcast = append(broadcast, tx)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(tx.TxID().String())
+	}))
+	defer server.Close()
+
+	cmd := projectClaimCmd()
+	cmd.SetArgs([]string{projectFile, "--broadcast", "--broadcast-url", server.URL, "--skip-utxo-check", "--output", filepath.Join(dir, "claim.tx")})
+	out := captureStdout(t, func() {
+		require.NoError(t, cmd.Execute())
+	})
+
+	assert.Contains(t, out, "Pledges: 2 of 3 available (2 inputs)")
+	assert.Contains(t, out, "Total amount: 0.01000000 BSV")
+
+	// Only the claim is broadcast: the unneeded pledge's change transaction
+	// stays off the network, so its coins aren't moved for nothing
+	require.Len(t, broadcast, 1)
+	claim := broadcast[0]
+	assert.Len(t, claim.Inputs, 2)
+	for _, input := range claim.Inputs {
+		assert.NotEqual(t, unneeded.ChangeTransaction().TxID().String(), input.SourceTXID.String())
+	}
+}
+
+// captureStdout returns what fn prints to standard output
+func captureStdout(t *testing.T, fn func()) string {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+	fn()
+	w.Close()
+	return string(<-done)
+}
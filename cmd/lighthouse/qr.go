@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/spf13/cobra"
+	"github.com/yourusername/lighthouse/core"
+)
+
+// projectQRCmd renders a QR code for sharing a project
+func projectQRCmd() *cobra.Command {
+	var (
+		ascii  bool
+		size   int
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "qr [project-file]",
+		Short: "Generate a QR code for sharing a project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectFile := args[0]
+
+			// Read the project file
+			data, err := ioutil.ReadFile(projectFile)
+			if err != nil {
+				return fmt.Errorf("failed to read project file: %w", err)
+			}
+
+			// Load the project
+			project, err := core.LoadProject(data)
+			if err != nil {
+				return fmt.Errorf("failed to load project: %w", err)
+			}
+
+			// Encode a reference to the project, never the serialized blob
+			uri := project.URI()
+			qr, err := qrcode.New(uri, qrcode.Medium)
+			if err != nil {
+				return fmt.Errorf("failed to generate QR code: %w", err)
+			}
+
+			if ascii {
+				fmt.Println(qr.ToSmallString(false))
+				fmt.Printf("%s\n", uri)
+				return nil
+			}
+
+			// Determine output filename
+			if output == "" {
+				baseName := strings.TrimSuffix(filepath.Base(projectFile), filepath.Ext(projectFile))
+				output = fmt.Sprintf("%s-qr.png", baseName)
+			}
+
+			if err := qr.WriteFile(size, output); err != nil {
+				return fmt.Errorf("failed to write QR code: %w", err)
+			}
+
+			fmt.Printf("QR code written to %s\n", output)
+			fmt.Printf("URI: %s\n", uri)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&ascii, "ascii", false, "Print the QR code to the terminal instead of writing a PNG")
+	cmd.Flags().IntVar(&size, "size", 256, "PNG size in pixels")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output PNG filename (default: project-qr.png)")
+
+	return cmd
+}
@@ -1,22 +1,47 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"math"
+	"mime"
+	"net"
 	"net/http"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/spf13/cobra"
+	"github.com/yourusername/lighthouse/core"
 )
 
 // serverCmd runs a lighthouse server
 func serverCmd() *cobra.Command {
 	var (
-		port    int
-		dataDir string
-		tlsCert string
-		tlsKey  string
+		port      int
+		dataDir   string
+		storeKind string
+		tlsCert   string
+		tlsKey    string
+		rateLimit int
+		maxBody   int64
+		logFormat string
 	)
 
 	cmd := &cobra.Command{
@@ -24,53 +49,102 @@ func serverCmd() *cobra.Command {
 		Short: "Run a Lighthouse server",
 		Long:  "Start a server to coordinate pledges for projects",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runServer(port, dataDir, tlsCert, tlsKey)
+			logger, err := newLogger(logFormat, os.Stderr)
+			if err != nil {
+				return err
+			}
+			slog.SetDefault(logger)
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runServer(ctx, port, storeKind, dataDir, tlsCert, tlsKey, rateLimit, maxBody)
 		},
 	}
 
 	cmd.Flags().IntVarP(&port, "port", "p", 8080, "Port to listen on")
 	cmd.Flags().StringVarP(&dataDir, "data", "d", "./lighthouse-data", "Data directory for projects and pledges")
+	cmd.Flags().StringVar(&storeKind, "store", StoreFile, "Storage backend: file (in --data) or memory (lost on exit)")
 	cmd.Flags().StringVar(&tlsCert, "tls-cert", "", "TLS certificate file")
 	cmd.Flags().StringVar(&tlsKey, "tls-key", "", "TLS key file")
+	cmd.Flags().StringVar(&logFormat, "log-format", "text", "Log format: text or json")
+	cmd.Flags().IntVar(&rateLimit, "rate-limit", 30, "Project and pledge submissions allowed per client IP per minute (0 disables)")
+	cmd.Flags().Int64Var(&maxBody, "max-body-size", defaultMaxBodySize, "Largest project or pledge submission accepted, in bytes")
 
 	return cmd
 }
 
-func runServer(port int, dataDir, tlsCert, tlsKey string) error {
-	// Ensure data directory exists
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		return fmt.Errorf("failed to create data directory: %w", err)
+// shutdownTimeout bounds how long the server waits for in-flight requests
+// to finish after a shutdown signal
+const shutdownTimeout = 30 * time.Second
+
+// runServer serves until ctx is cancelled, then stops accepting connections
+// and drains active requests so pledge writes are not cut off
+func runServer(ctx context.Context, port int, storeKind, dataDir, tlsCert, tlsKey string, rateLimit int, maxBody int64) error {
+	store, err := newStore(storeKind, dataDir)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("Starting Lighthouse server on port %d\n", port)
-	fmt.Printf("Data directory: %s\n", dataDir)
+	if storeKind == StoreMemory {
+		slog.Info("starting lighthouse server", "port", port, "store", storeKind)
+	} else {
+		slog.Info("starting lighthouse server", "port", port, "store", storeKind, "data", dataDir)
+	}
+
+	hub := NewStatusHub(maxSubscribersPerProject)
+	challenges := NewChallengeStore(claimChallengeTTL)
+	limiter := newRateLimiter(rateLimit, time.Minute)
+	claimTxs := NewClaimTxCache()
 
 	// Setup HTTP routes
 	mux := http.NewServeMux()
 
 	// Health check
-	mux.HandleFunc("/health", healthHandler)
+	mux.HandleFunc("/health", healthHandler(NewHealthChecker(store, healthStatsTTL)))
 
 	// Project routes
-	mux.HandleFunc("/api/projects", corsMiddleware(projectsHandler(dataDir)))
-	mux.HandleFunc("/api/projects/", corsMiddleware(projectHandler(dataDir)))
+	mux.HandleFunc("/api/projects", corsMiddleware(rateLimitMiddleware(limiter, projectsHandler(store, maxBody))))
+	mux.HandleFunc("/api/projects/", corsMiddleware(projectHandler(store, hub, challenges, claimTxs)))
 
 	// Pledge routes
-	mux.HandleFunc("/api/pledges", corsMiddleware(pledgesHandler(dataDir)))
+	mux.HandleFunc("/api/pledges", corsMiddleware(rateLimitMiddleware(limiter, pledgesHandler(store, hub, claimTxs, maxBody))))
 
-	// Add logging middleware
-	handler := loggingMiddleware(mux)
+	// Add compression, panic recovery and logging middleware
+	handler := loggingMiddleware(recoveryMiddleware(gzipMiddleware(mux)))
 
 	// Start server
-	addr := fmt.Sprintf(":%d", port)
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: handler,
+	}
+	// Shutdown doesn't wait for hijacked WebSocket connections, so end them
+	srv.RegisterOnShutdown(hub.Close)
 
-	if tlsCert != "" && tlsKey != "" {
-		fmt.Printf("Starting HTTPS server on %s\n", addr)
-		return http.ListenAndServeTLS(addr, tlsCert, tlsKey, handler)
-	} else {
-		fmt.Printf("Starting HTTP server on %s\n", addr)
-		return http.ListenAndServe(addr, handler)
+	serveErr := make(chan error, 1)
+	go func() {
+		if tlsCert != "" && tlsKey != "" {
+			slog.Info("listening", "addr", srv.Addr, "tls", true)
+			serveErr <- srv.ListenAndServeTLS(tlsCert, tlsKey)
+		} else {
+			slog.Info("listening", "addr", srv.Addr, "tls", false)
+			serveErr <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down", "timeout", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down cleanly: %w", err)
 	}
+	slog.Info("server stopped")
+	return nil
 }
 
 // Middleware for CORS
@@ -89,45 +163,341 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// Middleware for logging
+// rateLimiter is a per-client token bucket: each client may make up to
+// limit requests per period, refilled continuously
+type rateLimiter struct {
+	mu      sync.Mutex
+	limit   float64
+	period  time.Duration
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+// tokenBucket tracks one client's remaining requests
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// maxRateLimitBuckets is how many clients are tracked before idle ones are pruned
+const maxRateLimitBuckets = 10000
+
+// newRateLimiter allows limit requests per period per client. A limit of 0
+// or less disables rate limiting.
+func newRateLimiter(limit int, period time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:   float64(limit),
+		period:  period,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// allow takes a token for the client, or reports how long until one is free
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	if l.limit <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	rate := l.limit / l.period.Seconds() // tokens per second
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitBuckets {
+			l.prune(now)
+		}
+		bucket = &tokenBucket{tokens: l.limit, last: now}
+		l.buckets[client] = bucket
+	}
+
+	bucket.tokens = math.Min(l.limit, bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+	bucket.last = now
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / rate * float64(time.Second))
+		return false, wait
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// prune forgets clients whose buckets have refilled completely
+func (l *rateLimiter) prune(now time.Time) {
+	for client, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= l.period {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// Middleware limiting POST requests per client IP. Reads are not limited.
+func rateLimitMiddleware(limiter *rateLimiter, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			next(w, r)
+			return
+		}
+
+		client, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			client = r.RemoteAddr
+		}
+
+		if ok, wait := limiter.allow(client); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeJSONError(w, http.StatusTooManyRequests, "Rate limit exceeded")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// newLogger creates a structured logger writing text or JSON to w
+func newLogger(format string, w io.Writer) (*slog.Logger, error) {
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, nil)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, nil)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be text or json", format)
+	}
+}
+
+// statusRecorder captures the status code a handler writes
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status before passing it on
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records an implicit 200 for handlers that never call WriteHeader
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Hijack lets WebSocket upgrades take over the connection
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	if r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+// Middleware for logging each request with its status and duration
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		fmt.Printf("%s %s %s\n", r.Method, r.URL.Path, r.RemoteAddr)
-		next.ServeHTTP(w, r)
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+
+		level := slog.LevelInfo
+		if rec.status >= 500 {
+			level = slog.LevelError
+		} else if rec.status >= 400 {
+			level = slog.LevelWarn
+		}
+		slog.Log(r.Context(), level, "request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"remote", r.RemoteAddr,
+			"status", rec.status,
+			"duration", time.Since(start),
+		)
 	})
 }
 
-// Health check handler
-func healthHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"status":  "healthy",
-		"service": "lighthouse-server",
+// Middleware recovering panics in handlers, e.g. from a malformed
+// protobuf, so the client gets a 500 instead of a dropped connection
+func recoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w}
+		defer func() {
+			err := recover()
+			if err == nil {
+				return
+			}
+			// The server uses this to abort a response deliberately
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
+
+			slog.Error("panic in handler",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"error", err,
+				"stack", string(debug.Stack()),
+			)
+			// Too late for an error response if the handler already started one
+			if rec.status == 0 {
+				writeJSONError(rec, http.StatusInternalServerError, "Internal server error")
+			}
+		}()
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// gzipResponseWriter compresses everything a handler writes
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	zw          *gzip.Writer
+	wroteHeader bool
+}
+
+// WriteHeader marks the response as gzipped before passing the status on.
+// Responses that can't carry a body are left alone.
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified {
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.zw = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write compresses b, sending an implicit 200 first if needed
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.zw == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.zw.Write(b)
+}
+
+// Close flushes the compressed stream
+func (w *gzipResponseWriter) Close() error {
+	if w.zw == nil {
+		return nil
+	}
+	return w.zw.Close()
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding allows gzip
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.TrimSpace(name) != "gzip" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Middleware gzipping responses for clients that accept it. WebSocket
+// upgrades are passed through untouched.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) || r.Method == "HEAD" || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.Close()
+		next.ServeHTTP(gw, r)
 	})
 }
 
 // Projects handler
-func projectsHandler(dataDir string) http.HandlerFunc {
+func projectsHandler(store ProjectStore, maxBody int64) http.HandlerFunc {
+	projectLocks := newKeyedMutex()
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
 		switch r.Method {
 		case "GET":
-			// List all projects
-			projects, err := listProjects(dataDir)
+			// List all projects, optionally filtered by ?category= and ?tag=
+			projects, err := store.List()
 			if err != nil {
-				http.Error(w, fmt.Sprintf("Failed to list projects: %v", err), http.StatusInternalServerError)
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to list projects: %v", err))
 				return
 			}
+			query := r.URL.Query()
+			projects = filterProjects(projects, query.Get("category"), query["tag"])
+
+			if projects == nil {
+				projects = []*core.Project{}
+			}
 			json.NewEncoder(w).Encode(map[string]interface{}{"projects": projects})
 
 		case "POST":
-			// Create new project (placeholder)
-			w.WriteHeader(http.StatusNotImplemented)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": "Project creation via API not yet implemented",
-				"note":  "Use CLI: lighthouse project create",
-			})
+			// Create new project from a serialized .lighthouse body
+			data, ok := readProtobufBody(w, r, maxBody)
+			if !ok {
+				return
+			}
+
+			project, err := core.LoadProject(data)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid project: %v", err))
+				return
+			}
+
+			// Replacing a stored project needs its owner's signature, or
+			// anyone could swap in their own auth key and claim it
+			unlock := projectLocks.Lock(project.ID())
+			defer unlock()
+
+			existing, err := store.Load(project.ID())
+			switch {
+			case errors.Is(err, ErrProjectNotFound):
+			case err != nil:
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+				return
+			case sameProject(existing, project):
+				json.NewEncoder(w).Encode(map[string]interface{}{"project": existing})
+				return
+			default:
+				if err := existing.VerifyUpdate(project); err != nil {
+					writeJSONError(w, http.StatusConflict, fmt.Sprintf("Project %s already exists and the update is not signed by its auth key: %v", project.ID(), err))
+					return
+				}
+			}
+
+			if err := store.Save(project); err != nil {
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to save project: %v", err))
+				return
+			}
+
+			if existing == nil {
+				w.WriteHeader(http.StatusCreated)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"project": project})
 
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -135,23 +505,118 @@ func projectsHandler(dataDir string) http.HandlerFunc {
 	}
 }
 
+// defaultMaxBodySize caps project and pledge submissions. Projects carry
+// their cover image, so this leaves room for the largest one allowed.
+const defaultMaxBodySize = 2 << 20
+
+// protobufContentTypes are the media types accepted for serialized
+// projects and pledges
+var protobufContentTypes = map[string]bool{
+	"application/octet-stream": true,
+	"application/protobuf":     true,
+	"application/x-protobuf":   true,
+}
+
+// readProtobufBody reads a serialized project or pledge of at most limit
+// bytes. Otherwise it replies with 415 or 413 and returns false.
+func readProtobufBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, bool) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || !protobufContentTypes[mediaType] {
+		writeJSONError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/octet-stream or application/protobuf")
+		return nil, false
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body exceeds %d bytes", limit))
+			return nil, false
+		}
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Failed to read request body: %v", err))
+		return nil, false
+	}
+	return data, true
+}
+
+// sameProject reports whether two projects serialize to the same bytes, so
+// posting a stored project again is harmless
+func sameProject(a, b *core.Project) bool {
+	aData, err := a.Serialize()
+	if err != nil {
+		return false
+	}
+	bData, err := b.Serialize()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aData, bData)
+}
+
+// filterProjects keeps projects in the category (if given) that have every
+// one of the tags. Matching ignores case.
+func filterProjects(projects []*core.Project, category string, tags []string) []*core.Project {
+	var filtered []*core.Project
+	for _, project := range projects {
+		if category != "" && !strings.EqualFold(project.Category(), category) {
+			continue
+		}
+		matches := true
+		for _, tag := range tags {
+			if !project.HasTag(tag) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, project)
+		}
+	}
+	return filtered
+}
+
 // Individual project handler
-func projectHandler(dataDir string) http.HandlerFunc {
+func projectHandler(store ProjectStore, hub *StatusHub, challenges *ChallengeStore, claimTxs *ClaimTxCache) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
-		// Extract project ID from URL
-		projectID := filepath.Base(r.URL.Path)
+		// Extract project ID and optional sub-resource from URL
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/projects/"), "/", 2)
+		projectID := parts[0]
+
+		if len(parts) == 2 {
+			switch parts[1] {
+			case "status":
+				projectStatusHandler(store, projectID, w, r)
+			case "subscribe":
+				projectSubscribeHandler(store, hub, projectID, w, r)
+			case "claim":
+				projectClaimHandler(store, challenges, projectID, w, r)
+			case "claim-tx":
+				projectClaimTxHandler(store, claimTxs, projectID, w, r)
+			case "cover":
+				projectCoverHandler(store, projectID, w, r)
+			case "pledges":
+				projectPledgesHandler(store, projectID, w, r)
+			default:
+				writeJSONError(w, http.StatusNotFound, "Not found")
+			}
+			return
+		}
 
 		switch r.Method {
 		case "GET":
-			// Get project details (placeholder)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"project": map[string]string{
-					"id":      projectID,
-					"message": "Project details not yet implemented",
-				},
-			})
+			project, err := store.Load(projectID)
+			if errors.Is(err, ErrProjectNotFound) {
+				writeJSONError(w, http.StatusNotFound, "Project not found")
+				return
+			}
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+				return
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{"project": project})
 
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -159,23 +624,512 @@ func projectHandler(dataDir string) http.HandlerFunc {
 	}
 }
 
+// Project funding status handler
+func projectStatusHandler(store ProjectStore, projectID string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	project, err := store.Load(projectID)
+	if errors.Is(err, ErrProjectNotFound) {
+		writeJSONError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+		return
+	}
+
+	contract, err := loadStoredContract(store, project)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load contract: %v", err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": contract.GetStatus()})
+}
+
+// ClaimRequest is the body of an authorized claim: a challenge from
+// GET /api/projects/{id}/claim and the owner's signature over it
+type ClaimRequest struct {
+	Challenge string `json:"challenge"`
+	Signature string `json:"signature"` // hex DER signature over claimMessage
+}
+
+// Project claim handler. GET issues a challenge; POST with the challenge
+// signed by the project's auth key returns the combined claim transaction.
+func projectClaimHandler(store ProjectStore, challenges *ChallengeStore, projectID string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	project, err := store.Load(projectID)
+	if errors.Is(err, ErrProjectNotFound) {
+		writeJSONError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+		return
+	}
+	if len(project.AuthKey()) == 0 {
+		writeJSONError(w, http.StatusForbidden, "Project has no auth key, so claims cannot be authorized")
+		return
+	}
+
+	if r.Method == "GET" {
+		challenge, expires, err := challenges.Issue(projectID)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"challenge": challenge,
+			"message":   string(claimMessage(projectID, challenge)),
+			"expires":   expires,
+		})
+		return
+	}
+
+	var req ClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid claim request: %v", err))
+		return
+	}
+	if req.Challenge == "" || req.Signature == "" {
+		writeJSONError(w, http.StatusUnauthorized, "Challenge and signature are required")
+		return
+	}
+	if err := challenges.Consume(projectID, req.Challenge); err != nil {
+		writeJSONError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	sig, err := hex.DecodeString(req.Signature)
+	if err != nil {
+		writeJSONError(w, http.StatusUnauthorized, "Signature must be hex encoded")
+		return
+	}
+	if err := project.VerifyAuthMessage(claimMessage(projectID, req.Challenge), sig); err != nil {
+		writeJSONError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	contract, err := loadStoredContract(store, project)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load contract: %v", err))
+		return
+	}
+	if !contract.GoalReached() {
+		writeJSONError(w, http.StatusPreconditionFailed, fmt.Sprintf("Funding goal not reached: %d/%d",
+			contract.TotalPledged(), project.GoalAmount()))
+		return
+	}
+	if err := contract.CheckClaimable(); err != nil {
+		if errors.Is(err, core.ErrProjectExpired) {
+			writeJSONError(w, http.StatusPreconditionFailed, "Project has expired")
+		} else {
+			writeJSONError(w, http.StatusPreconditionFailed, fmt.Sprintf("Cannot claim: %v", err))
+		}
+		return
+	}
+
+	tx, err := contract.CombineSorted()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to combine transaction: %v", err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"txid": tx.TxID().String(),
+		"tx":   tx.String(),
+	})
+}
+
+// projectCoverHandler serves a project's cover image, or redirects to it if
+// it is hosted elsewhere. The project ID stays the same when the cover is
+// replaced, so the ETag also covers the image.
+func projectCoverHandler(store ProjectStore, projectID string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	project, err := store.Load(projectID)
+	if errors.Is(err, ErrProjectNotFound) {
+		writeJSONError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+		return
+	}
+
+	// A cover hosted elsewhere is only referenced by the project
+	if coverURL := project.CoverImageRef(); coverURL != "" {
+		http.Redirect(w, r, coverURL, http.StatusFound)
+		return
+	}
+
+	image, mimeType, err := project.CoverImage()
+	if errors.Is(err, core.ErrNoCoverImage) {
+		writeJSONError(w, http.StatusNotFound, "Project has no cover image")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	hash := sha256.Sum256(image)
+	w.Header().Set("Content-Type", mimeType)
+	w.Header().Set("ETag", fmt.Sprintf(`"%s-%s"`, project.ID(), hex.EncodeToString(hash[:8])))
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(image))
+}
+
+// projectPledgesHandler lists the pledges stored for a project, oldest
+// first. Unlike GET /api/pledges it returns them as stored, without
+// rebuilding the contract, so it stays cheap for projects with many pledges.
+func projectPledgesHandler(store ProjectStore, projectID string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, err := store.Load(projectID); err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			writeJSONError(w, http.StatusNotFound, "Project not found")
+		} else {
+			writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+		}
+		return
+	}
+
+	stored, err := store.LoadPledges(projectID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load pledges: %v", err))
+		return
+	}
+	core.SortPledgesByTime(stored)
+
+	pledges := []PledgeJSON{}
+	for _, pledge := range stored {
+		pledges = append(pledges, newPledgeJSON(pledge))
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"pledges": pledges})
+}
+
+// Claim transaction download. Projects without an auth key can't use the
+// authorized /claim flow, so anyone may fetch their combined transaction:
+// it only pays the project's own outputs. Projects with an auth key keep
+// claiming behind the owner's signature, so this route refuses them.
+func projectClaimTxHandler(store ProjectStore, claimTxs *ClaimTxCache, projectID string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	project, err := store.Load(projectID)
+	if errors.Is(err, ErrProjectNotFound) {
+		writeJSONError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+		return
+	}
+	if len(project.AuthKey()) > 0 {
+		writeJSONError(w, http.StatusForbidden, "Project has an auth key; request the claim transaction from the claim endpoint")
+		return
+	}
+
+	// The project can expire or be cancelled without a new pledge arriving
+	// to drop the cached transaction, so only serve it while neither holds
+	cached, generation, ok := claimTxs.Get(projectID)
+	if ok && !project.IsExpired() && project.Status() != core.ProjectCancelled {
+		json.NewEncoder(w).Encode(cached)
+		return
+	}
+
+	contract, err := loadStoredContract(store, project)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load contract: %v", err))
+		return
+	}
+	if !contract.GoalReached() {
+		writeJSONError(w, http.StatusConflict, fmt.Sprintf("Funding goal not reached: %d/%d",
+			contract.TotalPledged(), project.GoalAmount()))
+		return
+	}
+	if err := contract.CheckClaimable(); err != nil {
+		if errors.Is(err, core.ErrProjectExpired) {
+			writeJSONError(w, http.StatusConflict, "Project has expired")
+		} else {
+			writeJSONError(w, http.StatusConflict, fmt.Sprintf("Cannot claim: %v", err))
+		}
+		return
+	}
+
+	tx, err := contract.CombineSorted()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to combine transaction: %v", err))
+		return
+	}
+
+	claimTx := ClaimTxJSON{TxID: tx.TxID().String(), Tx: tx.String()}
+	claimTxs.Put(projectID, generation, claimTx)
+	json.NewEncoder(w).Encode(claimTx)
+}
+
+// upgrader accepts WebSocket connections from any origin, matching the
+// API's CORS policy
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// subscribeWriteTimeout bounds how long a push to a slow client may block
+const subscribeWriteTimeout = 10 * time.Second
+
+// Live project status over WebSocket. The current status is sent on
+// connect, then again each time a pledge for the project is accepted.
+func projectSubscribeHandler(store ProjectStore, hub *StatusHub, projectID string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	project, err := store.Load(projectID)
+	if errors.Is(err, ErrProjectNotFound) {
+		writeJSONError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+		return
+	}
+
+	contract, err := loadStoredContract(store, project)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load contract: %v", err))
+		return
+	}
+
+	updates, unsubscribe, err := hub.Subscribe(projectID)
+	if err != nil {
+		writeJSONError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	defer unsubscribe()
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade has already replied with an HTTP error
+	}
+	defer conn.Close()
+
+	// Clients don't send anything; reading detects when they go away
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	status := contract.GetStatus()
+	for {
+		conn.SetWriteDeadline(time.Now().Add(subscribeWriteTimeout))
+		if err := conn.WriteJSON(map[string]interface{}{"status": status}); err != nil {
+			return
+		}
+
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				// The hub closed because the server is shutting down
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+					time.Now().Add(subscribeWriteTimeout))
+				return
+			}
+			status = update
+		case <-disconnected:
+			return
+		}
+	}
+}
+
+// loadStoredContract builds a contract from a project's stored pledges.
+// Stored pledges that no longer fit the contract are logged and skipped.
+func loadStoredContract(store ProjectStore, project *core.Project) (*core.Contract, error) {
+	pledges, err := store.LoadPledges(project.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pledges: %w", err)
+	}
+
+	contract, errs := core.BuildContract(project, pledges)
+	for _, err := range errs {
+		slog.Warn("skipping stored pledge", "project", project.ID(), "error", err)
+	}
+	return contract, nil
+}
+
+// keyedMutex holds one lock per key, created on first use and dropped when
+// no one holds or waits for it
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	mu      sync.Mutex
+	holders int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*keyedLock)}
+}
+
+// Lock blocks until key is free and returns the function that releases it
+func (k *keyedMutex) Lock(key string) func() {
+	k.mu.Lock()
+	lock, ok := k.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		k.locks[key] = lock
+	}
+	lock.holders++
+	k.mu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+		k.mu.Lock()
+		lock.holders--
+		if lock.holders == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
 // Pledges handler
-func pledgesHandler(dataDir string) http.HandlerFunc {
+func pledgesHandler(store ProjectStore, hub *StatusHub, claimTxs *ClaimTxCache, maxBody int64) http.HandlerFunc {
+	// Checking a pledge against the stored ones and saving it must not
+	// interleave with another submission to the same project, or two
+	// pledges of one UTXO could both be accepted
+	projectLocks := newKeyedMutex()
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
 		switch r.Method {
 		case "GET":
-			// List pledges (placeholder)
-			json.NewEncoder(w).Encode(map[string]interface{}{"pledges": []string{}})
+			// List a project's pledges oldest first, for a funding timeline
+			projectID := r.URL.Query().Get("project")
+			if projectID == "" {
+				writeJSONError(w, http.StatusBadRequest, "project query parameter is required")
+				return
+			}
+			project, err := store.Load(projectID)
+			if errors.Is(err, ErrProjectNotFound) {
+				writeJSONError(w, http.StatusNotFound, "Project not found")
+				return
+			}
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+				return
+			}
+
+			contract, err := loadStoredContract(store, project)
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load contract: %v", err))
+				return
+			}
+
+			pledges := []PledgeJSON{}
+			for _, pledge := range contract.PledgesByTime() {
+				pledges = append(pledges, newPledgeJSON(pledge))
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"pledges": pledges})
 
 		case "POST":
-			// Create pledge (placeholder)
-			w.WriteHeader(http.StatusNotImplemented)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": "Pledge creation via API not yet implemented",
-				"note":  "Use CLI: lighthouse pledge create",
-			})
+			// Submit a serialized pledge
+			data, ok := readProtobufBody(w, r, maxBody)
+			if !ok {
+				return
+			}
+
+			pledge, err := core.LoadPledge(data)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid pledge: %v", err))
+				return
+			}
+
+			// The pledge must target a project we know about
+			project, err := store.Load(pledge.ProjectID())
+			if errors.Is(err, ErrProjectNotFound) {
+				writeJSONError(w, http.StatusNotFound, "Pledge targets an unknown project")
+				return
+			}
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load project: %v", err))
+				return
+			}
+
+			if err := project.CheckAcceptingPledges(); err != nil {
+				writeJSONError(w, http.StatusForbidden, err.Error())
+				return
+			}
+
+			if err := pledge.Validate(); err != nil {
+				writeJSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Pledge validation failed: %v", err))
+				return
+			}
+			// Validate only checks the sighash flag, not the signatures
+			if err := pledge.VerifySignatures(); err != nil {
+				writeJSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Pledge signature check failed: %v", err))
+				return
+			}
+
+			// Check against pledges already accepted for this project
+			unlock := projectLocks.Lock(project.ID())
+			defer unlock()
+			contract, err := loadStoredContract(store, project)
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load contract: %v", err))
+				return
+			}
+
+			if err := contract.AddPledge(pledge); err != nil {
+				// A retried submission of a pledge we already hold succeeds
+				// without storing it again
+				if errors.Is(err, core.ErrDuplicatePledge) {
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(map[string]interface{}{"pledge": newPledgeJSON(pledge)})
+					return
+				}
+				if errors.Is(err, core.ErrConflictingInputs) {
+					writeJSONError(w, http.StatusConflict, fmt.Sprintf("Pledge conflicts with an existing pledge: %v", err))
+					return
+				}
+				writeJSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Pledge rejected: %v", err))
+				return
+			}
+
+			if err := store.SavePledge(pledge); err != nil {
+				writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to save pledge: %v", err))
+				return
+			}
+			claimTxs.Invalidate(project.ID())
+			hub.Publish(project.ID(), contract.GetStatus())
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"pledge": newPledgeJSON(pledge)})
 
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -183,25 +1137,9 @@ func pledgesHandler(dataDir string) http.HandlerFunc {
 	}
 }
 
-// List projects in data directory
-func listProjects(dataDir string) ([]map[string]interface{}, error) {
-	pattern := filepath.Join(dataDir, "*.lighthouse")
-	files, err := filepath.Glob(pattern)
-	if err != nil {
-		return nil, err
-	}
-
-	var projects []map[string]interface{}
-	for _, file := range files {
-		// For now, just return basic file info
-		// In a full implementation, we'd parse the project files
-		base := filepath.Base(file)
-		projects = append(projects, map[string]interface{}{
-			"id":   base,
-			"file": file,
-			"name": base[:len(base)-11], // Remove .lighthouse extension
-		})
-	}
-
-	return projects, nil
+// writeJSONError writes an error response with a JSON body
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
@@ -0,0 +1,1006 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/lighthouse/core"
+	pb "github.com/yourusername/lighthouse/core/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProjectHandler(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	project, err := core.NewProject("Server Test", "Testing project lookup", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, project.SetMinPledgeAmount(50000))
+	expires := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	project.SetExpiry(expires)
+	require.NoError(t, store.Save(project))
+
+	handler := projectHandler(store, NewStatusHub(maxSubscribersPerProject), NewChallengeStore(claimChallengeTTL), NewClaimTxCache())
+
+	t.Run("existing project", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/projects/"+project.ID(), nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp struct {
+			Project ProjectJSON `json:"project"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, project.ID(), resp.Project.ID)
+		assert.Equal(t, "Server Test", resp.Project.Title)
+		assert.Equal(t, "Testing project lookup", resp.Project.Description)
+		assert.Equal(t, uint64(100000000), resp.Project.Goal)
+		assert.Equal(t, uint64(50000), resp.Project.MinPledge)
+		require.NotNil(t, resp.Project.Expires)
+		assert.True(t, expires.Equal(*resp.Project.Expires))
+		assert.False(t, resp.Project.HasCover)
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/projects/"+strings.Repeat("0", 64), nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("malformed ID", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/projects/..%2Fsecret", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestProjectCoverHandler(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	handler := projectHandler(store, NewStatusHub(maxSubscribersPerProject), NewChallengeStore(claimChallengeTTL), NewClaimTxCache())
+
+	image := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+	withCover, err := core.NewProject("Cover Test", "Has a cover image", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, withCover.SetCoverImage(image))
+	require.NoError(t, store.Save(withCover))
+
+	noCover, err := core.NewProject("No Cover Test", "Has no cover image", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(noCover))
+
+	hosted, err := core.NewProject("Hosted Cover Test", "Has a hosted cover image", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, hosted.SetCoverImageURL("https://example.org/cover.png"))
+	require.NoError(t, store.Save(hosted))
+
+	var etag string
+	t.Run("image served", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/projects/"+withCover.ID()+"/cover", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+		assert.Equal(t, image, rec.Body.Bytes())
+		etag = rec.Header().Get("ETag")
+		assert.Contains(t, etag, withCover.ID())
+	})
+
+	t.Run("cached copy", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/projects/"+withCover.ID()+"/cover", nil)
+		req.Header.Set("If-None-Match", etag)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.Bytes())
+	})
+
+	t.Run("hosted image", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/projects/"+hosted.ID()+"/cover", nil))
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "https://example.org/cover.png", rec.Header().Get("Location"))
+	})
+
+	t.Run("no cover image", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/projects/"+noCover.ID()+"/cover", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/projects/"+strings.Repeat("0", 64)+"/cover", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestRunServerShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runServer(ctx, 0, StoreFile, t.TempDir(), "", "", 0, defaultMaxBodySize)
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	handler := rateLimitMiddleware(limiter, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	post := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/pledges", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusCreated, post("10.0.0.1:1000").Code)
+	assert.Equal(t, http.StatusCreated, post("10.0.0.1:1001").Code)
+
+	// The third request from the same IP is refused until a token refills
+	rec := post("10.0.0.1:1002")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+
+	// Other clients and reads are unaffected
+	assert.Equal(t, http.StatusCreated, post("10.0.0.2:1000").Code)
+	req := httptest.NewRequest("GET", "/api/pledges", nil)
+	req.RemoteAddr = "10.0.0.1:1003"
+	getRec := httptest.NewRecorder()
+	handler(getRec, req)
+	assert.Equal(t, http.StatusCreated, getRec.Code)
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, http.StatusCreated, post("10.0.0.1:1004").Code)
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger("json", &buf)
+	require.NoError(t, err)
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logger)
+
+	handler := loggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSONError(w, http.StatusNotFound, "Project not found")
+	}))
+	req := httptest.NewRequest("GET", "/api/projects/abc", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "/api/projects/abc", entry["path"])
+	assert.Equal(t, float64(http.StatusNotFound), entry["status"])
+	assert.Contains(t, entry, "duration")
+
+	// WebSocket upgrades need the wrapped writer to stay hijackable
+	var _ http.Hijacker = &statusRecorder{}
+
+	_, err = newLogger("xml", &buf)
+	assert.Error(t, err)
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	var logs bytes.Buffer
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	t.Run("panicking handler", func(t *testing.T) {
+		server := httptest.NewServer(recoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var project *core.Project
+			w.Write([]byte(project.Title()))
+		})))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		require.NoError(t, err, "connection should not be dropped")
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "Internal server error", body["error"])
+		assert.Contains(t, logs.String(), "panic in handler")
+	})
+
+	t.Run("crafted project body", func(t *testing.T) {
+		server := httptest.NewServer(recoveryMiddleware(projectsHandler(NewFileStore(t.TempDir()), defaultMaxBodySize)))
+		defer server.Close()
+
+		// A zero goal would make progress NaN, which can't be encoded as JSON
+		zeroGoal, err := proto.Marshal(&pb.Project{Version: 1, Details: &pb.ProjectDetails{
+			Network: core.NetworkMainnet,
+			Outputs: []*pb.Output{{Amount: 0, Script: []byte{0x51}}},
+		}})
+		require.NoError(t, err)
+
+		tests := []struct {
+			name    string
+			body    []byte
+			message string
+		}{
+			// A project with a version but no details is rejected, not a panic
+			{"no details", []byte{0x08, 0x01}, "missing payment details"},
+			{"zero goal", zeroGoal, "amount must be greater than 0"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resp, err := http.Post(server.URL, "application/octet-stream", bytes.NewReader(tt.body))
+				require.NoError(t, err)
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Contains(t, body["error"], tt.message)
+			})
+		}
+	})
+
+	t.Run("response already started", func(t *testing.T) {
+		handler := recoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			panic("late failure")
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Zero(t, rec.Body.Len())
+	})
+}
+
+func TestSubmissionLimits(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	handlers := map[string]http.HandlerFunc{
+		"/api/projects": projectsHandler(store, 1024),
+		"/api/pledges":  pledgesHandler(store, NewStatusHub(maxSubscribersPerProject), NewClaimTxCache(), 1024),
+	}
+
+	for path, handler := range handlers {
+		post := func(contentType string, body []byte) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+			return rec
+		}
+
+		t.Run(path+" oversized body", func(t *testing.T) {
+			rec := post("application/octet-stream", make([]byte, 1025))
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+			assert.Contains(t, rec.Body.String(), "exceeds 1024 bytes")
+		})
+
+		t.Run(path+" wrong content type", func(t *testing.T) {
+			for _, contentType := range []string{"", "application/json", "text/plain; charset=utf-8", "not a type"} {
+				rec := post(contentType, []byte{0x08, 0x01})
+				assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code, contentType)
+			}
+		})
+
+		t.Run(path+" accepted content types", func(t *testing.T) {
+			// Past the checks, the garbage body is rejected as invalid instead
+			for _, contentType := range []string{"application/octet-stream", "application/x-protobuf", "application/protobuf; proto=lighthouse.Project"} {
+				rec := post(contentType, []byte{0xff})
+				assert.Equal(t, http.StatusBadRequest, rec.Code, contentType)
+			}
+		})
+	}
+}
+
+func TestGzipMiddleware(t *testing.T) {
+	body := strings.Repeat(`{"title":"Community Garden"}`, 100)
+	handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+
+	t.Run("compresses when accepted", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/projects", nil)
+		req.Header.Set("Accept-Encoding", "br, gzip;q=0.8")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		assert.Less(t, rec.Body.Len(), len(body))
+
+		zr, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		decoded, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(decoded))
+	})
+
+	t.Run("plain otherwise", func(t *testing.T) {
+		for _, accept := range []string{"", "br", "gzip;q=0"} {
+			req := httptest.NewRequest("GET", "/api/projects", nil)
+			req.Header.Set("Accept-Encoding", accept)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Empty(t, rec.Header().Get("Content-Encoding"), accept)
+			assert.Equal(t, body, rec.Body.String(), accept)
+		}
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		req := httptest.NewRequest("GET", "/api/projects", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Zero(t, rec.Body.Len())
+	})
+}
+
+func TestProjectClaimHandler(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	challenges := NewChallengeStore(claimChallengeTTL)
+	handler := projectHandler(store, NewStatusHub(maxSubscribersPerProject), challenges, NewClaimTxCache())
+
+	authKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	project, err := core.NewProject("Claim Test", "Testing authorized claims", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	project.SetAuthKey(authKey.PubKey().Compressed())
+	require.NoError(t, store.Save(project))
+
+	claimURL := "/api/projects/" + project.ID() + "/claim"
+	issue := func() string {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", claimURL, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp struct {
+			Challenge string `json:"challenge"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.NotEmpty(t, resp.Challenge)
+		return resp.Challenge
+	}
+	claim := func(req ClaimRequest) *httptest.ResponseRecorder {
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("POST", claimURL, bytes.NewReader(body)))
+		return rec
+	}
+	sign := func(key *ec.PrivateKey, challenge string) string {
+		hash := sha256.Sum256(claimMessage(project.ID(), challenge))
+		sig, err := key.Sign(hash[:])
+		require.NoError(t, err)
+		return hex.EncodeToString(sig.Serialize())
+	}
+
+	t.Run("missing signature", func(t *testing.T) {
+		rec := claim(ClaimRequest{Challenge: issue()})
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("signature from another key", func(t *testing.T) {
+		otherKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		challenge := issue()
+		rec := claim(ClaimRequest{Challenge: challenge, Signature: sign(otherKey, challenge)})
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("unknown challenge", func(t *testing.T) {
+		challenge := strings.Repeat("ab", 32)
+		rec := claim(ClaimRequest{Challenge: challenge, Signature: sign(authKey, challenge)})
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("goal not reached", func(t *testing.T) {
+		challenge := issue()
+		rec := claim(ClaimRequest{Challenge: challenge, Signature: sign(authKey, challenge)})
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+		// Challenges are single use
+		rec = claim(ClaimRequest{Challenge: challenge, Signature: sign(authKey, challenge)})
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestProjectClaimTxHandler(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	hub := NewStatusHub(maxSubscribersPerProject)
+	claimTxs := NewClaimTxCache()
+	handler := projectHandler(store, hub, NewChallengeStore(claimChallengeTTL), claimTxs)
+	submit := pledgesHandler(store, hub, claimTxs, defaultMaxBodySize)
+
+	project, err := core.NewProject("Claim Tx Test", "Testing claim downloads", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(project))
+
+	download := func(projectID string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/projects/"+projectID+"/claim-tx", nil))
+		return rec
+	}
+	pledge := func(amount, satoshis uint64) {
+		data, err := newSignedPledge(t, project, amount, satoshis).Serialize()
+		require.NoError(t, err)
+		req := httptest.NewRequest("POST", "/api/pledges", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		rec := httptest.NewRecorder()
+		submit(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	t.Run("unknown project", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, download(strings.Repeat("ab", 32)).Code)
+	})
+
+	t.Run("goal not reached", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, download(project.ID()).Code)
+		pledge(60000000, 60000000)
+		assert.Equal(t, http.StatusConflict, download(project.ID()).Code)
+	})
+
+	t.Run("claimable", func(t *testing.T) {
+		// The fee allowances pay the claim fee
+		pledge(40000000, 40000000)
+
+		rec := download(project.ID())
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var claimTx ClaimTxJSON
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&claimTx))
+		assert.Len(t, claimTx.TxID, 64)
+		assert.NotEmpty(t, claimTx.Tx)
+
+		cached, _, ok := claimTxs.Get(project.ID())
+		require.True(t, ok)
+		assert.Equal(t, claimTx, cached)
+	})
+
+	t.Run("expired after caching", func(t *testing.T) {
+		_, _, ok := claimTxs.Get(project.ID())
+		require.True(t, ok)
+
+		project.SetExpiry(time.Now().Add(-time.Hour))
+		require.NoError(t, store.Save(project))
+
+		rec := download(project.ID())
+		assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), "expired")
+	})
+
+	t.Run("projects with an auth key use the claim flow", func(t *testing.T) {
+		authKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		owned, err := core.NewProject("Owned Claim Tx Test", "Testing claim downloads", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+		require.NoError(t, err)
+		owned.SetAuthKey(authKey.PubKey().Compressed())
+		require.NoError(t, store.Save(owned))
+
+		assert.Equal(t, http.StatusForbidden, download(owned.ID()).Code)
+	})
+}
+
+func TestPledgesHandlerList(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	handler := pledgesHandler(store, NewStatusHub(maxSubscribersPerProject), NewClaimTxCache(), defaultMaxBodySize)
+
+	project, err := core.NewProject("Timeline Test", "Testing the pledge list", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(project))
+
+	// Created in order, so their timestamps increase
+	var created []*core.Pledge
+	for _, amount := range []uint64{30000000, 20000000, 10000000} {
+		pledge := newSignedPledge(t, project, amount, amount)
+		created = append(created, pledge)
+		time.Sleep(time.Millisecond)
+	}
+	for i := len(created) - 1; i >= 0; i-- {
+		data, err := created[i].Serialize()
+		require.NoError(t, err)
+		req := httptest.NewRequest("POST", "/api/pledges", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	list := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/api/pledges"+query, nil))
+		return rec
+	}
+
+	t.Run("oldest first with timestamps", func(t *testing.T) {
+		rec := list("?project=" + project.ID())
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Pledges []PledgeJSON `json:"pledges"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Len(t, resp.Pledges, len(created))
+		for i, pledge := range resp.Pledges {
+			assert.Equal(t, created[i].ID(), pledge.ID)
+			require.NotNil(t, pledge.Time)
+			assert.True(t, created[i].Time().Equal(*pledge.Time))
+			assert.False(t, pledge.NoTimestamp)
+		}
+	})
+
+	t.Run("project required", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, list("").Code)
+		assert.Equal(t, http.StatusNotFound, list("?project="+strings.Repeat("ab", 32)).Code)
+	})
+}
+
+func TestProjectPledgesHandler(t *testing.T) {
+	for _, kind := range []string{StoreFile, StoreMemory} {
+		t.Run(kind, func(t *testing.T) {
+			store, err := newStore(kind, t.TempDir())
+			require.NoError(t, err)
+			handler := projectHandler(store, NewStatusHub(maxSubscribersPerProject), NewChallengeStore(claimChallengeTTL), NewClaimTxCache())
+
+			project, err := core.NewProject("Project Pledges Test", "Testing the per-project pledge list", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+			require.NoError(t, err)
+			other, err := core.NewProject("Other Project", "Has its own pledges", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", core.NetworkMainnet)
+			require.NoError(t, err)
+			require.NoError(t, store.Save(project))
+			require.NoError(t, store.Save(other))
+
+			// Created in order, so their timestamps increase
+			var created []*core.Pledge
+			for i := 0; i < 3; i++ {
+				created = append(created, newSignedPledge(t, project, 10000000, 10000000))
+				time.Sleep(time.Millisecond)
+			}
+			for i := len(created) - 1; i >= 0; i-- {
+				require.NoError(t, store.SavePledge(created[i]))
+			}
+			require.NoError(t, store.SavePledge(newSignedPledge(t, other, 10000000, 10000000)))
+
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest("GET", "/api/projects/"+project.ID()+"/pledges", nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+			var resp struct {
+				Pledges []PledgeJSON `json:"pledges"`
+			}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			require.Len(t, resp.Pledges, len(created))
+			for i, pledge := range resp.Pledges {
+				assert.Equal(t, created[i].ID(), pledge.ID)
+				assert.Equal(t, project.ID(), pledge.ProjectID)
+			}
+
+			rec = httptest.NewRecorder()
+			handler(rec, httptest.NewRequest("GET", "/api/projects/"+strings.Repeat("ab", 32)+"/pledges", nil))
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+
+			rec = httptest.NewRecorder()
+			handler(rec, httptest.NewRequest("POST", "/api/projects/"+project.ID()+"/pledges", nil))
+			assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		})
+	}
+}
+
+func TestPledgesHandlerClosedProject(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	handler := pledgesHandler(store, NewStatusHub(maxSubscribersPerProject), NewClaimTxCache(), defaultMaxBodySize)
+
+	project, err := core.NewProject("Paused Test", "Testing paused projects", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+
+	data, err := newSignedPledge(t, project, 10000000, 10000000).Serialize()
+	require.NoError(t, err)
+	submit := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/pledges", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	for _, status := range []core.ProjectStatus{core.ProjectPaused, core.ProjectCancelled} {
+		project.SetStatus(status)
+		require.NoError(t, store.Save(project))
+
+		rec := submit()
+		assert.Equal(t, http.StatusForbidden, rec.Code, status.String())
+		assert.Contains(t, rec.Body.String(), "not accepting pledges")
+	}
+	pledges, err := store.LoadPledges(project.ID())
+	require.NoError(t, err)
+	assert.Empty(t, pledges)
+
+	project.SetStatus(core.ProjectActive)
+	require.NoError(t, store.Save(project))
+	rec := submit()
+	assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+}
+
+func TestPledgesHandlerForgedSignature(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	handler := pledgesHandler(store, NewStatusHub(maxSubscribersPerProject), NewClaimTxCache(), defaultMaxBodySize)
+
+	project, err := core.NewProject("Forgery Test", "Testing forged signatures", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(project))
+
+	data, err := newSignedPledge(t, project, 10000000, 10000000).Serialize()
+	require.NoError(t, err)
+
+	// Flip a byte inside the signature's R value, leaving the DER framing
+	// and the sighash flag intact
+	var msg pb.Pledge
+	require.NoError(t, proto.Unmarshal(data, &msg))
+	msg.Inputs[0].UnlockScript[10] ^= 0x01
+	forged, err := proto.Marshal(&msg)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/api/pledges", bytes.NewReader(forged))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "signature")
+
+	pledges, err := store.LoadPledges(project.ID())
+	require.NoError(t, err)
+	assert.Empty(t, pledges)
+}
+
+func TestPledgesHandlerRetry(t *testing.T) {
+	for _, kind := range []string{StoreFile, StoreMemory} {
+		t.Run(kind, func(t *testing.T) {
+			store, err := newStore(kind, t.TempDir())
+			require.NoError(t, err)
+			handler := pledgesHandler(store, NewStatusHub(maxSubscribersPerProject), NewClaimTxCache(), defaultMaxBodySize)
+
+			project, err := core.NewProject("Retry Test", "Testing pledge retries", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+			require.NoError(t, err)
+			require.NoError(t, store.Save(project))
+
+			submit := func(pledge *core.Pledge) *httptest.ResponseRecorder {
+				data, err := pledge.Serialize()
+				require.NoError(t, err)
+				req := httptest.NewRequest("POST", "/api/pledges", bytes.NewReader(data))
+				req.Header.Set("Content-Type", "application/octet-stream")
+				rec := httptest.NewRecorder()
+				handler(rec, req)
+				return rec
+			}
+
+			// Two different pledges of the same UTXO
+			key, err := ec.NewPrivateKey()
+			require.NoError(t, err)
+			address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+			require.NoError(t, err)
+			lockingScriptHex, err := createP2PKHLockingScriptHex(address.AddressString)
+			require.NoError(t, err)
+			txid := make([]byte, 32)
+			_, err = rand.Read(txid)
+			require.NoError(t, err)
+			newPledge := func(amount uint64) *core.Pledge {
+				utxo, err := transaction.NewUTXO(hex.EncodeToString(txid), 0, lockingScriptHex, 50000500)
+				require.NoError(t, err)
+				pledge, err := core.NewPledge(project, amount, []*transaction.UTXO{utxo})
+				require.NoError(t, err)
+				require.NoError(t, pledge.Sign([]*ec.PrivateKey{key}))
+				return pledge
+			}
+			pledge := newPledge(50000000)
+
+			rec := submit(pledge)
+			require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+			t.Run("identical retry succeeds", func(t *testing.T) {
+				rec := submit(pledge)
+				require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+				var resp struct {
+					Pledge PledgeJSON `json:"pledge"`
+				}
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, pledge.ID(), resp.Pledge.ID)
+
+				pledges, err := store.LoadPledges(project.ID())
+				require.NoError(t, err)
+				assert.Len(t, pledges, 1)
+			})
+
+			t.Run("different pledge of the same input conflicts", func(t *testing.T) {
+				other := newPledge(49999900)
+				require.NotEqual(t, pledge.ID(), other.ID())
+				assert.Equal(t, http.StatusConflict, submit(other).Code)
+
+				_, pledgeCount, err := store.Counts()
+				require.NoError(t, err)
+				assert.Equal(t, 1, pledgeCount)
+			})
+		})
+	}
+}
+
+func TestPledgesHandlerConcurrentConflicts(t *testing.T) {
+	for _, kind := range []string{StoreFile, StoreMemory} {
+		t.Run(kind, func(t *testing.T) {
+			store, err := newStore(kind, t.TempDir())
+			require.NoError(t, err)
+			// Slow reads widen the window between the conflict check and the save
+			handler := pledgesHandler(slowPledgeStore{store}, NewStatusHub(maxSubscribersPerProject), NewClaimTxCache(), defaultMaxBodySize)
+
+			project, err := core.NewProject("Race Test", "Testing concurrent pledges", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+			require.NoError(t, err)
+			require.NoError(t, store.Save(project))
+
+			// Different pledges, all of the same UTXO
+			key, err := ec.NewPrivateKey()
+			require.NoError(t, err)
+			address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+			require.NoError(t, err)
+			lockingScriptHex, err := createP2PKHLockingScriptHex(address.AddressString)
+			require.NoError(t, err)
+			txid := make([]byte, 32)
+			_, err = rand.Read(txid)
+			require.NoError(t, err)
+
+			const submissions = 8
+			bodies := make([][]byte, submissions)
+			for i := range bodies {
+				utxo, err := transaction.NewUTXO(hex.EncodeToString(txid), 0, lockingScriptHex, 50000500)
+				require.NoError(t, err)
+				pledge, err := core.NewPledge(project, uint64(50000000+i), []*transaction.UTXO{utxo})
+				require.NoError(t, err)
+				require.NoError(t, pledge.Sign([]*ec.PrivateKey{key}))
+				bodies[i], err = pledge.Serialize()
+				require.NoError(t, err)
+			}
+
+			codes := make([]int, submissions)
+			var wg sync.WaitGroup
+			start := make(chan struct{})
+			for i := range bodies {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					<-start
+					req := httptest.NewRequest("POST", "/api/pledges", bytes.NewReader(bodies[i]))
+					req.Header.Set("Content-Type", "application/octet-stream")
+					rec := httptest.NewRecorder()
+					handler(rec, req)
+					codes[i] = rec.Code
+				}(i)
+			}
+			close(start)
+			wg.Wait()
+
+			created, conflicts := 0, 0
+			for _, code := range codes {
+				switch code {
+				case http.StatusCreated:
+					created++
+				case http.StatusConflict:
+					conflicts++
+				}
+			}
+			assert.Equal(t, 1, created, "codes: %v", codes)
+			assert.Equal(t, submissions-1, conflicts, "codes: %v", codes)
+
+			pledges, err := store.LoadPledges(project.ID())
+			require.NoError(t, err)
+			assert.Len(t, pledges, 1)
+		})
+	}
+}
+
+// slowPledgeStore delays loading pledges, so unserialized submissions overlap
+type slowPledgeStore struct {
+	ProjectStore
+}
+
+func (s slowPledgeStore) LoadPledges(projectID string) ([]*core.Pledge, error) {
+	pledges, err := s.ProjectStore.LoadPledges(projectID)
+	time.Sleep(10 * time.Millisecond)
+	return pledges, err
+}
+
+// newSignedPledge creates a signed pledge funded by one UTXO of the given
+// value plus the fee allowance NewPledge requires
+func newSignedPledge(t *testing.T, project *core.Project, amount, satoshis uint64) *core.Pledge {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+	require.NoError(t, err)
+	lockingScriptHex, err := createP2PKHLockingScriptHex(address.AddressString)
+	require.NoError(t, err)
+
+	txid := make([]byte, 32)
+	_, err = rand.Read(txid)
+	require.NoError(t, err)
+	allowance, err := core.FeeAllowance(project, 1, core.DefaultFeeRate)
+	require.NoError(t, err)
+	utxo, err := transaction.NewUTXO(hex.EncodeToString(txid), 0, lockingScriptHex, satoshis+allowance)
+	require.NoError(t, err)
+
+	pledge, err := core.NewPledge(project, amount, []*transaction.UTXO{utxo})
+	require.NoError(t, err)
+	require.NoError(t, pledge.Sign([]*ec.PrivateKey{key}))
+	return pledge
+}
+
+func TestChallengeStore(t *testing.T) {
+	now := time.Now()
+	challenges := NewChallengeStore(time.Minute)
+	challenges.now = func() time.Time { return now }
+
+	challenge, expires, err := challenges.Issue("project")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Minute), expires)
+
+	// A challenge only works for the project it was issued for, and only once
+	assert.ErrorIs(t, challenges.Consume("other", challenge), ErrChallengeNotFound)
+	challenge, _, err = challenges.Issue("project")
+	require.NoError(t, err)
+	assert.NoError(t, challenges.Consume("project", challenge))
+	assert.ErrorIs(t, challenges.Consume("project", challenge), ErrChallengeNotFound)
+
+	challenge, _, err = challenges.Issue("project")
+	require.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	assert.ErrorIs(t, challenges.Consume("project", challenge), ErrChallengeNotFound)
+}
+
+func TestProjectsHandlerFilter(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	newProject := func(title, category string, tags ...string) {
+		project, err := core.NewProject(title, "Testing list filters", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+		require.NoError(t, err)
+		project.SetCategory(category)
+		project.SetTags(tags...)
+		require.NoError(t, store.Save(project))
+	}
+	newProject("Game", "Software", "games", "open-source")
+	newProject("Library", "Software", "open-source")
+	newProject("Album", "Music", "indie")
+
+	list := func(query string) []string {
+		rec := httptest.NewRecorder()
+		projectsHandler(store, defaultMaxBodySize)(rec, httptest.NewRequest("GET", "/api/projects"+query, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp struct {
+			Projects []ProjectJSON `json:"projects"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		var titles []string
+		for _, project := range resp.Projects {
+			titles = append(titles, project.Title)
+		}
+		return titles
+	}
+
+	assert.Len(t, list(""), 3)
+	assert.ElementsMatch(t, []string{"Game", "Library"}, list("?category=software"))
+	assert.ElementsMatch(t, []string{"Game", "Library"}, list("?tag=open-source"))
+	assert.ElementsMatch(t, []string{"Game"}, list("?tag=open-source&tag=Games"))
+	assert.ElementsMatch(t, []string{"Album"}, list("?category=Music&tag=indie"))
+	assert.Empty(t, list("?category=Film"))
+}
+
+func TestProjectsHandlerReplace(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	handler := projectsHandler(store, defaultMaxBodySize)
+	challenges := NewChallengeStore(claimChallengeTTL)
+	projectHandler := projectHandler(store, NewStatusHub(maxSubscribersPerProject), challenges, NewClaimTxCache())
+
+	newVersion := func(authKey *ec.PrivateKey, description string) *core.Project {
+		project, err := core.NewProject("Replace Test", description, 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+		require.NoError(t, err)
+		project.SetAuthKey(authKey.PubKey().Compressed())
+		return project
+	}
+	post := func(project *core.Project) *httptest.ResponseRecorder {
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		req := httptest.NewRequest("POST", "/api/projects", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	ownerKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	original := newVersion(ownerKey, "The original")
+	require.Equal(t, http.StatusCreated, post(original).Code)
+
+	t.Run("identical body", func(t *testing.T) {
+		rec := post(original)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	})
+
+	t.Run("different auth key cannot take over claims", func(t *testing.T) {
+		attackerKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		hijack := newVersion(attackerKey, "The original")
+		require.NoError(t, hijack.SignAuth(attackerKey))
+		require.Equal(t, original.ID(), hijack.ID())
+
+		rec := post(hijack)
+		assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+
+		stored, err := store.Load(original.ID())
+		require.NoError(t, err)
+		assert.Equal(t, original.AuthKey(), stored.AuthKey())
+
+		// The attacker's key still can't claim
+		claimURL := "/api/projects/" + original.ID() + "/claim"
+		rec = httptest.NewRecorder()
+		projectHandler(rec, httptest.NewRequest("GET", claimURL, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Challenge string `json:"challenge"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		hash := sha256.Sum256(claimMessage(original.ID(), resp.Challenge))
+		sig, err := attackerKey.Sign(hash[:])
+		require.NoError(t, err)
+		body, err := json.Marshal(ClaimRequest{Challenge: resp.Challenge, Signature: hex.EncodeToString(sig.Serialize())})
+		require.NoError(t, err)
+		rec = httptest.NewRecorder()
+		projectHandler(rec, httptest.NewRequest("POST", claimURL, bytes.NewReader(body)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("unsigned update", func(t *testing.T) {
+		rec := post(newVersion(ownerKey, "Quietly changed"))
+		assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	})
+
+	t.Run("update signed by the owner", func(t *testing.T) {
+		update := newVersion(ownerKey, "Updated by the owner")
+		require.NoError(t, update.SignAuth(ownerKey))
+
+		rec := post(update)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		stored, err := store.Load(original.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "Updated by the owner", stored.Description())
+	})
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/lighthouse/core"
+)
+
+// ErrProjectNotFound is returned when no project is stored under an ID
+var ErrProjectNotFound = errors.New("project not found")
+
+// Store backends selectable with the server's --store flag
+const (
+	StoreFile   = "file"
+	StoreMemory = "memory"
+)
+
+// ProjectStore holds the projects and pledges a server coordinates,
+// keyed by ID
+type ProjectStore interface {
+	Save(project *core.Project) error
+	Load(id string) (*core.Project, error)
+	List() ([]*core.Project, error)
+	// SavePledge stores a pledge under its content-hash ID, so saving
+	// the same pledge again is a no-op
+	SavePledge(pledge *core.Pledge) error
+	LoadPledges(projectID string) ([]*core.Pledge, error)
+	// Counts returns how many projects and pledges are stored
+	Counts() (projects, pledges int, err error)
+}
+
+// newStore creates the named store backend. The file store keeps its data
+// in dataDir, creating it if needed.
+func newStore(kind, dataDir string) (ProjectStore, error) {
+	switch kind {
+	case StoreFile:
+		if err := os.MkdirAll(dataDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create data directory: %w", err)
+		}
+		store := NewFileStore(dataDir)
+		if err := store.rebuildIndex(); err != nil {
+			return nil, fmt.Errorf("failed to index pledges: %w", err)
+		}
+		return store, nil
+	case StoreMemory:
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown store %q (expected %s or %s)", kind, StoreFile, StoreMemory)
+	}
+}
+
+// FileStore persists projects in a data directory keyed by project ID.
+// Pledge files are indexed by project in memory, so loading one project's
+// pledges doesn't read every pledge in the directory. The index is built
+// on first use and kept up to date by SavePledge; pledge files copied into
+// the directory while the store is open are picked up by rebuildIndex.
+type FileStore struct {
+	dir string
+
+	mu      sync.Mutex
+	pledges map[string][]string // project ID -> pledge file paths
+}
+
+// NewFileStore creates a store backed by the given directory
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Save writes a project to the store
+func (s *FileStore) Save(project *core.Project) error {
+	data, err := project.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize project: %w", err)
+	}
+
+	if err := core.AtomicWriteFile(s.projectPath(project.ID()), data, 0644); err != nil {
+		return fmt.Errorf("failed to write project file: %w", err)
+	}
+	return nil
+}
+
+// Load reads the project with the given ID
+func (s *FileStore) Load(id string) (*core.Project, error) {
+	if !validID(id) {
+		return nil, ErrProjectNotFound
+	}
+
+	data, err := ioutil.ReadFile(s.projectPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	return core.LoadProject(data)
+}
+
+// List returns all projects in the store
+func (s *FileStore) List() ([]*core.Project, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.lighthouse"))
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []*core.Project
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		project, err := core.LoadProject(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", file, err)
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// SavePledge writes a pledge to the store. Files are named by pledge ID,
+// so one that already holds this pledge is left alone; anything else at
+// that path, such as a file truncated by a crash, is replaced.
+func (s *FileStore) SavePledge(pledge *core.Pledge) error {
+	path := filepath.Join(s.dir, pledge.ID()+".pledge")
+	if !storedPledgeMatches(path, pledge.ID()) {
+		data, err := pledge.Serialize()
+		if err != nil {
+			return fmt.Errorf("failed to serialize pledge: %w", err)
+		}
+
+		if err := core.AtomicWriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write pledge file: %w", err)
+		}
+	}
+
+	// Until the index is built there is nothing to update
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pledges != nil {
+		s.indexPledge(pledge.ProjectID(), path)
+	}
+	return nil
+}
+
+// storedPledgeMatches reports whether the file at path loads as the pledge
+// with the given ID
+func storedPledgeMatches(path, id string) bool {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	stored, err := core.LoadPledge(data)
+	return err == nil && stored.ID() == id
+}
+
+// LoadPledges returns all stored pledges for a project, in file name order
+func (s *FileStore) LoadPledges(projectID string) ([]*core.Pledge, error) {
+	files, err := s.pledgeFiles(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pledges []*core.Pledge
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		pledge, err := core.LoadPledge(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", file, err)
+		}
+		pledges = append(pledges, pledge)
+	}
+
+	return pledges, nil
+}
+
+// pledgeFiles returns the indexed pledge files for a project, building the
+// index first if needed
+func (s *FileStore) pledgeFiles(projectID string) ([]string, error) {
+	s.mu.Lock()
+	built := s.pledges != nil
+	s.mu.Unlock()
+	if !built {
+		if err := s.rebuildIndex(); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.pledges[projectID]...), nil
+}
+
+// rebuildIndex scans every pledge file in the data directory and replaces
+// the project index. Files that can't be read or parsed are skipped with a
+// warning, so one corrupt file doesn't hide the rest.
+func (s *FileStore) rebuildIndex() error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.pledge"))
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string][]string)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			slog.Warn("skipping unreadable pledge file", "file", file, "error", err)
+			continue
+		}
+		pledge, err := core.LoadPledge(data)
+		if err != nil {
+			slog.Warn("skipping invalid pledge file", "file", file, "error", err)
+			continue
+		}
+		index[pledge.ProjectID()] = append(index[pledge.ProjectID()], file)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pledges = index
+	return nil
+}
+
+// indexPledge adds a pledge file to the index, keeping each project's files
+// in name order. The caller must hold s.mu.
+func (s *FileStore) indexPledge(projectID, path string) {
+	files := s.pledges[projectID]
+	i := sort.SearchStrings(files, path)
+	if i < len(files) && files[i] == path {
+		return
+	}
+	files = append(files, "")
+	copy(files[i+1:], files[i:])
+	files[i] = path
+	s.pledges[projectID] = files
+}
+
+// Counts returns how many project and pledge files are stored, without
+// loading them
+func (s *FileStore) Counts() (projects, pledges int, err error) {
+	// Glob treats a missing directory as empty, so check it exists
+	if _, err := os.Stat(s.dir); err != nil {
+		return 0, 0, err
+	}
+	projectFiles, err := filepath.Glob(filepath.Join(s.dir, "*.lighthouse"))
+	if err != nil {
+		return 0, 0, err
+	}
+	pledgeFiles, err := filepath.Glob(filepath.Join(s.dir, "*.pledge"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(projectFiles), len(pledgeFiles), nil
+}
+
+// Dir returns the data directory backing the store
+func (s *FileStore) Dir() string {
+	return s.dir
+}
+
+// projectPath returns the file path for a project ID
+func (s *FileStore) projectPath(id string) string {
+	return filepath.Join(s.dir, id+".lighthouse")
+}
+
+// validID checks that an ID is non-empty hex, as every core.IDHasher
+// produces, which also keeps user-supplied IDs from escaping the data
+// directory
+func validID(id string) bool {
+	if id == "" {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
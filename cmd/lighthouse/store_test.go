@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/lighthouse/core"
+)
+
+func TestFileStorePledgeIndex(t *testing.T) {
+	dir := t.TempDir()
+	store, err := newStore(StoreFile, dir)
+	require.NoError(t, err)
+
+	projectA, err := core.NewProject("Index Test A", "Testing the pledge index", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	projectB, err := core.NewProject("Index Test B", "Testing the pledge index", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(projectA))
+	require.NoError(t, store.Save(projectB))
+
+	want := map[string][]string{}
+	save := func(store ProjectStore, project *core.Project) *core.Pledge {
+		pledge := newSignedPledge(t, project, 10000000, 10000000)
+		require.NoError(t, store.SavePledge(pledge))
+		want[project.ID()] = append(want[project.ID()], pledge.ID())
+		sort.Strings(want[project.ID()])
+		return pledge
+	}
+	check := func(store ProjectStore) {
+		t.Helper()
+		for _, project := range []*core.Project{projectA, projectB} {
+			pledges, err := store.LoadPledges(project.ID())
+			require.NoError(t, err)
+			var ids []string
+			for _, pledge := range pledges {
+				assert.Equal(t, project.ID(), pledge.ProjectID())
+				ids = append(ids, pledge.ID())
+			}
+			assert.Equal(t, want[project.ID()], ids)
+		}
+	}
+
+	save(store, projectA)
+	save(store, projectB)
+	again := save(store, projectA)
+	check(store)
+
+	t.Run("saving a pledge twice indexes it once", func(t *testing.T) {
+		require.NoError(t, store.SavePledge(again))
+		check(store)
+	})
+
+	t.Run("truncated pledge file is replaced", func(t *testing.T) {
+		pledge := newSignedPledge(t, projectB, 10000000, 10000000)
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		// As left by a crash partway through writing
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pledge.ID()+".pledge"), data[:len(data)/2], 0644))
+
+		require.NoError(t, store.SavePledge(pledge))
+		want[projectB.ID()] = append(want[projectB.ID()], pledge.ID())
+		sort.Strings(want[projectB.ID()])
+		check(store)
+	})
+
+	t.Run("restart rebuilds the same index", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.pledge"), []byte("not a pledge"), 0644))
+
+		restarted, err := newStore(StoreFile, dir)
+		require.NoError(t, err)
+		check(restarted)
+		assert.Equal(t, store.(*FileStore).pledges, restarted.(*FileStore).pledges)
+
+		save(restarted, projectB)
+		check(restarted)
+	})
+
+	t.Run("built on first use", func(t *testing.T) {
+		check(NewFileStore(dir))
+	})
+
+	t.Run("files copied in are found after a rebuild", func(t *testing.T) {
+		pledge := newSignedPledge(t, projectA, 10000000, 10000000)
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pledge.ID()+".pledge"), data, 0644))
+
+		fileStore := store.(*FileStore)
+		pledges, err := fileStore.LoadPledges(projectA.ID())
+		require.NoError(t, err)
+		assert.Len(t, pledges, len(want[projectA.ID()]))
+
+		require.NoError(t, fileStore.rebuildIndex())
+		want[projectA.ID()] = append(want[projectA.ID()], pledge.ID())
+		sort.Strings(want[projectA.ID()])
+		check(fileStore)
+	})
+}
+
+func TestFileStoreShortIDs(t *testing.T) {
+	hasher, err := core.TruncatedSHA256Hasher(core.MinIDBytes)
+	require.NoError(t, err)
+	require.NoError(t, core.SetIDHasher(hasher))
+	defer func() { require.NoError(t, core.SetIDHasher(nil)) }()
+
+	store := NewFileStore(t.TempDir())
+	project, err := core.NewProject("Short ID Test", "Testing truncated IDs", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	require.Len(t, project.ID(), 2*core.MinIDBytes)
+	require.NoError(t, store.Save(project))
+
+	loaded, err := store.Load(project.ID())
+	require.NoError(t, err)
+	assert.Equal(t, project.ID(), loaded.ID())
+
+	for _, id := range []string{"", "../secret", "not-hex"} {
+		_, err := store.Load(id)
+		assert.ErrorIs(t, err, ErrProjectNotFound, id)
+	}
+}
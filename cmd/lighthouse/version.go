@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/lighthouse/buildinfo"
+)
+
+// versionCmd prints the version and build details
+func versionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show version and build information",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := buildinfo.Get()
+			if jsonOutput {
+				return printJSON(info)
+			}
+
+			commit := info.Commit
+			if commit == "" {
+				commit = "unknown"
+			} else if info.Modified {
+				commit += " (modified)"
+			}
+			date := info.Date
+			if date == "" {
+				date = "unknown"
+			}
+
+			fmt.Printf("lighthouse %s\n", info.Version)
+			fmt.Printf("Commit: %s\n", commit)
+			fmt.Printf("Built: %s\n", date)
+			fmt.Printf("Go: %s\n", info.GoVersion)
+			return nil
+		},
+	}
+}
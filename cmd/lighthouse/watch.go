@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/lighthouse/core"
+)
+
+// defaultWatchInterval is how often project watch re-scans by default
+const defaultWatchInterval = 5 * time.Second
+
+// watchBarWidth is the width of the watch progress bar in characters
+const watchBarWidth = 40
+
+// projectWatchCmd redraws a project's funding status as pledges arrive
+func projectWatchCmd() *cobra.Command {
+	var (
+		pledgeDirs []string
+		interval   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "watch [project-file]",
+		Short: "Watch a project's funding status live",
+		Long: `Re-scan the project and its pledge directories every --interval and
+redraw the funding progress, pledge count and a progress bar in place.
+Stop with Ctrl-C.
+
+With --json, the status is printed as one JSON object per line each time
+it changes.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("interval must be positive, got %s", interval)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return watchProject(ctx, os.Stdout, args[0], defaultPledgeDirs(args[0], pledgeDirs), interval)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&pledgeDirs, "pledge-dir", "p", nil, "Directory containing pledge files, repeatable (default: same as project)")
+	cmd.Flags().DurationVar(&interval, "interval", defaultWatchInterval, "How often to re-scan for pledges")
+
+	return cmd
+}
+
+// watchProject scans and redraws until ctx is cancelled. A scan that fails,
+// e.g. while the project file is being rewritten, is shown rather than
+// ending the watch.
+func watchProject(ctx context.Context, w io.Writer, projectFile string, pledgeDirs []string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lines int
+	var last []byte
+	for {
+		status, rejected, err := scanProject(projectFile, pledgeDirs)
+
+		if jsonOutput {
+			if err == nil {
+				data, err := json.Marshal(status)
+				if err != nil {
+					return err
+				}
+				if !bytes.Equal(data, last) {
+					fmt.Fprintf(w, "%s\n", data)
+					last = data
+				}
+			}
+		} else {
+			frame := renderWatch(projectFile, status, rejected, err, interval, time.Now())
+			// Move back to the start of the previous frame and clear it
+			if lines > 0 {
+				fmt.Fprintf(w, "\033[%dA\033[J", lines)
+			}
+			fmt.Fprint(w, frame)
+			lines = strings.Count(frame, "\n")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanProject reads the project and its pledges afresh. Pledges that fail
+// to load or are rejected are counted, not reported, so they don't scroll
+// the display; project status lists them.
+func scanProject(projectFile string, pledgeDirs []string) (ProjectStatusJSON, int, error) {
+	data, err := ioutil.ReadFile(projectFile)
+	if err != nil {
+		return ProjectStatusJSON{}, 0, fmt.Errorf("failed to read project file: %w", err)
+	}
+	project, err := core.LoadProject(data)
+	if err != nil {
+		return ProjectStatusJSON{}, 0, fmt.Errorf("failed to load project: %w", err)
+	}
+
+	contract := core.NewContract(project)
+	_, errs := contract.AddPledgesFromDirs(pledgeDirs...)
+	duplicates, rejected := 0, 0
+	for _, err := range errs {
+		if errors.Is(err, core.ErrDuplicatePledge) {
+			duplicates++
+		} else {
+			rejected++
+		}
+	}
+	return newProjectStatusJSON(project, contract, duplicates), rejected, nil
+}
+
+// renderWatch draws one frame of the watch display
+func renderWatch(projectFile string, status ProjectStatusJSON, rejected int, scanErr error, interval time.Duration, now time.Time) string {
+	var b strings.Builder
+	if scanErr != nil {
+		fmt.Fprintf(&b, "Watching %s\n\n", projectFile)
+		fmt.Fprintf(&b, "Error: %v\n", scanErr)
+	} else {
+		fmt.Fprintf(&b, "Watching %s\n\n", status.Title)
+		fmt.Fprintf(&b, "%s %5.1f%%\n", progressBar(status.Progress, watchBarWidth), status.Progress)
+		fmt.Fprintf(&b, "Pledged:   %s of %s BSV\n", core.SatoshisToBSV(status.Pledged), core.SatoshisToBSV(status.Goal))
+		fmt.Fprintf(&b, "Remaining: %s BSV\n", core.SatoshisToBSV(status.Remaining))
+		fmt.Fprintf(&b, "Pledges:   %d", status.PledgeCount)
+		if status.Duplicates > 0 {
+			fmt.Fprintf(&b, ", %d duplicate copies skipped", status.Duplicates)
+		}
+		if rejected > 0 {
+			fmt.Fprintf(&b, ", %d rejected (see project status)", rejected)
+		}
+		fmt.Fprintf(&b, "\n")
+		fmt.Fprintf(&b, "Status:    %s\n", watchState(status))
+	}
+	fmt.Fprintf(&b, "\nUpdated %s, every %s. Ctrl-C to stop.\n", now.Format("15:04:05"), interval)
+	return b.String()
+}
+
+// watchState describes the project state in the same terms as project status
+func watchState(status ProjectStatusJSON) string {
+	switch {
+	case status.Status == core.ProjectCancelled.String():
+		return "CANCELLED"
+	case status.CanClaim:
+		return "READY TO CLAIM! 🎉"
+	case status.IsExpired:
+		return "EXPIRED"
+	case status.Status == core.ProjectPaused.String():
+		return "Paused, not taking pledges"
+	default:
+		return "Active"
+	}
+}
+
+// progressBar draws percent, clamped to 0-100, as a bar width characters wide
+func progressBar(percent float64, width int) string {
+	filled := int(percent / 100 * float64(width))
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/lighthouse/core"
+)
+
+func TestProgressBar(t *testing.T) {
+	assert.Equal(t, "[░░░░░░░░░░]", progressBar(0, 10))
+	assert.Equal(t, "[████░░░░░░]", progressBar(45, 10))
+	assert.Equal(t, "[██████████]", progressBar(100, 10))
+	assert.Equal(t, "[██████████]", progressBar(250, 10))
+	assert.Equal(t, "[░░░░░░░░░░]", progressBar(-5, 10))
+}
+
+func TestWatchProject(t *testing.T) {
+	dir := t.TempDir()
+	project, err := core.NewProject("Watch Test", "Testing the live view", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+	data, err := project.Serialize()
+	require.NoError(t, err)
+	projectFile := filepath.Join(dir, "watch.lighthouse")
+	require.NoError(t, os.WriteFile(projectFile, data, 0644))
+
+	t.Run("one frame", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var out bytes.Buffer
+		require.NoError(t, watchProject(ctx, &out, projectFile, []string{dir}, time.Hour))
+		assert.Contains(t, out.String(), "Watching Watch Test")
+		assert.Contains(t, out.String(), "Pledged:   0.00000000 of 1.00000000 BSV")
+		assert.Contains(t, out.String(), "Pledges:   0\n")
+		assert.Contains(t, out.String(), "Status:    Active")
+		assert.NotContains(t, out.String(), "\033[")
+	})
+
+	t.Run("redraws in place", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var out bytes.Buffer
+		require.NoError(t, watchProject(ctx, &out, projectFile, []string{dir}, 10*time.Millisecond))
+		frames := strings.Count(out.String(), "Watching Watch Test")
+		require.Greater(t, frames, 1)
+		lines := strings.Count(renderWatch(projectFile, ProjectStatusJSON{}, 0, nil, time.Second, time.Now()), "\n")
+		assert.Equal(t, frames-1, strings.Count(out.String(), "\033["+strconv.Itoa(lines)+"A\033[J"))
+	})
+
+	t.Run("unreadable project is shown", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var out bytes.Buffer
+		require.NoError(t, watchProject(ctx, &out, filepath.Join(dir, "missing.lighthouse"), []string{dir}, time.Hour))
+		assert.Contains(t, out.String(), "Error: failed to read project file")
+	})
+
+	t.Run("json prints changes only", func(t *testing.T) {
+		jsonOutput = true
+		defer func() { jsonOutput = false }()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var out bytes.Buffer
+		require.NoError(t, watchProject(ctx, &out, projectFile, []string{dir}, 10*time.Millisecond))
+		assert.Equal(t, 1, strings.Count(out.String(), "\n"))
+		assert.Contains(t, out.String(), `"title":"Watch Test"`)
+	})
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SatoshisPerBSV is the number of satoshis in one BSV
+const SatoshisPerBSV = 100000000
+
+// MaxSatoshis is the total BSV supply in satoshis. No amount can exceed it.
+const MaxSatoshis = 21000000 * SatoshisPerBSV
+
+// BSVToSatoshis parses a decimal BSV amount such as "0.1" exactly, without
+// going through floating point. At most 8 decimal places are allowed.
+func BSVToSatoshis(bsv string) (uint64, error) {
+	bsv = strings.TrimSpace(bsv)
+	if bsv == "" {
+		return 0, errors.New("amount is empty")
+	}
+
+	whole, frac, _ := strings.Cut(bsv, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid amount %q", bsv)
+	}
+	if len(frac) > 8 {
+		return 0, fmt.Errorf("invalid amount %q: more than 8 decimal places", bsv)
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("invalid amount %q", bsv)
+	}
+
+	// Pad the fraction to exactly 8 digits of satoshis
+	digits := whole + frac + strings.Repeat("0", 8-len(frac))
+	satoshis, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil || satoshis > MaxSatoshis {
+		return 0, fmt.Errorf("invalid amount %q: exceeds 21000000 BSV", bsv)
+	}
+	return satoshis, nil
+}
+
+// SatoshisToBSV formats a satoshi amount as BSV with all 8 decimal places
+func SatoshisToBSV(sat uint64) string {
+	return fmt.Sprintf("%d.%08d", sat/SatoshisPerBSV, sat%SatoshisPerBSV)
+}
+
+// isDigits reports whether s contains only ASCII digits
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
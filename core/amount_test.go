@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBSVToSatoshis(t *testing.T) {
+	valid := []struct {
+		input    string
+		expected uint64
+	}{
+		{"1", 100000000},
+		{"0.1", 10000000},
+		{"0.00000001", 1},
+		{"0.0001", 10000},
+		{"1.5", 150000000},
+		{"21000000", 2100000000000000},
+		{"21000000.00000000", 2100000000000000},
+		{".5", 50000000},
+		{"2.", 200000000},
+		{" 0.3 ", 30000000},
+		{"0", 0},
+	}
+	for _, tc := range valid {
+		t.Run(tc.input, func(t *testing.T) {
+			satoshis, err := BSVToSatoshis(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, satoshis)
+		})
+	}
+
+	invalid := []string{
+		"",
+		".",
+		"-1",
+		"1e8",
+		"0.000000001",
+		"1.2.3",
+		"abc",
+		"21000000.00000001",
+		"99999999999999999999",
+	}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := BSVToSatoshis(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSatoshisToBSV(t *testing.T) {
+	assert.Equal(t, "0.00000001", SatoshisToBSV(1))
+	assert.Equal(t, "0.10000000", SatoshisToBSV(10000000))
+	assert.Equal(t, "21000000.00000000", SatoshisToBSV(2100000000000000))
+	assert.Equal(t, "0.00000000", SatoshisToBSV(0))
+
+	// Formatting and parsing roundtrip exactly
+	for _, sat := range []uint64{1, 12345678, 100000000, 2099999999999999} {
+		parsed, err := BSVToSatoshis(SatoshisToBSV(sat))
+		require.NoError(t, err)
+		assert.Equal(t, sat, parsed)
+	}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidArmor is returned for text that isn't a well-formed armored block
+var ErrInvalidArmor = errors.New("invalid armor")
+
+// ErrArmorChecksum is returned when an armored block's CRC does not match its data
+var ErrArmorChecksum = errors.New("armor checksum mismatch")
+
+// Armor block types
+const (
+	ArmorTypePledge  = "LIGHTHOUSE PLEDGE"
+	ArmorTypeProject = "LIGHTHOUSE PROJECT"
+)
+
+// armorLineLength is how many base64 characters go on each armored line
+const armorLineLength = 64
+
+// ArmorPledge encodes a serialized pledge as ASCII-armored text that
+// survives being pasted into chat or email
+func ArmorPledge(data []byte) string {
+	return Armor(ArmorTypePledge, data)
+}
+
+// DeArmorPledge decodes a pledge armored with ArmorPledge, verifying its checksum
+func DeArmorPledge(text string) ([]byte, error) {
+	return DeArmor(ArmorTypePledge, text)
+}
+
+// Armor wraps data in an OpenPGP-style block: a BEGIN line naming the
+// block type, base64 lines, a CRC-24 checksum line and an END line
+func Armor(blockType string, data []byte) string {
+	var b strings.Builder
+	b.WriteString("-----BEGIN " + blockType + "-----\n\n")
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > armorLineLength {
+		b.WriteString(encoded[:armorLineLength] + "\n")
+		encoded = encoded[armorLineLength:]
+	}
+	if encoded != "" {
+		b.WriteString(encoded + "\n")
+	}
+
+	crc := crc24(data)
+	b.WriteString("=" + base64.StdEncoding.EncodeToString([]byte{byte(crc >> 16), byte(crc >> 8), byte(crc)}) + "\n")
+	b.WriteString("-----END " + blockType + "-----\n")
+	return b.String()
+}
+
+// DeArmor extracts and verifies the first block of the given type in text.
+// Text around the block, such as an email signature, is ignored.
+func DeArmor(blockType, text string) ([]byte, error) {
+	begin := "-----BEGIN " + blockType + "-----"
+	end := "-----END " + blockType + "-----"
+
+	start := strings.Index(text, begin)
+	if start < 0 {
+		return nil, fmt.Errorf("%w: no %s block found", ErrInvalidArmor, blockType)
+	}
+	body := text[start+len(begin):]
+	stop := strings.Index(body, end)
+	if stop < 0 {
+		return nil, fmt.Errorf("%w: missing END line", ErrInvalidArmor)
+	}
+	body = body[:stop]
+
+	var encoded, checksum strings.Builder
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "="):
+			if checksum.Len() > 0 {
+				return nil, fmt.Errorf("%w: more than one checksum line", ErrInvalidArmor)
+			}
+			checksum.WriteString(line[1:])
+		case checksum.Len() > 0:
+			return nil, fmt.Errorf("%w: data after checksum line", ErrInvalidArmor)
+		default:
+			encoded.WriteString(line)
+		}
+	}
+	if checksum.Len() == 0 {
+		return nil, fmt.Errorf("%w: missing checksum", ErrInvalidArmor)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArmor, err)
+	}
+	crcBytes, err := base64.StdEncoding.DecodeString(checksum.String())
+	if err != nil || len(crcBytes) != 3 {
+		return nil, fmt.Errorf("%w: malformed checksum", ErrInvalidArmor)
+	}
+
+	want := uint32(crcBytes[0])<<16 | uint32(crcBytes[1])<<8 | uint32(crcBytes[2])
+	if crc24(data) != want {
+		return nil, ErrArmorChecksum
+	}
+	return data, nil
+}
+
+// crc24 computes the OpenPGP armor checksum (RFC 4880 section 6.1)
+func crc24(data []byte) uint32 {
+	const (
+		crc24Init = 0xB704CE
+		crc24Poly = 0x1864CFB
+	)
+
+	crc := uint32(crc24Init)
+	for _, b := range data {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= crc24Poly
+			}
+		}
+	}
+	return crc & 0xFFFFFF
+}
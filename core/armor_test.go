@@ -0,0 +1,90 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArmorPledge(t *testing.T) {
+	data := bytes.Repeat([]byte{0x0a, 0x20, 0xff, 0x00, 0x7e}, 40)
+
+	t.Run("roundtrip", func(t *testing.T) {
+		armored := ArmorPledge(data)
+		assert.True(t, strings.HasPrefix(armored, "-----BEGIN LIGHTHOUSE PLEDGE-----\n"))
+		assert.True(t, strings.HasSuffix(armored, "-----END LIGHTHOUSE PLEDGE-----\n"))
+		for _, line := range strings.Split(armored, "\n") {
+			assert.LessOrEqual(t, len(line), armorLineLength)
+		}
+
+		decoded, err := DeArmorPledge(armored)
+		require.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	})
+
+	t.Run("surrounding text and line endings", func(t *testing.T) {
+		armored := strings.ReplaceAll(ArmorPledge(data), "\n", "\r\n")
+		decoded, err := DeArmorPledge("Here is my pledge:\r\n\r\n" + armored + "\r\nThanks!\r\n")
+		require.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	})
+
+	t.Run("empty data", func(t *testing.T) {
+		decoded, err := DeArmorPledge(ArmorPledge(nil))
+		require.NoError(t, err)
+		assert.Empty(t, decoded)
+	})
+
+	t.Run("bad checksum", func(t *testing.T) {
+		armored := ArmorPledge(data)
+		lines := strings.Split(armored, "\n")
+		// Swap a base64 character in the first data line
+		line := []byte(lines[2])
+		if line[0] == 'A' {
+			line[0] = 'B'
+		} else {
+			line[0] = 'A'
+		}
+		lines[2] = string(line)
+
+		_, err := DeArmorPledge(strings.Join(lines, "\n"))
+		assert.ErrorIs(t, err, ErrArmorChecksum)
+	})
+
+	t.Run("malformed armor", func(t *testing.T) {
+		armored := ArmorPledge(data)
+
+		_, err := DeArmorPledge("not armored")
+		assert.ErrorIs(t, err, ErrInvalidArmor)
+
+		_, err = DeArmorPledge(strings.Replace(armored, "-----END LIGHTHOUSE PLEDGE-----", "", 1))
+		assert.ErrorIs(t, err, ErrInvalidArmor)
+
+		// A project block is not a pledge
+		_, err = DeArmorPledge(Armor(ArmorTypeProject, data))
+		assert.ErrorIs(t, err, ErrInvalidArmor)
+
+		_, err = DeArmorPledge(withoutChecksumLine(armored))
+		assert.ErrorIs(t, err, ErrInvalidArmor)
+	})
+}
+
+// withoutChecksumLine removes the checksum line from an armored block
+func withoutChecksumLine(armored string) string {
+	var kept []string
+	for _, line := range strings.Split(armored, "\n") {
+		if !strings.HasPrefix(line, "=") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+func TestCRC24(t *testing.T) {
+	// Check value for the CRC-24/OPENPGP parameters
+	assert.Equal(t, uint32(0x21CF02), crc24([]byte("123456789")))
+	assert.Equal(t, uint32(0xB704CE), crc24(nil))
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultBroadcastURL is the public WhatsOnChain mainnet endpoint for raw transactions
+const DefaultBroadcastURL = "https://api.whatsonchain.com/v1/bsv/main/tx/raw"
+
+// TestnetBroadcastURL is the public WhatsOnChain testnet endpoint for raw transactions
+const TestnetBroadcastURL = "https://api.whatsonchain.com/v1/bsv/test/tx/raw"
+
+// Broadcaster submits a transaction to the network and returns its txid
+type Broadcaster interface {
+	Broadcast(tx *transaction.Transaction) (string, error)
+}
+
+// DefaultBroadcastTimeout bounds each broadcast attempt
+const DefaultBroadcastTimeout = 30 * time.Second
+
+// DefaultBroadcastRetries is how many times a transient failure is retried
+const DefaultBroadcastRetries = 3
+
+// ErrBroadcastRejected is returned when the node definitively refuses a
+// transaction. It is never retried.
+var ErrBroadcastRejected = errors.New("broadcast rejected")
+
+// BroadcasterConfig tunes how a broadcaster talks to its endpoint
+type BroadcasterConfig struct {
+	// Timeout bounds each attempt. Zero means DefaultBroadcastTimeout.
+	Timeout time.Duration
+
+	// MaxRetries is how many more attempts follow a network error, 5xx or
+	// 429 response, with exponential backoff. Zero disables retries.
+	MaxRetries int
+}
+
+// WhatsOnChainBroadcaster broadcasts transactions through the WhatsOnChain API
+type WhatsOnChainBroadcaster struct {
+	url        string
+	client     *http.Client
+	maxRetries int
+	retryDelay time.Duration
+}
+
+// NewWhatsOnChainBroadcaster creates a broadcaster that posts to the given
+// endpoint with the default timeout and retries
+func NewWhatsOnChainBroadcaster(url string) *WhatsOnChainBroadcaster {
+	return NewWhatsOnChainBroadcasterWithConfig(url, BroadcasterConfig{
+		Timeout:    DefaultBroadcastTimeout,
+		MaxRetries: DefaultBroadcastRetries,
+	})
+}
+
+// NewWhatsOnChainBroadcasterWithConfig creates a broadcaster with a custom
+// timeout and retry policy
+func NewWhatsOnChainBroadcasterWithConfig(url string, config BroadcasterConfig) *WhatsOnChainBroadcaster {
+	if url == "" {
+		url = DefaultBroadcastURL
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultBroadcastTimeout
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	return &WhatsOnChainBroadcaster{
+		url:        url,
+		client:     &http.Client{Timeout: config.Timeout},
+		maxRetries: config.MaxRetries,
+		retryDelay: time.Second,
+	}
+}
+
+// Broadcast posts the raw transaction hex and returns the txid reported by
+// the node. Transient failures are retried; rejections are returned at once.
+func (b *WhatsOnChainBroadcaster) Broadcast(tx *transaction.Transaction) (string, error) {
+	if tx == nil {
+		return "", errors.New("no transaction to broadcast")
+	}
+
+	body, err := json.Marshal(map[string]string{"txhex": tx.Hex()})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		txid, retry, err := b.post(body)
+		if err == nil {
+			return txid, nil
+		}
+
+		// An earlier attempt that timed out may have reached the node after all
+		if attempt > 0 && errors.Is(err, ErrBroadcastRejected) && isAlreadyKnown(err.Error()) {
+			return tx.TxID().String(), nil
+		}
+
+		if !retry || attempt >= b.maxRetries {
+			if attempt > 0 {
+				return "", fmt.Errorf("broadcast failed after %d attempts: %w", attempt+1, err)
+			}
+			return "", err
+		}
+		time.Sleep(b.retryDelay << attempt)
+	}
+}
+
+// post makes a single broadcast attempt and reports whether a failure is
+// worth retrying
+func (b *WhatsOnChainBroadcaster) post(body []byte) (string, bool, error) {
+	resp, err := b.client.Post(b.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", true, fmt.Errorf("failed to reach broadcast endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", true, fmt.Errorf("failed to read broadcast response: %w", err)
+	}
+
+	// Surface the node's error body so the user can see why it was rejected
+	message := strings.TrimSpace(string(respBody))
+	switch {
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return "", true, fmt.Errorf("broadcast endpoint unavailable (HTTP %d): %s", resp.StatusCode, message)
+	case resp.StatusCode != http.StatusOK:
+		return "", false, fmt.Errorf("%w (HTTP %d): %s", ErrBroadcastRejected, resp.StatusCode, message)
+	}
+
+	// WhatsOnChain returns the txid as a JSON string
+	var txid string
+	if err := json.Unmarshal(respBody, &txid); err != nil {
+		txid = message
+	}
+	if txid == "" {
+		return "", false, errors.New("broadcast endpoint returned an empty txid")
+	}
+
+	return txid, false, nil
+}
+
+// isAlreadyKnown reports whether a rejection says the node already has the
+// transaction
+func isAlreadyKnown(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "already in mempool") ||
+		strings.Contains(message, "already known") ||
+		strings.Contains(message, "txn-already-known")
+}
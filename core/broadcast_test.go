@@ -0,0 +1,105 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhatsOnChainBroadcaster(t *testing.T) {
+	// flaky answers each request with the next response in turn
+	type response struct {
+		status int
+		body   string
+		delay  time.Duration
+	}
+	flaky := func(t *testing.T, responses ...response) (*httptest.Server, *int32) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&calls, 1)
+			resp := responses[len(responses)-1]
+			if int(n) <= len(responses) {
+				resp = responses[n-1]
+			}
+			time.Sleep(resp.delay)
+			w.WriteHeader(resp.status)
+			w.Write([]byte(resp.body))
+		}))
+		t.Cleanup(server.Close)
+		return server, &calls
+	}
+	newBroadcaster := func(url string, config BroadcasterConfig) *WhatsOnChainBroadcaster {
+		b := NewWhatsOnChainBroadcasterWithConfig(url, config)
+		b.retryDelay = time.Millisecond
+		return b
+	}
+	tx := transaction.NewTransaction()
+
+	t.Run("success", func(t *testing.T) {
+		server, calls := flaky(t, response{status: http.StatusOK, body: `"abc123"`})
+		txid, err := newBroadcaster(server.URL, BroadcasterConfig{MaxRetries: 3}).Broadcast(tx)
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", txid)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("retries server errors", func(t *testing.T) {
+		server, calls := flaky(t,
+			response{status: http.StatusBadGateway, body: "bad gateway"},
+			response{status: http.StatusServiceUnavailable, body: "unavailable"},
+			response{status: http.StatusOK, body: `"abc123"`},
+		)
+		txid, err := newBroadcaster(server.URL, BroadcasterConfig{MaxRetries: 3}).Broadcast(tx)
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", txid)
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		server, calls := flaky(t, response{status: http.StatusInternalServerError, body: "down"})
+		_, err := newBroadcaster(server.URL, BroadcasterConfig{MaxRetries: 2}).Broadcast(tx)
+		assert.ErrorContains(t, err, "after 3 attempts")
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("never retries rejections", func(t *testing.T) {
+		for _, resp := range []response{
+			{status: http.StatusBadRequest, body: "unexpected response code 500: 16: mandatory-script-verify-flag-failed"},
+			{status: http.StatusUnprocessableEntity, body: "invalid transaction"},
+			{status: http.StatusBadRequest, body: "Transaction already in the mempool"},
+		} {
+			server, calls := flaky(t, resp)
+			_, err := newBroadcaster(server.URL, BroadcasterConfig{MaxRetries: 3}).Broadcast(tx)
+			assert.ErrorIs(t, err, ErrBroadcastRejected)
+			assert.ErrorContains(t, err, resp.body)
+			assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+		}
+	})
+
+	t.Run("times out slow nodes", func(t *testing.T) {
+		server, calls := flaky(t,
+			response{status: http.StatusOK, body: `"slow"`, delay: 200 * time.Millisecond},
+			response{status: http.StatusOK, body: `"abc123"`},
+		)
+		txid, err := newBroadcaster(server.URL, BroadcasterConfig{Timeout: 50 * time.Millisecond, MaxRetries: 1}).Broadcast(tx)
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", txid)
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+
+	t.Run("timed out attempt that reached the node", func(t *testing.T) {
+		server, _ := flaky(t,
+			response{status: http.StatusOK, body: `"slow"`, delay: 200 * time.Millisecond},
+			response{status: http.StatusBadRequest, body: "txn-already-known"},
+		)
+		txid, err := newBroadcaster(server.URL, BroadcasterConfig{Timeout: 50 * time.Millisecond, MaxRetries: 1}).Broadcast(tx)
+		require.NoError(t, err)
+		assert.Equal(t, tx.TxID().String(), txid)
+	})
+}
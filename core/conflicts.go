@@ -0,0 +1,43 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// FindConflictingPledges finds UTXOs that more than one pledge spends. It
+// returns the competing pledge IDs, sorted, keyed by outpoint ("txid:vout").
+// Only one of those pledges can ever be claimed, so pledgers who appear
+// here have committed the same funds twice. Copies of the same pledge are
+// not conflicts.
+func FindConflictingPledges(pledges []*Pledge) map[string][]string {
+	spenders := make(map[string]map[string]bool)
+	for _, pledge := range pledges {
+		for _, input := range pledge.Transaction().Inputs {
+			key := outpointKey(input)
+			if spenders[key] == nil {
+				spenders[key] = make(map[string]bool)
+			}
+			spenders[key][pledge.ID()] = true
+		}
+	}
+
+	conflicts := make(map[string][]string)
+	for outpoint, ids := range spenders {
+		if len(ids) < 2 {
+			continue
+		}
+		for id := range ids {
+			conflicts[outpoint] = append(conflicts[outpoint], id)
+		}
+		sort.Strings(conflicts[outpoint])
+	}
+	return conflicts
+}
+
+// outpointKey identifies the output an input spends as "txid:vout"
+func outpointKey(input *transaction.TransactionInput) string {
+	return fmt.Sprintf("%s:%d", input.SourceTXID.String(), input.SourceTxOutIndex)
+}
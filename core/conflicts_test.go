@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindConflictingPledges(t *testing.T) {
+	project, err := NewProject("Conflict Test", "Testing conflict detection", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	shared := withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 30000000))[0]
+
+	newPledge := func(memo string, utxos ...*transaction.UTXO) *Pledge {
+		pledge, err := NewPledge(project, 30000000, utxos)
+		require.NoError(t, err)
+		require.NoError(t, pledge.SetMemo(memo))
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey}))
+		return pledge
+	}
+
+	// Three pledges double-commit the same UTXO, one is independent
+	first := newPledge("first", shared)
+	second := newPledge("second", shared)
+	third := newPledge("third", shared)
+	independent := createSignedTestPledge(t, project, 30000000)
+
+	t.Run("no conflicts", func(t *testing.T) {
+		assert.Empty(t, FindConflictingPledges(nil))
+		assert.Empty(t, FindConflictingPledges([]*Pledge{first, independent}))
+	})
+
+	t.Run("copies of one pledge are not conflicts", func(t *testing.T) {
+		assert.Empty(t, FindConflictingPledges([]*Pledge{first, first}))
+	})
+
+	t.Run("groups every pledge spending an outpoint", func(t *testing.T) {
+		conflicts := FindConflictingPledges([]*Pledge{first, independent, second, third})
+		require.Len(t, conflicts, 1)
+
+		outpoint := outpointKey(first.Transaction().Inputs[0])
+		assert.ElementsMatch(t, []string{first.ID(), second.ID(), third.ID()}, conflicts[outpoint])
+	})
+}
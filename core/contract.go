@@ -1,17 +1,84 @@
 package core
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
 	"github.com/bsv-blockchain/go-sdk/transaction"
+	pb "github.com/yourusername/lighthouse/core/proto"
+	"google.golang.org/protobuf/proto"
 )
 
+// ErrDuplicatePledge is returned when the same pledge is added twice
+var ErrDuplicatePledge = errors.New("pledge already added")
+
+// ErrPledgeBelowMinimum is returned when a pledge is smaller than the project's minimum pledge
+var ErrPledgeBelowMinimum = errors.New("pledge is below the project minimum")
+
+// ErrOutputMismatch is returned when a pledge commits to different outputs than its project
+var ErrOutputMismatch = errors.New("pledge outputs do not match project")
+
+// ErrConflictingInputs is returned when a pledge spends inputs already used by another pledge
+var ErrConflictingInputs = errors.New("pledge uses same inputs as existing pledge")
+
+// ErrProjectExpired is returned when claiming a project past its expiry
+var ErrProjectExpired = errors.New("project has expired")
+
+// ErrNoPledges is returned when claiming a contract with no pledges, e.g.
+// because every pledge file failed to load
+var ErrNoPledges = errors.New("no valid pledges")
+
+// ErrNoInputs is returned for a pledge that spends nothing, which could
+// only come from a malformed or hand-crafted pledge file
+var ErrNoInputs = errors.New("pledge has no inputs")
+
+// ErrTooManyInputs is returned when no claim transaction within the input
+// limit reaches the goal
+var ErrTooManyInputs = errors.New("claim needs too many inputs")
+
+// ErrFeeNotCovered is returned when the claimed pledges can't pay the
+// project outputs plus the claim fee
+var ErrFeeNotCovered = errors.New("pledges do not cover outputs plus fee")
+
+// ErrOverFunded is returned when the claimed pledges carry more than the
+// outputs, the fee and their fee contributions account for, which would
+// all go to the miners. Pledges are indivisible, so a claim may overshoot
+// the goal by less than its smallest pledge; beyond that a pledge could
+// have been left out.
+var ErrOverFunded = errors.New("pledges over-fund the contract")
+
 // Contract represents an assurance contract that combines pledges
 type Contract struct {
 	project  *Project
 	pledges  []*Pledge
 	combined *transaction.Transaction
+	feeRate  uint64
+
+	// claimed holds the pledges the combined transaction spends, which is
+	// a subset of pledges when the claim doesn't need them all
+	claimed []*Pledge
+
+	// total is the sum of the pledge amounts, kept up to date as pledges
+	// are added and removed so TotalPledged doesn't rescan them
+	total uint64
+
+	// selectForGoal makes Combine use SelectPledgesForGoal
+	selectForGoal bool
+
+	// selectMinimal makes Combine use SelectMinimalPledges
+	selectMinimal bool
+
+	// allowExpiredClaim lets a funded contract be claimed after expiry
+	allowExpiredClaim bool
 }
 
 // NewContract creates a new assurance contract for a project
@@ -19,9 +86,37 @@ func NewContract(project *Project) *Contract {
 	return &Contract{
 		project: project,
 		pledges: make([]*Pledge, 0),
+		feeRate: DefaultFeeRate,
 	}
 }
 
+// SetFeeRate sets the fee rate in satoshis per kilobyte used by Combine
+func (c *Contract) SetFeeRate(satPerKB uint64) {
+	c.feeRate = satPerKB
+}
+
+// SetSelectPledgesForGoal makes Combine always spend the pledges chosen by
+// SelectPledgesForGoal, so an over-funded contract collects as close to
+// the goal as possible. Without it Combine spends every pledge unless that
+// would over-fund the contract, and only then selects.
+func (c *Contract) SetSelectPledgesForGoal(enabled bool) {
+	c.selectForGoal = enabled
+}
+
+// SetAllowExpiredClaim lets CanClaim and Combine ignore the project's
+// expiry. By default an expired project can't be claimed, since pledgers
+// then expect refunds.
+func (c *Contract) SetAllowExpiredClaim(enabled bool) {
+	c.allowExpiredClaim = enabled
+}
+
+// SetSelectMinimalPledges makes Combine spend only the pledges chosen by
+// SelectMinimalPledges, for the smallest claim transaction. It takes
+// precedence over SetSelectPledgesForGoal.
+func (c *Contract) SetSelectMinimalPledges(enabled bool) {
+	c.selectMinimal = enabled
+}
+
 // AddPledge adds a pledge to the contract
 func (c *Contract) AddPledge(pledge *Pledge) error {
 	// Verify pledge is for this project
@@ -29,24 +124,247 @@ func (c *Contract) AddPledge(pledge *Pledge) error {
 		return errors.New("pledge is for different project")
 	}
 
+	// A paused project keeps its pledges, so only cancelling rejects them
+	// here; callers taking new pledges check CheckAcceptingPledges
+	if c.project.Status() == ProjectCancelled {
+		return fmt.Errorf("%w: project is cancelled", ErrProjectClosed)
+	}
+
+	if pledge.Network() != "" && pledge.Network() != c.project.Network() {
+		return fmt.Errorf("pledge is for %s but project is on %s", pledge.Network(), c.project.Network())
+	}
+
+	// NewPledge enforces the minimum, but a pledge file can be hand-crafted
+	if pledge.Amount() < c.project.MinPledgeAmount() {
+		return fmt.Errorf("%w: %d < %d", ErrPledgeBelowMinimum, pledge.Amount(), c.project.MinPledgeAmount())
+	}
+
+	// Checked before the generic validation so callers can tell it apart:
+	// a pledge that spends nothing would count towards the goal for free
+	if !pledge.hasInputs() {
+		return fmt.Errorf("%w: pledge %s", ErrNoInputs, pledge.ID())
+	}
+
+	// The project ID only names the project; a tampered pledge could still
+	// sign over a different payout, which would never combine
+	if err := pledge.CheckOutputs(c.project); err != nil {
+		return fmt.Errorf("%w: %v", ErrOutputMismatch, err)
+	}
+
+	// A pledge signed without ANYONECANPAY is valid alone but breaks as soon
+	// as other pledges' inputs are combined with it
+	if err := pledge.CheckSigHash(); err != nil {
+		return fmt.Errorf("pledge %s: %w", pledge.ID(), err)
+	}
+
+	// Surplus beyond the maximum would be lost to the miners, and the claim
+	// would be refused, so one pledge could block the whole campaign
+	limit, err := MaxFeeContribution(c.project, len(pledge.Transaction().Inputs))
+	if err != nil {
+		return err
+	}
+	if contribution := pledge.FeeContribution(); contribution > limit {
+		return fmt.Errorf("%w: pledge %s carries %d, maximum is %d", ErrFeeContributionTooHigh, pledge.ID(), contribution, limit)
+	}
+
+	// The same pledge may turn up twice, e.g. copied under another filename
+	if c.HasPledge(pledge.ID()) {
+		return ErrDuplicatePledge
+	}
+
 	// Validate the pledge
 	if err := pledge.Validate(); err != nil {
 		return fmt.Errorf("invalid pledge: %w", err)
 	}
 
+	// Signatures commit to nLockTime, which the combined transaction shares
+	if len(c.pledges) > 0 && pledge.Timelock() != c.pledges[0].Timelock() {
+		return fmt.Errorf("pledge lock time %d does not match contract lock time %d", pledge.Timelock(), c.pledges[0].Timelock())
+	}
+
 	// Check for duplicate pledges (same inputs)
 	for _, existing := range c.pledges {
 		if c.hasDuplicateInputs(existing, pledge) {
-			return errors.New("pledge uses same inputs as existing pledge")
+			return ErrConflictingInputs
 		}
 	}
 
 	c.pledges = append(c.pledges, pledge)
+	c.total += pledge.Amount()
 	return nil
 }
 
+// BuildContract creates a contract for a project and adds each pledge to it.
+// Pledges that are rejected are reported in the returned errors.
+func BuildContract(project *Project, pledges []*Pledge) (*Contract, []error) {
+	contract := NewContract(project)
+
+	var errs []error
+	for _, pledge := range pledges {
+		if err := contract.AddPledge(pledge); err != nil {
+			errs = append(errs, fmt.Errorf("failed to add pledge %s: %w", pledge.ID(), err))
+		}
+	}
+
+	return contract, errs
+}
+
+// PledgeDirError reports a pledge file or pledge from a directory that
+// AddPledgesFromDirs skipped
+type PledgeDirError struct {
+	Dir string
+	Err error
+}
+
+func (e *PledgeDirError) Error() string {
+	return e.Dir + ": " + e.Err.Error()
+}
+
+func (e *PledgeDirError) Unwrap() error {
+	return e.Err
+}
+
+// AddPledgesFromDirs loads the *.pledge files in each directory and adds
+// them to the contract. A pledge found in several directories is added once
+// and its copies reported as ErrDuplicatePledge. Every skipped file or
+// pledge is reported as a *PledgeDirError naming its directory.
+func (c *Contract) AddPledgesFromDirs(dirs ...string) (added int, errs []error) {
+	for _, dir := range dirs {
+		pledges, loadErrs := LoadPledgesFromDir(dir, 0)
+		for _, err := range loadErrs {
+			errs = append(errs, &PledgeDirError{Dir: dir, Err: err})
+		}
+		for _, pledge := range pledges {
+			if err := c.AddPledge(pledge); err != nil {
+				errs = append(errs, &PledgeDirError{Dir: dir, Err: fmt.Errorf("failed to add pledge %s: %w", pledge.ID(), err)})
+				continue
+			}
+			added++
+		}
+	}
+	return added, errs
+}
+
+// LoadPledgeFiles loads every *.pledge file in a directory. Files that
+// cannot be read or parsed are reported in the returned errors.
+func LoadPledgeFiles(dir string) ([]*Pledge, []error) {
+	return LoadPledgesFromDir(dir, 1)
+}
+
+// LoadPledgesFromDir loads every *.pledge file in a directory using a pool
+// of concurrency workers (one per CPU if concurrency < 1). Pledges and
+// per-file errors are returned in filename order.
+func LoadPledgesFromDir(dir string, concurrency int) ([]*Pledge, []error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pledge"))
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to list pledge files: %w", err)}
+	}
+
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+
+	// Each worker writes only its own slots, so no locking is needed
+	pledges := make([]*Pledge, len(files))
+	errs := make([]error, len(files))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				pledges[i], errs[i] = loadPledgeFile(files[i])
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var loaded []*Pledge
+	var loadErrs []error
+	for i := range files {
+		if errs[i] != nil {
+			loadErrs = append(loadErrs, errs[i])
+		} else {
+			loaded = append(loaded, pledges[i])
+		}
+	}
+
+	return loaded, loadErrs
+}
+
+// loadPledgeFile reads and parses a single pledge file
+func loadPledgeFile(file string) (*Pledge, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pledge file %s: %w", file, err)
+	}
+
+	pledge, err := LoadPledge(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pledge from %s: %w", file, err)
+	}
+	return pledge, nil
+}
+
+// Serialize packs the project and its pledges into a single protobuf envelope
+func (c *Contract) Serialize() ([]byte, error) {
+	envelope := &pb.Contract{
+		Project: c.project.pb,
+		FeeRate: c.feeRate,
+	}
+	for _, pledge := range c.pledges {
+		envelope.Pledges = append(envelope.Pledges, pledge.pb)
+	}
+	return proto.Marshal(envelope)
+}
+
+// LoadContract restores a contract saved with Serialize. Pledges are
+// re-added in their original order, so they are validated again.
+func LoadContract(data []byte) (*Contract, error) {
+	var envelope pb.Contract
+	if err := proto.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contract: %w", err)
+	}
+	if envelope.Project == nil {
+		return nil, errors.New("contract has no project")
+	}
+
+	project, err := projectFromPB(envelope.Project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project: %w", err)
+	}
+
+	contract := NewContract(project)
+	if envelope.FeeRate != 0 {
+		contract.SetFeeRate(envelope.FeeRate)
+	}
+
+	for i, pbPledge := range envelope.Pledges {
+		pledge, err := pledgeFromPB(pbPledge)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pledge %d: %w", i, err)
+		}
+		if err := contract.AddPledge(pledge); err != nil {
+			return nil, fmt.Errorf("failed to add pledge %s: %w", pledge.ID(), err)
+		}
+	}
+
+	return contract, nil
+}
+
 // TotalPledged returns the total amount pledged so far
 func (c *Contract) TotalPledged() uint64 {
+	return c.total
+}
+
+// sumPledged adds up the pledge amounts, for checking the cached total
+func (c *Contract) sumPledged() uint64 {
 	total := uint64(0)
 	for _, pledge := range c.pledges {
 		total += pledge.Amount()
@@ -54,83 +372,456 @@ func (c *Contract) TotalPledged() uint64 {
 	return total
 }
 
+// ReachedMilestones returns the project milestones met by the total pledged
+func (c *Contract) ReachedMilestones() []Milestone {
+	total := c.TotalPledged()
+	var reached []Milestone
+	for _, m := range c.project.Milestones() {
+		if m.Threshold <= total {
+			reached = append(reached, m)
+		}
+	}
+	return reached
+}
+
 // Progress returns the funding progress as a percentage
 func (c *Contract) Progress() float64 {
 	return float64(c.TotalPledged()) / float64(c.project.GoalAmount()) * 100
 }
 
-// CanClaim checks if the contract can be claimed (goal reached)
-func (c *Contract) CanClaim() bool {
+// ProgressClamped returns the funding progress as a percentage, capped at 100
+func (c *Contract) ProgressClamped() float64 {
+	return math.Min(c.Progress(), 100)
+}
+
+// Remaining returns how many satoshis are still needed to reach the goal,
+// or 0 once the goal is met
+func (c *Contract) Remaining() uint64 {
+	total := c.TotalPledged()
+	if total >= c.project.GoalAmount() {
+		return 0
+	}
+	return c.project.GoalAmount() - total
+}
+
+// GoalReached reports whether the pledges add up to the funding goal
+func (c *Contract) GoalReached() bool {
 	return c.TotalPledged() >= c.project.GoalAmount()
 }
 
+// CanClaim checks if the contract can be claimed, i.e. CheckClaimable
+// passes
+func (c *Contract) CanClaim() bool {
+	return c.CheckClaimable() == nil
+}
+
+// CheckClaimable returns why the contract can't be claimed, if it can't:
+// the project is cancelled, the goal isn't reached, the project expired
+// (unless expired claims are allowed), or the pledges Combine would spend
+// don't balance against the outputs and fee. Signatures aren't checked.
+func (c *Contract) CheckClaimable() error {
+	if err := c.checkClaimable(); err != nil {
+		return err
+	}
+	pledges, err := c.claimPledges()
+	if err != nil {
+		return err
+	}
+	_, err = c.buildClaim(pledges, false)
+	return err
+}
+
 // Combine creates the final transaction from all pledges
 func (c *Contract) Combine() (*transaction.Transaction, error) {
-	if !c.CanClaim() {
-		return nil, fmt.Errorf("funding goal not reached: %d/%d", c.TotalPledged(), c.project.GoalAmount())
+	return c.combine(false)
+}
+
+// CombineSorted creates the final transaction with inputs in BIP69 order, so
+// the same set of pledges always produces the same transaction regardless of
+// the order they were added in. Outputs keep the project's order: every
+// pledge signature commits to them, so reordering would invalidate it.
+func (c *Contract) CombineSorted() (*transaction.Transaction, error) {
+	return c.combine(true)
+}
+
+// CombineBatched builds the claim within a limit of maxInputs inputs, for
+// campaigns with so many small pledges that spending them all would make a
+// non-standard transaction. When the usual selection is over the limit it
+// falls back to SelectMinimalPledges, leaving the other pledges unspent.
+//
+// Pledges can't be consolidated across several transactions: each pledge
+// signature commits to the full project outputs, so every transaction that
+// spends a pledge must pay the whole goal. The result therefore holds a
+// single claim transaction, and ErrTooManyInputs is returned when the goal
+// can't be reached within the limit.
+func (c *Contract) CombineBatched(maxInputs int) ([]*transaction.Transaction, error) {
+	if maxInputs <= 0 {
+		return nil, fmt.Errorf("input limit must be positive, got %d", maxInputs)
+	}
+	if err := c.checkClaimable(); err != nil {
+		return nil, err
+	}
+
+	pledges, err := c.claimPledges()
+	if err != nil {
+		return nil, err
+	}
+	if countInputs(pledges) > maxInputs {
+		if pledges, err = c.SelectMinimalPledges(); err != nil {
+			return nil, err
+		}
+		if n := countInputs(pledges); n > maxInputs {
+			return nil, fmt.Errorf("%w: reaching the goal takes %d inputs, limit is %d", ErrTooManyInputs, n, maxInputs)
+		}
 	}
 
+	tx, err := c.combinePledges(pledges, false)
+	if err != nil {
+		return nil, err
+	}
+	return []*transaction.Transaction{tx}, nil
+}
+
+// countInputs totals the claim transaction inputs of a set of pledges
+func countInputs(pledges []*Pledge) int {
+	n := 0
+	for _, pledge := range pledges {
+		n += inputCost(pledge)
+	}
+	return n
+}
+
+// checkClaimable is CheckClaimable without building the claim, for
+// callers that build it next
+func (c *Contract) checkClaimable() error {
+	if c.project.Status() == ProjectCancelled {
+		return fmt.Errorf("%w: project is cancelled", ErrProjectClosed)
+	}
+	if len(c.pledges) == 0 {
+		return ErrNoPledges
+	}
+	if !c.GoalReached() {
+		return fmt.Errorf("funding goal not reached: %d/%d", c.TotalPledged(), c.project.GoalAmount())
+	}
+	if !c.allowExpiredClaim && c.project.IsExpired() {
+		return fmt.Errorf("%w: expired %s", ErrProjectExpired, c.project.Expires().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// combine builds the claim transaction, optionally sorting inputs per BIP69
+func (c *Contract) combine(sortInputs bool) (*transaction.Transaction, error) {
+	if err := c.checkClaimable(); err != nil {
+		return nil, err
+	}
+
+	pledges, err := c.claimPledges()
+	if err != nil {
+		return nil, err
+	}
+	return c.combinePledges(pledges, sortInputs)
+}
+
+// combinePledges builds the claim transaction spending the given pledges
+// and keeps it as the contract's transaction
+func (c *Contract) combinePledges(pledges []*Pledge, sortInputs bool) (*transaction.Transaction, error) {
+	tx, err := c.buildClaim(pledges, sortInputs)
+	if err != nil {
+		return nil, err
+	}
+	c.combined = tx
+	c.claimed = pledges
+	return tx, nil
+}
+
+// buildClaim builds the claim transaction spending the given pledges
+func (c *Contract) buildClaim(pledges []*Pledge, sortInputs bool) (*transaction.Transaction, error) {
 	// Create a new transaction
 	tx := transaction.NewTransaction()
+	if len(pledges) > 0 {
+		tx.LockTime = pledges[0].Timelock()
+	}
 
-	// Add all inputs from all pledges
+	// Add all inputs from the claimed pledges. AddPledge rejects pledges
+	// without inputs, but check again rather than pay out for nothing.
 	inputValue := uint64(0)
-	for _, pledge := range c.pledges {
+	for _, pledge := range pledges {
+		if !pledge.hasInputs() {
+			return nil, fmt.Errorf("%w: pledge %s", ErrNoInputs, pledge.ID())
+		}
 		for _, input := range pledge.Transaction().Inputs {
 			tx.Inputs = append(tx.Inputs, input)
-			// In a real implementation, we'd look up the input value
-			// For now, we'll trust the pledge amount
 		}
-		inputValue += pledge.Amount()
+		inputValue += pledge.InputTotal()
+	}
+
+	// BIP69: ascending txid (as displayed) then output index. The
+	// ANYONECANPAY signatures don't cover input positions, so this is safe.
+	if sortInputs {
+		sort.SliceStable(tx.Inputs, func(i, j int) bool {
+			a, b := tx.Inputs[i], tx.Inputs[j]
+			if txidA, txidB := a.SourceTXID.String(), b.SourceTXID.String(); txidA != txidB {
+				return txidA < txidB
+			}
+			return a.SourceTxOutIndex < b.SourceTxOutIndex
+		})
 	}
 
-	// Add the project outputs
+	// Add the project outputs. The constructors reject dust, but a loaded
+	// or imported project may still carry outputs nodes won't relay.
+	// Zero-value OP_RETURN data outputs are standard.
 	outputs, err := c.project.Outputs()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project outputs: %w", err)
 	}
 
 	outputValue := uint64(0)
-	for _, out := range outputs {
+	for i, out := range outputs {
+		if out.Satoshis < DustThreshold && !(out.Satoshis == 0 && out.LockingScript.IsData()) {
+			return nil, fmt.Errorf("%w: output %d is %d satoshis, minimum is %d", ErrDustOutput, i, out.Satoshis, DustThreshold)
+		}
 		tx.AddOutput(out)
 		outputValue += out.Satoshis
 	}
 
-	// Calculate and add change if necessary
-	if inputValue > outputValue {
-		change := inputValue - outputValue
-		// In a real implementation, we'd need to determine where change goes
-		// For now, we'll add it to the first output
-		if len(tx.Outputs) > 0 {
-			tx.Outputs[0].Satoshis += change
-		}
+	// Pledges are signed over the project outputs, so there is nowhere to
+	// send change: their fee contributions must cover the fee. Whatever
+	// they carry beyond it goes to the miners too, which is accepted as
+	// long as it is no more than the pledges themselves contribute. Every
+	// FeeAllowance includes the whole claim overhead, and a pledge may be
+	// made at a higher rate or fold in dust change, so that much surplus is
+	// expected; AddPledge keeps each within MaxFeeContribution. So are
+	// pledge amounts beyond the goal, if no pledge could be left out.
+	fee := EstimateFee(tx, c.feeRate)
+	if inputValue < outputValue+fee {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrFeeNotCovered, inputValue, outputValue+fee)
+	}
+	tolerance := fee
+	if contributions := feeContributions(pledges); contributions > tolerance {
+		tolerance = contributions
+	}
+	tolerance += unavoidableOvershoot(pledges, outputValue)
+	if excess := inputValue - outputValue - fee; excess > tolerance {
+		return nil, fmt.Errorf("%w by %d satoshis", ErrOverFunded, excess)
 	}
 
-	c.combined = tx
 	return tx, nil
 }
 
+// feeContributions sums each pledge's FeeContribution
+func feeContributions(pledges []*Pledge) uint64 {
+	total := uint64(0)
+	for _, pledge := range pledges {
+		total += pledge.FeeContribution()
+	}
+	return total
+}
+
+// unavoidableOvershoot is how far the pledge amounts exceed goal when none
+// of the pledges could be left out and still reach it, or 0 if one could
+func unavoidableOvershoot(pledges []*Pledge, goal uint64) uint64 {
+	total := uint64(0)
+	for _, pledge := range pledges {
+		total += pledge.Amount()
+	}
+	if total <= goal {
+		return 0
+	}
+	for _, pledge := range pledges {
+		if total-pledge.Amount() >= goal {
+			return 0
+		}
+	}
+	return total - goal
+}
+
+// VerifyCombined runs every input of a claim transaction against the pledge
+// output it spends. Pledges are signed SIGHASH_ALL|ANYONECANPAY, so neither
+// combining them nor reordering inputs should break a signature; this
+// proves it for tx, and catches tampered signatures or foreign inputs.
+func (c *Contract) VerifyCombined(tx *transaction.Transaction) error {
+	if tx == nil || len(tx.Inputs) == 0 {
+		return errors.New("claim transaction has no inputs")
+	}
+
+	sources := make(map[string]*transaction.TransactionOutput)
+	for _, pledge := range c.pledges {
+		for _, input := range pledge.Transaction().Inputs {
+			sources[outpointKey(input)] = input.SourceTxOutput()
+		}
+	}
+
+	for i, input := range tx.Inputs {
+		outpoint := outpointKey(input)
+		source, ok := sources[outpoint]
+		if !ok {
+			return fmt.Errorf("input %d spends %s, which is not a pledge input", i, outpoint)
+		}
+		if source == nil || source.LockingScript == nil || len(*source.LockingScript) == 0 {
+			return fmt.Errorf("input %d (%s) has no source locking script to verify against", i, outpoint)
+		}
+		if input.UnlockingScript == nil || len(*input.UnlockingScript) == 0 {
+			return fmt.Errorf("input %d (%s) is not signed", i, outpoint)
+		}
+
+		err := interpreter.NewEngine().Execute(
+			interpreter.WithTx(tx, i, source),
+			interpreter.WithForkID(),
+			interpreter.WithAfterGenesis(),
+		)
+		if err != nil {
+			return fmt.Errorf("input %d (%s) has an invalid signature: %w", i, outpoint, err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimOutput is one payout of the claim transaction
+type ClaimOutput struct {
+	Address string `json:"address,omitempty"`
+	Script  string `json:"script"`
+	Amount  uint64 `json:"amount"`
+}
+
+// ClaimPreview breaks down the claim transaction before it is broadcast
+type ClaimPreview struct {
+	TxID         string        `json:"txid"`
+	PledgeCount  int           `json:"pledgeCount"`
+	Available    int           `json:"availablePledges"`
+	InputCount   int           `json:"inputCount"`
+	TotalInputs  uint64        `json:"totalInputs"`
+	TotalOutputs uint64        `json:"totalOutputs"`
+	Fee          uint64        `json:"fee"`
+	Size         int           `json:"size"`
+	Outputs      []ClaimOutput `json:"outputs"`
+}
+
+// ClaimPreview builds the claim transaction the same way the claim command
+// does and reports what it spends and pays out. The fee is whatever the
+// inputs leave over after the outputs, which is what miners will receive.
+// It is a dry run: the contract's transaction is left as it was.
+func (c *Contract) ClaimPreview() (*ClaimPreview, error) {
+	if err := c.checkClaimable(); err != nil {
+		return nil, err
+	}
+	pledges, err := c.claimPledges()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.buildClaim(pledges, true)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ClaimPreview{
+		TxID:        tx.TxID().String(),
+		PledgeCount: len(pledges),
+		Available:   len(c.pledges),
+		InputCount:  len(tx.Inputs),
+		Size:        estimateSize(tx),
+	}
+	for _, pledge := range pledges {
+		preview.TotalInputs += pledge.InputTotal()
+	}
+
+	mainnet := c.project.Network() != NetworkTestnet
+	for _, out := range tx.Outputs {
+		output := ClaimOutput{
+			Script: hex.EncodeToString(*out.LockingScript),
+			Amount: out.Satoshis,
+		}
+		if address, err := outputAddress(*out.LockingScript, mainnet); err == nil {
+			output.Address = address
+		}
+		preview.Outputs = append(preview.Outputs, output)
+		preview.TotalOutputs += out.Satoshis
+	}
+	preview.Fee = preview.TotalInputs - preview.TotalOutputs
+
+	return preview, nil
+}
+
+// claimPledges returns the pledges Combine spends: every pledge, unless
+// that over-funds the contract, in which case the goal is met with the
+// subset SelectPledgesForGoal picks
+func (c *Contract) claimPledges() ([]*Pledge, error) {
+	if c.selectMinimal {
+		return c.SelectMinimalPledges()
+	}
+	if c.selectForGoal {
+		return c.SelectPledgesForGoal()
+	}
+	if _, err := c.buildClaim(c.pledges, false); errors.Is(err, ErrOverFunded) {
+		return c.SelectPledgesForGoal()
+	}
+	return c.pledges, nil
+}
+
+// Project returns the project the contract funds
+func (c *Contract) Project() *Project {
+	return c.project
+}
+
 // Transaction returns the combined transaction if available
 func (c *Contract) Transaction() *transaction.Transaction {
 	return c.combined
 }
 
+// ClaimedPledges returns the pledges spent by the combined transaction, or
+// nil before one is built. Like the transaction they may be fewer than
+// Pledges, so report and broadcast these rather than every pledge.
+func (c *Contract) ClaimedPledges() []*Pledge {
+	return c.claimed
+}
+
 // RemovePledge removes a pledge from the contract
 func (c *Contract) RemovePledge(pledgeID string) error {
 	for i, pledge := range c.pledges {
 		if pledge.ID() == pledgeID {
 			c.pledges = append(c.pledges[:i], c.pledges[i+1:]...)
+			c.total -= pledge.Amount()
 			return nil
 		}
 	}
 	return errors.New("pledge not found")
 }
 
+// HasPledge checks if a pledge with the given ID has been added
+func (c *Contract) HasPledge(id string) bool {
+	for _, pledge := range c.pledges {
+		if pledge.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
 // Pledges returns all pledges in the contract
 func (c *Contract) Pledges() []*Pledge {
 	return c.pledges
 }
 
+// PledgesByTime returns the pledges oldest first. Pledges without a
+// timestamp sort last, and ties keep the order the pledges were added in.
+func (c *Contract) PledgesByTime() []*Pledge {
+	sorted := append([]*Pledge(nil), c.pledges...)
+	SortPledgesByTime(sorted)
+	return sorted
+}
+
+// SortPledgesByTime sorts pledges oldest first, in place. Pledges without a
+// timestamp sort last, and ties keep their order.
+func SortPledgesByTime(pledges []*Pledge) {
+	sort.SliceStable(pledges, func(i, j int) bool {
+		ti, tj := pledges[i].Time(), pledges[j].Time()
+		if ti.IsZero() || tj.IsZero() {
+			return !ti.IsZero() && tj.IsZero()
+		}
+		return ti.Before(tj)
+	})
+}
+
 // hasDuplicateInputs checks if two pledges share any inputs
 func (c *Contract) hasDuplicateInputs(p1, p2 *Pledge) bool {
 	inputs1 := make(map[string]bool)
@@ -152,27 +843,97 @@ func (c *Contract) hasDuplicateInputs(p1, p2 *Pledge) bool {
 	return false
 }
 
-// ValidatePledges verifies all pledges are still valid (unspent)
-func (c *Contract) ValidatePledges() error {
-	// In a real implementation, this would check the blockchain
-	// to ensure all pledge inputs are still unspent
-	for i, pledge := range c.pledges {
-		if err := pledge.Validate(); err != nil {
-			return fmt.Errorf("pledge %d invalid: %w", i, err)
+// ValidatePledges re-validates every pledge and asks the checker whether
+// its inputs are still unspent. Pledges that fail either check are dropped
+// from the contract and their IDs returned. A lookup error aborts the check
+// without modifying the contract.
+func (c *Contract) ValidatePledges(checker UTXOChecker) ([]string, error) {
+	var valid []*Pledge
+	var invalidated []string
+	for _, pledge := range c.pledges {
+		ok, err := c.pledgeUnspent(pledge, checker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check pledge %s: %w", pledge.ID(), err)
+		}
+		if ok {
+			valid = append(valid, pledge)
+		} else {
+			invalidated = append(invalidated, pledge.ID())
 		}
 	}
-	return nil
+
+	if len(invalidated) > 0 {
+		c.pledges = valid
+		c.total = c.sumPledged()
+		c.combined = nil // Any combined transaction spent the dropped inputs
+		c.claimed = nil
+	}
+	return invalidated, nil
+}
+
+// pledgeUnspent reports whether a pledge is valid and all of its inputs are unspent
+func (c *Contract) pledgeUnspent(pledge *Pledge, checker UTXOChecker) (bool, error) {
+	if err := pledge.Validate(); err != nil {
+		return false, nil
+	}
+
+	for i := range pledge.Transaction().Inputs {
+		unspent, err := pledge.inputUnspent(i, checker)
+		if err != nil {
+			return false, err
+		}
+		if !unspent {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// VerifiedTotalPledged sums only the pledges whose signatures verify and
+// whose inputs the checker reports unspent, which includes confirming the
+// outputs they spend exist as recorded, so invalid pledges can't make a
+// campaign look funded. Unlike ValidatePledges it leaves the contract
+// unchanged. A lookup error aborts the check.
+func (c *Contract) VerifiedTotalPledged(checker UTXOChecker) (uint64, error) {
+	var total uint64
+	for _, pledge := range c.pledges {
+		if err := pledge.VerifySignatures(); err != nil {
+			continue
+		}
+		ok, err := c.pledgeUnspent(pledge, checker)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check pledge %s: %w", pledge.ID(), err)
+		}
+		if ok {
+			total += pledge.Amount()
+		}
+	}
+	return total, nil
+}
+
+// VerifiedProgress returns the funding progress of the verified pledges as
+// a percentage, like Progress
+func (c *Contract) VerifiedProgress(checker UTXOChecker) (float64, error) {
+	total, err := c.VerifiedTotalPledged(checker)
+	if err != nil {
+		return 0, err
+	}
+	return float64(total) / float64(c.project.GoalAmount()) * 100, nil
 }
 
 // Status returns the current status of the contract
 type ContractStatus struct {
-	ProjectID      string
-	GoalAmount     uint64
-	TotalPledged   uint64
-	PledgeCount    int
-	Progress       float64
-	CanClaim       bool
-	IsExpired      bool
+	ProjectID    string  `json:"projectId"`
+	GoalAmount   uint64  `json:"goalAmount"`
+	TotalPledged uint64  `json:"totalPledged"`
+	PledgeCount  int     `json:"pledgeCount"`
+	Progress     float64 `json:"progress"`
+	Remaining    uint64  `json:"remaining"`
+	CanClaim     bool    `json:"canClaim"`
+	IsExpired    bool    `json:"isExpired"`
+	Status       string  `json:"status"`
+
+	ReachedMilestones []Milestone `json:"reachedMilestones,omitempty"`
 }
 
 // GetStatus returns the current contract status
@@ -183,7 +944,11 @@ func (c *Contract) GetStatus() ContractStatus {
 		TotalPledged: c.TotalPledged(),
 		PledgeCount:  len(c.pledges),
 		Progress:     c.Progress(),
+		Remaining:    c.Remaining(),
 		CanClaim:     c.CanClaim(),
 		IsExpired:    c.project.IsExpired(),
+		Status:       c.project.Status().String(),
+
+		ReachedMilestones: c.ReachedMilestones(),
 	}
 }
\ No newline at end of file
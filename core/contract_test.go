@@ -0,0 +1,1245 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/yourusername/lighthouse/core/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestContractCombineBalance(t *testing.T) {
+	project, err := NewProject(
+		"Combine Test",
+		"Testing combine balance",
+		100000000, // 1 BSV
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	// At 1000 sat/KB a two-pledge claim costs roughly 340 satoshis
+	newContract := func(amounts ...uint64) *Contract {
+		contract := NewContract(project)
+		contract.SetFeeRate(1000)
+		for _, amount := range amounts {
+			require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, amount)))
+		}
+		return contract
+	}
+
+	t.Run("balanced pledges combine", func(t *testing.T) {
+		contract := newContract(50000000, 50000500)
+
+		tx, err := contract.Combine()
+		require.NoError(t, err)
+		require.Len(t, tx.Outputs, 1)
+		assert.Equal(t, uint64(100000000), tx.Outputs[0].Satoshis)
+		assert.Len(t, tx.Inputs, 2)
+	})
+
+	t.Run("pledges that do not cover the fee are rejected", func(t *testing.T) {
+		contract := newContract(50000000, 50000000)
+
+		assert.False(t, contract.CanClaim())
+		_, err := contract.Combine()
+		assert.ErrorIs(t, err, ErrFeeNotCovered)
+	})
+
+	t.Run("indivisible pledges over the goal combine", func(t *testing.T) {
+		contract := newContract(50000000, 60000000)
+		assert.NoError(t, contract.CheckClaimable())
+		assert.True(t, contract.CanClaim())
+
+		tx, err := contract.Combine()
+		require.NoError(t, err)
+		assert.Len(t, tx.Inputs, 2)
+		assert.Equal(t, uint64(100000000), tx.Outputs[0].Satoshis)
+	})
+
+	t.Run("pledges the goal does not need are left out", func(t *testing.T) {
+		contract := newContract(50000000, 60000000, 50000500)
+		assert.True(t, contract.CanClaim())
+		assert.True(t, contract.GetStatus().CanClaim)
+
+		tx, err := contract.Combine()
+		require.NoError(t, err)
+		assert.Len(t, tx.Inputs, 2)
+		assert.Equal(t, tx, contract.Transaction())
+	})
+}
+
+func TestContractFeeAllowances(t *testing.T) {
+	project, err := NewProjectWithOutputs("Fee Test", "Testing fee allowances", []ProjectOutput{
+		{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 70000000},
+		{Address: "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", Amount: 30000000},
+	})
+	require.NoError(t, err)
+
+	t.Run("pledges adding up to the goal pay the fee", func(t *testing.T) {
+		for _, n := range []int{1, 2, 10} {
+			contract := NewContract(project)
+			for i := 0; i < n; i++ {
+				require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 100000000/uint64(n))))
+			}
+
+			tx, err := contract.Combine()
+			require.NoError(t, err, "%d pledges", n)
+
+			// The outputs are exactly the goal and the allowances the fee
+			var inputs, contributions uint64
+			for _, pledge := range contract.Pledges() {
+				inputs += pledge.InputTotal()
+				contributions += pledge.FeeContribution()
+			}
+			assert.Equal(t, uint64(70000000), tx.Outputs[0].Satoshis)
+			assert.Equal(t, uint64(30000000), tx.Outputs[1].Satoshis)
+			assert.Equal(t, inputs-100000000, contributions)
+			assert.GreaterOrEqual(t, contributions, EstimateFee(tx, DefaultFeeRate))
+		}
+	})
+
+	t.Run("allowances do not cover a higher rate", func(t *testing.T) {
+		contract := NewContract(project)
+		contract.SetFeeRate(1000)
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 50000000)))
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 50000000)))
+
+		_, err := contract.Combine()
+		assert.ErrorContains(t, err, "do not cover outputs plus fee")
+	})
+
+	t.Run("pledges carrying more than their allowance combine", func(t *testing.T) {
+		// Change too small to return, as pledge create falls back to
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		utxos := withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 50000000))
+		utxos[0].Satoshis += 500
+		_, err = NewPledgeWithChange(project, 50000000, utxos, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", DefaultFeeRate)
+		require.ErrorIs(t, err, ErrDustChange)
+		dustChange, err := NewPledge(project, 50000000, utxos)
+		require.NoError(t, err)
+		require.NoError(t, dustChange.Sign([]*ec.PrivateKey{privKey}))
+
+		// Made at the highest rate a pledge may pay
+		privKey, err = ec.NewPrivateKey()
+		require.NoError(t, err)
+		allowance, err := FeeAllowance(project, 1, MaxFeeRate)
+		require.NoError(t, err)
+		highRate, err := NewPledgeWithFeeRate(project, 50000000, createTestKeyUTXOs(t, privKey, 50000000+allowance), MaxFeeRate)
+		require.NoError(t, err)
+		require.NoError(t, highRate.Sign([]*ec.PrivateKey{privKey}))
+
+		contract := NewContract(project)
+		require.NoError(t, contract.AddPledge(dustChange))
+		require.NoError(t, contract.AddPledge(highRate))
+		assert.True(t, contract.CanClaim())
+
+		tx, err := contract.Combine()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(70000000), tx.Outputs[0].Satoshis)
+		assert.Equal(t, uint64(30000000), tx.Outputs[1].Satoshis)
+	})
+
+	t.Run("contribution beyond the maximum", func(t *testing.T) {
+		limit, err := MaxFeeContribution(project, 1)
+		require.NoError(t, err)
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = NewPledge(project, 50000000, createTestKeyUTXOs(t, privKey, 50000000+limit+1))
+		assert.ErrorIs(t, err, ErrFeeContributionTooHigh)
+
+		// A hand-crafted pledge is refused before it can block the claim
+		generous, err := NewPledge(project, 50000000, createTestKeyUTXOs(t, privKey, 50000000+limit))
+		require.NoError(t, err)
+		require.NoError(t, generous.Sign([]*ec.PrivateKey{privKey}))
+		generous.pb.Inputs[0].Satoshis += 1000
+
+		contract := NewContract(project)
+		assert.ErrorIs(t, contract.AddPledge(generous), ErrFeeContributionTooHigh)
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 50000000)))
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 50000000)))
+		assert.True(t, contract.CanClaim())
+	})
+
+	t.Run("fee rate above the maximum", func(t *testing.T) {
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = NewPledgeWithFeeRate(project, 50000000, createTestKeyUTXOs(t, privKey, 60000000), MaxFeeRate+1)
+		assert.ErrorIs(t, err, ErrFeeRateTooHigh)
+	})
+
+	t.Run("pledge without an allowance", func(t *testing.T) {
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = NewPledge(project, 50000000, createTestKeyUTXOs(t, privKey, 50000000))
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+		pledge, err := NewPledgeWithFeeRate(project, 50000000, createTestKeyUTXOs(t, privKey, 50000000), 0)
+		require.NoError(t, err)
+		assert.Zero(t, pledge.FeeContribution())
+	})
+}
+
+func TestContractDuplicatePledges(t *testing.T) {
+	project, err := NewProject(
+		"Duplicate Test",
+		"Testing duplicate pledges",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	utxos := withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 25000000))
+
+	pledge, err := NewPledge(project, 25000000, utxos)
+	require.NoError(t, err)
+	require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey}))
+
+	contract := NewContract(project)
+	require.NoError(t, contract.AddPledge(pledge))
+	assert.True(t, contract.HasPledge(pledge.ID()))
+
+	t.Run("same pledge loaded twice", func(t *testing.T) {
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		copy, err := LoadPledge(data)
+		require.NoError(t, err)
+
+		err = contract.AddPledge(copy)
+		assert.ErrorIs(t, err, ErrDuplicatePledge)
+		assert.Len(t, contract.Pledges(), 1)
+	})
+
+	t.Run("different pledge spending the same inputs", func(t *testing.T) {
+		conflicting, err := NewPledge(project, 25000000, utxos)
+		require.NoError(t, err)
+		require.NoError(t, conflicting.SetMemo("second attempt"))
+		require.NoError(t, conflicting.Sign([]*ec.PrivateKey{privKey}))
+		require.NotEqual(t, pledge.ID(), conflicting.ID())
+
+		err = contract.AddPledge(conflicting)
+		assert.ErrorIs(t, err, ErrConflictingInputs)
+		assert.NotErrorIs(t, err, ErrDuplicatePledge)
+	})
+}
+
+func TestContractZeroInputPledge(t *testing.T) {
+	project, err := NewProject("Empty Test", "Testing pledges without inputs", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	// A pledge file claiming the whole goal without spending anything
+	msg := &pb.Pledge{
+		ProjectId: []byte(project.ID()),
+		Time:      timestamppb.Now(),
+		Amount:    100000000,
+		Network:   project.Network(),
+	}
+	outputs, err := project.Outputs()
+	require.NoError(t, err)
+	for _, out := range outputs {
+		msg.Outputs = append(msg.Outputs, &pb.Output{Amount: out.Satoshis, Script: out.LockingScript.Bytes()})
+	}
+	data, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	empty, err := LoadPledge(data)
+	require.NoError(t, err)
+
+	t.Run("rejected by AddPledge", func(t *testing.T) {
+		contract := NewContract(project)
+		err := contract.AddPledge(empty)
+		assert.ErrorIs(t, err, ErrNoInputs)
+		assert.NotContains(t, err.Error(), "invalid pledge")
+		assert.Empty(t, contract.Pledges())
+		assert.Zero(t, contract.TotalPledged())
+	})
+
+	t.Run("rejected by Combine", func(t *testing.T) {
+		// Slipped past AddPledge, e.g. emptied after it was added
+		contract := NewContract(project)
+		contract.pledges = append(contract.pledges, empty)
+		contract.total = empty.Amount()
+		assert.ErrorIs(t, contract.CheckClaimable(), ErrNoInputs)
+
+		_, err := contract.Combine()
+		assert.ErrorIs(t, err, ErrNoInputs)
+		assert.Nil(t, contract.Transaction())
+	})
+}
+
+func TestContractSigHashCompatibility(t *testing.T) {
+	project, err := NewProject(
+		"Sighash Test",
+		"Testing combinable signatures",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	contract := NewContract(project)
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 40000000)))
+
+	// Two inputs, the second re-signed with plain SIGHASH_ALL
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	utxos := withFeeAllowance(t, project, append(createTestKeyUTXOs(t, privKey, 10000000), createTestKeyUTXOs(t, privKey, 20000000)...))
+	pledge, err := NewPledge(project, 30000000, utxos)
+	require.NoError(t, err)
+	require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey, privKey}))
+
+	allFlag := sighash.AllForkID
+	unlocker, err := p2pkh.Unlock(privKey, &allFlag)
+	require.NoError(t, err)
+	unlockingScript, err := unlocker.Sign(pledge.Transaction(), 1)
+	require.NoError(t, err)
+	pledge.Transaction().Inputs[1].UnlockingScript = unlockingScript
+
+	err = contract.AddPledge(pledge)
+	assert.ErrorIs(t, err, ErrIncompatibleSigHash)
+	assert.Contains(t, err.Error(), "input 1")
+	assert.Len(t, contract.Pledges(), 1)
+}
+
+func TestContractSerialization(t *testing.T) {
+	project, err := NewProject(
+		"Serialization Test",
+		"Testing contract serialization",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	contract := NewContract(project)
+	contract.SetFeeRate(250)
+	for _, amount := range []uint64{30000000, 10000000, 20000000} {
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, amount)))
+	}
+
+	data, err := contract.Serialize()
+	require.NoError(t, err)
+
+	loaded, err := LoadContract(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, project.ID(), loaded.GetStatus().ProjectID)
+	assert.Equal(t, contract.TotalPledged(), loaded.TotalPledged())
+	assert.Equal(t, contract.feeRate, loaded.feeRate)
+	require.Len(t, loaded.Pledges(), len(contract.Pledges()))
+	for i, pledge := range contract.Pledges() {
+		assert.Equal(t, pledge.ID(), loaded.Pledges()[i].ID())
+	}
+
+	t.Run("rejects data without a project", func(t *testing.T) {
+		_, err := LoadContract(nil)
+		assert.Error(t, err)
+	})
+}
+
+// mockUTXOChecker reports outputs listed in spent as spent and all others as unspent
+type mockUTXOChecker struct {
+	spent map[string]bool
+	err   error
+}
+
+func (m *mockUTXOChecker) IsUnspent(txid string, vout uint32, _ *transaction.TransactionOutput) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return !m.spent[fmt.Sprintf("%s:%d", txid, vout)], nil
+}
+
+func TestContractValidatePledges(t *testing.T) {
+	project, err := NewProject(
+		"UTXO Test",
+		"Testing UTXO validation",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	kept := createSignedTestPledge(t, project, 40000000)
+	spent := createSignedTestPledge(t, project, 60000000)
+
+	newContract := func() *Contract {
+		contract := NewContract(project)
+		require.NoError(t, contract.AddPledge(kept))
+		require.NoError(t, contract.AddPledge(spent))
+		return contract
+	}
+
+	spentInput := spent.Transaction().Inputs[0]
+	checker := &mockUTXOChecker{spent: map[string]bool{
+		fmt.Sprintf("%s:%d", spentInput.SourceTXID.String(), spentInput.SourceTxOutIndex): true,
+	}}
+
+	t.Run("drops pledges with spent inputs", func(t *testing.T) {
+		contract := newContract()
+		invalidated, err := contract.ValidatePledges(checker)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{spent.ID()}, invalidated)
+		assert.True(t, contract.HasPledge(kept.ID()))
+		assert.False(t, contract.HasPledge(spent.ID()))
+		assert.Equal(t, uint64(40000000), contract.TotalPledged())
+	})
+
+	t.Run("lookup errors leave the contract unchanged", func(t *testing.T) {
+		contract := newContract()
+		_, err := contract.ValidatePledges(&mockUTXOChecker{err: errors.New("network down")})
+		assert.Error(t, err)
+		assert.Len(t, contract.Pledges(), 2)
+	})
+}
+
+func TestContractVerifiedTotalPledged(t *testing.T) {
+	project, err := NewProject(
+		"Verified Total Test",
+		"Testing verified totals",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	valid := createSignedTestPledge(t, project, 30000000)
+	spent := createSignedTestPledge(t, project, 20000000)
+
+	// Signed by a key that doesn't own the input: well-formed, so the
+	// contract accepts it, but the signature doesn't verify
+	owner, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	other, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	badSig, err := NewPledge(project, 40000000, withFeeAllowance(t, project, createTestKeyUTXOs(t, owner, 40000000)))
+	require.NoError(t, err)
+	require.NoError(t, badSig.Sign([]*ec.PrivateKey{other}))
+
+	contract := NewContract(project)
+	for _, pledge := range []*Pledge{valid, spent, badSig} {
+		require.NoError(t, contract.AddPledge(pledge))
+	}
+
+	checker := &mockUTXOChecker{spent: map[string]bool{
+		outpointKey(spent.Transaction().Inputs[0]): true,
+	}}
+
+	verified, err := contract.VerifiedTotalPledged(checker)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(30000000), verified)
+
+	progress, err := contract.VerifiedProgress(checker)
+	require.NoError(t, err)
+	assert.InDelta(t, 30.0, progress, 0.0001)
+
+	// The claimed totals and the pledges themselves are unchanged
+	assert.Equal(t, uint64(90000000), contract.TotalPledged())
+	assert.InDelta(t, 90.0, contract.Progress(), 0.0001)
+	assert.Len(t, contract.Pledges(), 3)
+
+	t.Run("all unspent", func(t *testing.T) {
+		verified, err := contract.VerifiedTotalPledged(&mockUTXOChecker{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50000000), verified)
+	})
+
+	t.Run("missing outputs", func(t *testing.T) {
+		// WhatsOnChain answers 404 for outputs that were never created, which
+		// the spent endpoint alone can't tell from unspent
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		verified, err := contract.VerifiedTotalPledged(NewWhatsOnChainUTXOChecker(server.URL))
+		require.NoError(t, err)
+		assert.Zero(t, verified)
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		_, err := contract.VerifiedTotalPledged(&mockUTXOChecker{err: errors.New("network down")})
+		assert.ErrorContains(t, err, "network down")
+		_, err = contract.VerifiedProgress(&mockUTXOChecker{err: errors.New("network down")})
+		assert.Error(t, err)
+	})
+}
+
+func TestContractProjectStatus(t *testing.T) {
+	project, err := NewProject(
+		"Status Contract Test",
+		"Testing paused and cancelled projects",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	first := createSignedTestPledge(t, project, 60000000)
+	second := createSignedTestPledge(t, project, 40000000)
+
+	t.Run("paused keeps pledges and can be claimed", func(t *testing.T) {
+		project.SetStatus(ProjectPaused)
+		defer project.SetStatus(ProjectActive)
+
+		contract := NewContract(project)
+		contract.SetFeeRate(0)
+		require.NoError(t, contract.AddPledge(first))
+		require.NoError(t, contract.AddPledge(second))
+		assert.True(t, contract.CanClaim())
+		assert.Equal(t, "paused", contract.GetStatus().Status)
+
+		_, err := contract.Combine()
+		assert.NoError(t, err)
+	})
+
+	t.Run("cancelled rejects pledges", func(t *testing.T) {
+		project.SetStatus(ProjectCancelled)
+		defer project.SetStatus(ProjectActive)
+
+		contract := NewContract(project)
+		assert.ErrorIs(t, contract.AddPledge(first), ErrProjectClosed)
+		assert.Empty(t, contract.Pledges())
+		assert.Equal(t, "cancelled", contract.GetStatus().Status)
+	})
+
+	t.Run("cancelling after pledges blocks the claim", func(t *testing.T) {
+		contract := NewContract(project)
+		contract.SetFeeRate(0)
+		require.NoError(t, contract.AddPledge(first))
+		require.NoError(t, contract.AddPledge(second))
+		require.True(t, contract.CanClaim())
+
+		project.SetStatus(ProjectCancelled)
+		defer project.SetStatus(ProjectActive)
+
+		assert.True(t, contract.GoalReached())
+		assert.False(t, contract.CanClaim())
+		assert.False(t, contract.GetStatus().CanClaim)
+		_, err := contract.Combine()
+		assert.ErrorIs(t, err, ErrProjectClosed)
+	})
+}
+
+func TestContractCombineSorted(t *testing.T) {
+	project, err := NewProject(
+		"Sorting Test",
+		"Testing deterministic combine",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	pledges := []*Pledge{
+		createSignedTestPledge(t, project, 20000000),
+		createSignedTestPledge(t, project, 30000000),
+		createSignedTestPledge(t, project, 50000000),
+	}
+
+	build := func(order ...int) *Contract {
+		contract := NewContract(project)
+		contract.SetFeeRate(0)
+		for _, i := range order {
+			require.NoError(t, contract.AddPledge(pledges[i]))
+		}
+		return contract
+	}
+
+	tx1, err := build(0, 1, 2).CombineSorted()
+	require.NoError(t, err)
+	tx2, err := build(2, 0, 1).CombineSorted()
+	require.NoError(t, err)
+
+	assert.Equal(t, tx1.Bytes(), tx2.Bytes())
+	assert.Equal(t, tx1.TxID().String(), tx2.TxID().String())
+
+	// Inputs are in ascending txid order
+	for i := 1; i < len(tx1.Inputs); i++ {
+		assert.Less(t, tx1.Inputs[i-1].SourceTXID.String(), tx1.Inputs[i].SourceTXID.String())
+	}
+}
+
+func TestContractCombineBatched(t *testing.T) {
+	project, err := NewProject(
+		"Batching Test",
+		"Testing input limits",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	small := make([]*Pledge, 4)
+	for i := range small {
+		small[i] = createSignedTestPledge(t, project, 25000000)
+	}
+	large := createSignedTestPledge(t, project, 100000000)
+
+	build := func(pledges ...*Pledge) *Contract {
+		contract := NewContract(project)
+		contract.SetFeeRate(0)
+		for _, pledge := range pledges {
+			require.NoError(t, contract.AddPledge(pledge))
+		}
+		return contract
+	}
+	funded := func(txs []*transaction.Transaction) uint64 {
+		total := uint64(0)
+		for _, tx := range txs {
+			for _, out := range tx.Outputs {
+				total += out.Satoshis
+			}
+		}
+		return total
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		txs, err := build(small...).CombineBatched(4)
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		assert.Len(t, txs[0].Inputs, 4)
+		assert.Equal(t, project.GoalAmount(), funded(txs))
+	})
+
+	t.Run("over limit falls back to fewer inputs", func(t *testing.T) {
+		txs, err := build(append(small, large)...).CombineBatched(2)
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		require.Len(t, txs[0].Inputs, 1)
+		assert.Equal(t, large.Transaction().Inputs[0].SourceTXID.String(), txs[0].Inputs[0].SourceTXID.String())
+		assert.Equal(t, project.GoalAmount(), funded(txs))
+	})
+
+	t.Run("goal unreachable within limit", func(t *testing.T) {
+		_, err := build(small...).CombineBatched(3)
+		assert.ErrorIs(t, err, ErrTooManyInputs)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, err := build(small...).CombineBatched(0)
+		assert.Error(t, err)
+	})
+}
+
+func TestContractVerifyCombined(t *testing.T) {
+	project, err := NewProject(
+		"Verify Test",
+		"Testing combined signatures",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	contract := NewContract(project)
+	contract.SetFeeRate(0)
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 60000000)))
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 40000000)))
+
+	combined, err := contract.Combine()
+	require.NoError(t, err)
+
+	// rebuild copies the claim with its inputs in the given order, so
+	// changes don't reach the pledges
+	rebuild := func(order ...int) *transaction.Transaction {
+		tx := transaction.NewTransaction()
+		tx.LockTime = combined.LockTime
+		for _, i := range order {
+			input := combined.Inputs[i]
+			unlocking := script.Script(append([]byte(nil), *input.UnlockingScript...))
+			tx.AddInput(&transaction.TransactionInput{
+				SourceTXID:       input.SourceTXID,
+				SourceTxOutIndex: input.SourceTxOutIndex,
+				SequenceNumber:   input.SequenceNumber,
+				UnlockingScript:  &unlocking,
+			})
+		}
+		for _, out := range combined.Outputs {
+			tx.AddOutput(out)
+		}
+		return tx
+	}
+
+	t.Run("valid combine", func(t *testing.T) {
+		assert.NoError(t, contract.VerifyCombined(combined))
+
+		sorted, err := contract.CombineSorted()
+		require.NoError(t, err)
+		assert.NoError(t, contract.VerifyCombined(sorted))
+	})
+
+	t.Run("reordered inputs", func(t *testing.T) {
+		assert.NoError(t, contract.VerifyCombined(rebuild(1, 0)))
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		tx := rebuild(0, 1)
+		(*tx.Inputs[1].UnlockingScript)[10] ^= 0x01
+		err := contract.VerifyCombined(tx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "input 1")
+	})
+
+	t.Run("foreign input", func(t *testing.T) {
+		tx := rebuild(0, 1)
+		foreign := createSignedTestPledge(t, project, 10000000).Transaction().Inputs[0]
+		tx.AddInput(foreign)
+		assert.Error(t, contract.VerifyCombined(tx))
+	})
+}
+
+func TestContractClaimPreview(t *testing.T) {
+	project, err := NewProject(
+		"Preview Test",
+		"Testing claim previews",
+		100000000,
+		"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	t.Run("goal not reached", func(t *testing.T) {
+		contract := NewContract(project)
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 40000000)))
+
+		_, err := contract.ClaimPreview()
+		assert.Error(t, err)
+	})
+
+	t.Run("breakdown", func(t *testing.T) {
+		// The fee allowances are for 50 sat/KB, so the second pledge
+		// carries a little extra to pay 1000 sat/KB
+		contract := NewContract(project)
+		contract.SetFeeRate(1000)
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 60000000)))
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 40000400)))
+		allowance, err := FeeAllowance(project, 1, DefaultFeeRate)
+		require.NoError(t, err)
+
+		preview, err := contract.ClaimPreview()
+		require.NoError(t, err)
+		assert.Equal(t, 2, preview.PledgeCount)
+		assert.Equal(t, 2, preview.InputCount)
+		assert.Equal(t, 100000400+2*allowance, preview.TotalInputs)
+		assert.Equal(t, uint64(100000000), preview.TotalOutputs)
+		assert.Equal(t, 400+2*allowance, preview.Fee)
+
+		// A dry run leaves the contract alone, but matches the real claim
+		assert.Nil(t, contract.Transaction())
+		tx, err := contract.CombineSorted()
+		require.NoError(t, err)
+		assert.Equal(t, tx.TxID().String(), preview.TxID)
+
+		require.Len(t, preview.Outputs, 1)
+		assert.Equal(t, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", preview.Outputs[0].Address)
+		assert.Equal(t, uint64(100000000), preview.Outputs[0].Amount)
+	})
+}
+
+func TestLoadPledgesFromDir(t *testing.T) {
+	project, err := NewProject(
+		"Loading Test",
+		"Testing pledge loading",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	ids := writeTestPledgeFiles(t, project, dir, 20)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.pledge"), []byte("not a pledge"), 0644))
+
+	for _, concurrency := range []int{0, 1, 4} {
+		pledges, errs := LoadPledgesFromDir(dir, concurrency)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "corrupt.pledge")
+
+		// Results come back in filename order regardless of worker count
+		require.Len(t, pledges, len(ids))
+		for i, pledge := range pledges {
+			assert.Equal(t, ids[i], pledge.ID())
+		}
+	}
+}
+
+func TestContractAddPledgesFromDirs(t *testing.T) {
+	project, err := NewProject(
+		"Merge Test",
+		"Testing pledges from several directories",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	writePledge := func(dir, name string, pledge *Pledge) {
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0644))
+	}
+
+	// Two pledges of the same UTXO conflict
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	utxos := withFeeAllowance(t, project, createTestKeyUTXOs(t, key, 30000000))
+	first, err := NewPledge(project, 30000000, utxos)
+	require.NoError(t, err)
+	require.NoError(t, first.Sign([]*ec.PrivateKey{key}))
+	conflicting, err := NewPledge(project, 29999900, utxos)
+	require.NoError(t, err)
+	require.NoError(t, conflicting.Sign([]*ec.PrivateKey{key}))
+
+	shared := createSignedTestPledge(t, project, 40000000)
+
+	email, server := t.TempDir(), t.TempDir()
+	writePledge(email, "a.pledge", first)
+	writePledge(email, "b.pledge", shared)
+	writePledge(server, "c.pledge", shared)
+	writePledge(server, "d.pledge", conflicting)
+	require.NoError(t, os.WriteFile(filepath.Join(server, "corrupt.pledge"), []byte("not a pledge"), 0644))
+
+	contract := NewContract(project)
+	added, errs := contract.AddPledgesFromDirs(email, server)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, uint64(70000000), contract.TotalPledged())
+
+	// Every skip is reported against the directory it came from
+	require.Len(t, errs, 3)
+	for _, err := range errs {
+		var dirErr *PledgeDirError
+		require.ErrorAs(t, err, &dirErr)
+		assert.Equal(t, server, dirErr.Dir)
+	}
+	assert.ErrorContains(t, errs[0], "corrupt.pledge")
+	assert.ErrorIs(t, errs[1], ErrDuplicatePledge)
+	assert.ErrorIs(t, errs[2], ErrConflictingInputs)
+}
+
+func BenchmarkLoadPledgeFiles(b *testing.B) {
+	project, err := NewProject("Benchmark", "Benchmarking pledge loading", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(b, err)
+
+	dir := b.TempDir()
+	writeTestPledgeFiles(b, project, dir, 1000)
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			LoadPledgeFiles(dir)
+		}
+	})
+
+	b.Run("concurrent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			LoadPledgesFromDir(dir, 0)
+		}
+	})
+}
+
+// writeTestPledgeFiles writes n pledges to dir, named so that glob order
+// matches creation order, and returns their IDs
+func writeTestPledgeFiles(t testing.TB, project *Project, dir string, n int) []string {
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	var ids []string
+	for i := 0; i < n; i++ {
+		utxos := withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 100000))
+		pledge, err := NewPledge(project, 100000, utxos)
+		require.NoError(t, err)
+
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("%05d.pledge", i)), data, 0644))
+		ids = append(ids, pledge.ID())
+	}
+	return ids
+}
+
+func TestContractPledgeNetwork(t *testing.T) {
+	project, err := NewProject(
+		"Testnet Project",
+		"Testing pledge networks",
+		100000000,
+		"mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r",
+		NetworkTestnet,
+	)
+	require.NoError(t, err)
+
+	t.Run("pledge inherits project network", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 25000000)
+		assert.Equal(t, NetworkTestnet, pledge.Network())
+		assert.NoError(t, NewContract(project).AddPledge(pledge))
+	})
+
+	t.Run("mismatched network is rejected", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 25000000)
+		pledge.pb.Network = NetworkMainnet
+		pledge.id = pledge.calculateID()
+
+		err := NewContract(project).AddPledge(pledge)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "pledge is for mainnet")
+	})
+}
+
+func TestContractMinPledge(t *testing.T) {
+	project, err := NewProject(
+		"Minimum Test",
+		"Testing minimum pledge enforcement",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	// Create the pledge under a low minimum, then raise it, as if the pledge
+	// file had been crafted to skip NewPledge's check
+	pledge := createSignedTestPledge(t, project, 20000)
+	require.NoError(t, project.SetMinPledgeAmount(50000))
+
+	data, err := pledge.Serialize()
+	require.NoError(t, err)
+	loaded, err := LoadPledge(data)
+	require.NoError(t, err)
+	assert.Equal(t, project.ID(), loaded.ProjectID())
+
+	err = NewContract(project).AddPledge(loaded)
+	assert.ErrorIs(t, err, ErrPledgeBelowMinimum)
+
+	assert.NoError(t, NewContract(project).AddPledge(createSignedTestPledge(t, project, 50000)))
+}
+
+func TestContractOutputMismatch(t *testing.T) {
+	project, err := NewProjectWithOutputs("Output Match Test", "Testing pledge outputs", []ProjectOutput{
+		{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 60000000},
+		{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 40000000},
+	})
+	require.NoError(t, err)
+
+	// tamper rewrites a pledge file as an attacker would, keeping the project ID
+	tamper := func(edit func(pledge *pb.Pledge)) *Pledge {
+		pledge := createSignedTestPledge(t, project, 25000000)
+		edit(pledge.pb)
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadPledge(data)
+		require.NoError(t, err)
+		return loaded
+	}
+
+	t.Run("matching outputs", func(t *testing.T) {
+		assert.NoError(t, NewContract(project).AddPledge(createSignedTestPledge(t, project, 25000000)))
+	})
+
+	t.Run("different payout address", func(t *testing.T) {
+		attacker, err := script.NewAddressFromString("1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6")
+		require.NoError(t, err)
+		attackerScript, err := p2pkh.Lock(attacker)
+		require.NoError(t, err)
+
+		pledge := tamper(func(pledge *pb.Pledge) { pledge.Outputs[1].Script = attackerScript.Bytes() })
+		err = NewContract(project).AddPledge(pledge)
+		assert.ErrorIs(t, err, ErrOutputMismatch)
+	})
+
+	t.Run("different split", func(t *testing.T) {
+		pledge := tamper(func(pledge *pb.Pledge) {
+			pledge.Outputs[0].Amount -= 1000000
+			pledge.Outputs[1].Amount += 1000000
+		})
+		err := NewContract(project).AddPledge(pledge)
+		assert.ErrorIs(t, err, ErrOutputMismatch)
+	})
+
+	t.Run("missing output", func(t *testing.T) {
+		pledge := tamper(func(pledge *pb.Pledge) { pledge.Outputs = pledge.Outputs[:1] })
+		err := NewContract(project).AddPledge(pledge)
+		assert.ErrorIs(t, err, ErrOutputMismatch)
+	})
+}
+
+func TestContractNoPledges(t *testing.T) {
+	project, err := NewProject(
+		"Empty Test",
+		"Testing claims without pledges",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	contract := NewContract(project)
+	_, err = contract.Combine()
+	assert.ErrorIs(t, err, ErrNoPledges)
+	_, err = contract.CombineBatched(10)
+	assert.ErrorIs(t, err, ErrNoPledges)
+	_, err = contract.ClaimPreview()
+	assert.ErrorIs(t, err, ErrNoPledges)
+
+	// Under-funded is a different failure
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 10000000)))
+	_, err = contract.Combine()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNoPledges)
+}
+
+func TestContractExpiredClaim(t *testing.T) {
+	project, err := NewProject(
+		"Expiry Test",
+		"Testing expired claims",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	contract := NewContract(project)
+	contract.SetFeeRate(0)
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 100000000)))
+	require.True(t, contract.CanClaim())
+
+	project.SetExpiry(time.Now().Add(-time.Hour))
+
+	t.Run("expired project can't be claimed", func(t *testing.T) {
+		assert.True(t, contract.GoalReached())
+		assert.False(t, contract.CanClaim())
+		assert.False(t, contract.GetStatus().CanClaim)
+
+		_, err := contract.Combine()
+		assert.ErrorIs(t, err, ErrProjectExpired)
+
+		// Pledgers can be refunded instead
+		_, err = contract.BuildRefunds()
+		assert.NoError(t, err)
+	})
+
+	t.Run("allowed when forced", func(t *testing.T) {
+		contract.SetAllowExpiredClaim(true)
+		defer contract.SetAllowExpiredClaim(false)
+
+		assert.True(t, contract.CanClaim())
+		tx, err := contract.Combine()
+		require.NoError(t, err)
+		assert.Len(t, tx.Inputs, 1)
+	})
+}
+
+func TestContractCachedTotal(t *testing.T) {
+	project, err := NewProject(
+		"Total Test",
+		"Testing the cached total",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	pool := make([]*Pledge, 12)
+	for i := range pool {
+		pool[i] = createSignedTestPledge(t, project, uint64(i+1)*1000000)
+	}
+
+	contract := NewContract(project)
+	rng := rand.New(rand.NewSource(1))
+	for step := 0; step < 500; step++ {
+		pledge := pool[rng.Intn(len(pool))]
+		if rng.Intn(2) == 0 {
+			present := contract.HasPledge(pledge.ID())
+			err := contract.AddPledge(pledge)
+			if present {
+				assert.ErrorIs(t, err, ErrDuplicatePledge)
+			} else {
+				require.NoError(t, err)
+			}
+		} else {
+			_ = contract.RemovePledge(pledge.ID())
+		}
+		require.Equal(t, contract.sumPledged(), contract.TotalPledged(), "step %d", step)
+	}
+
+	// Dropping pledges with spent inputs keeps the total in step too
+	for _, pledge := range pool {
+		_ = contract.AddPledge(pledge)
+	}
+	input := pool[0].Transaction().Inputs[0]
+	checker := &mockUTXOChecker{spent: map[string]bool{
+		fmt.Sprintf("%s:%d", input.SourceTXID.String(), input.SourceTxOutIndex): true,
+	}}
+	_, err = contract.ValidatePledges(checker)
+	require.NoError(t, err)
+	assert.Equal(t, contract.sumPledged(), contract.TotalPledged())
+	assert.Equal(t, uint64(77000000), contract.TotalPledged())
+}
+
+func TestContractProgress(t *testing.T) {
+	project, err := NewProject(
+		"Progress Test",
+		"Testing progress",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		amounts   []uint64
+		progress  float64
+		clamped   float64
+		remaining uint64
+		claimable bool
+	}{
+		{"under funded", []uint64{25000000, 15000000}, 40, 40, 60000000, false},
+		{"exactly met", []uint64{60000000, 40000000}, 100, 100, 0, true},
+		// Neither pledge reaches the goal alone, so both are spent
+		{"over funded", []uint64{90000000, 50000000}, 140, 100, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := NewContract(project)
+			for _, amount := range tt.amounts {
+				require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, amount)))
+			}
+
+			assert.InDelta(t, tt.progress, contract.Progress(), 0.0001)
+			assert.InDelta(t, tt.clamped, contract.ProgressClamped(), 0.0001)
+			assert.Equal(t, tt.remaining, contract.Remaining())
+			assert.Equal(t, tt.remaining, contract.GetStatus().Remaining)
+			assert.Equal(t, tt.claimable, contract.CanClaim())
+			assert.Equal(t, tt.claimable, contract.GetStatus().CanClaim)
+		})
+	}
+}
+
+func TestContractCombineDustOutput(t *testing.T) {
+	project, err := NewProjectWithOutputs("Dust Claim Test", "Testing dust outputs", []ProjectOutput{
+		{Address: "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", Amount: 100000000},
+		{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 20000000},
+	})
+	require.NoError(t, err)
+
+	// A project loaded from elsewhere can carry a dust output the
+	// constructors would have refused
+	data, err := project.Serialize()
+	require.NoError(t, err)
+	var msg pb.Project
+	require.NoError(t, proto.Unmarshal(data, &msg))
+	msg.Details.Outputs[1].Amount = 300
+	data, err = proto.Marshal(&msg)
+	require.NoError(t, err)
+	project, err = LoadProject(data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100000300), project.GoalAmount())
+
+	contract := NewContract(project)
+	contract.SetFeeRate(0)
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 100000300)))
+	assert.ErrorIs(t, contract.CheckClaimable(), ErrDustOutput)
+
+	_, err = contract.Combine()
+	assert.ErrorIs(t, err, ErrDustOutput)
+	assert.Contains(t, err.Error(), "output 1 is 300 satoshis")
+}
+
+func TestContractCombineDataOutput(t *testing.T) {
+	project, err := NewProject("Data Claim Test", "Testing OP_RETURN outputs", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, project.AddDataOutput([]byte("https://example.com/manifest.json")))
+	require.Equal(t, uint64(100000000), project.GoalAmount())
+
+	contract := NewContract(project)
+	contract.SetFeeRate(0)
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 100000000)))
+
+	tx, err := contract.Combine()
+	require.NoError(t, err)
+	require.Len(t, tx.Outputs, 2)
+	assert.Equal(t, uint64(100000000), tx.Outputs[0].Satoshis)
+	assert.Equal(t, uint64(0), tx.Outputs[1].Satoshis)
+	assert.True(t, tx.Outputs[1].LockingScript.IsData())
+
+	// The data output adds to the size the fee is charged on
+	withoutData := transaction.NewTransaction()
+	withoutData.Inputs = tx.Inputs
+	withoutData.AddOutput(tx.Outputs[0])
+	assert.Greater(t, EstimateFee(tx, DefaultFeeRate), EstimateFee(withoutData, DefaultFeeRate))
+}
+
+func TestContractReachedMilestones(t *testing.T) {
+	project, err := NewProject(
+		"Milestone Test",
+		"Testing milestones",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+	goal := Milestone{Threshold: 100000000, Description: "Base game"}
+	stretch := Milestone{Threshold: 150000000, Description: "Extra levels"}
+	require.NoError(t, project.SetMilestones(goal, stretch))
+
+	contract := NewContract(project)
+	assert.Empty(t, contract.ReachedMilestones())
+
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 100000000)))
+	assert.Equal(t, []Milestone{goal}, contract.ReachedMilestones())
+
+	require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 60000000)))
+	assert.Equal(t, []Milestone{goal, stretch}, contract.ReachedMilestones())
+	assert.Equal(t, []Milestone{goal, stretch}, contract.GetStatus().ReachedMilestones)
+}
+
+func TestContractPledgesByTime(t *testing.T) {
+	project, err := NewProject("Timeline Test", "Testing pledge ordering", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// The time isn't signed, so it can be changed after signing
+	pledgeAt := func(ts *timestamppb.Timestamp) *Pledge {
+		pledge := createSignedTestPledge(t, project, 50000)
+		pledge.pb.Time = ts
+		pledge.id = pledge.calculateID()
+		return pledge
+	}
+
+	untimed := pledgeAt(nil)
+	late := pledgeAt(timestamppb.New(base.Add(2 * time.Hour)))
+	tiedFirst := pledgeAt(timestamppb.New(base.Add(time.Hour)))
+	early := pledgeAt(timestamppb.New(base))
+	epoch := pledgeAt(&timestamppb.Timestamp{})
+	tiedSecond := pledgeAt(timestamppb.New(base.Add(time.Hour)))
+
+	assert.True(t, untimed.Time().IsZero())
+	assert.True(t, epoch.Time().IsZero())
+	assert.Equal(t, base, early.Time())
+
+	contract := NewContract(project)
+	added := []*Pledge{untimed, late, tiedFirst, early, epoch, tiedSecond}
+	for _, pledge := range added {
+		require.NoError(t, contract.AddPledge(pledge))
+	}
+
+	// Untimestamped pledges go last, and ties keep the order they were added in
+	assert.Equal(t, []*Pledge{early, tiedFirst, tiedSecond, late, untimed, epoch}, contract.PledgesByTime())
+	assert.Equal(t, added, contract.Pledges(), "sorting must not reorder the contract")
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+)
+
+// pledgeCSVHeader names the columns written by PledgesToCSV
+var pledgeCSVHeader = []string{"pledge_id", "amount_bsv", "memo", "contact_name", "contact_email", "refund_address", "timestamp"}
+
+// PledgesToCSV writes one row per pledge, after a header row, for import
+// into spreadsheets. Missing optional fields are written as empty cells and
+// timestamps are in RFC 3339 UTC. Pledger-supplied text that a spreadsheet
+// would run as a formula is prefixed with a quote.
+func PledgesToCSV(w io.Writer, pledges []*Pledge) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(pledgeCSVHeader); err != nil {
+		return err
+	}
+
+	for _, pledge := range pledges {
+		name, email := pledge.ContactInfo()
+		timestamp := ""
+		if t := pledge.Time(); !t.IsZero() {
+			timestamp = t.UTC().Format(time.RFC3339)
+		}
+		row := []string{
+			pledge.ID(),
+			SatoshisToBSV(pledge.Amount()),
+			csvText(pledge.Memo()),
+			csvText(name),
+			csvText(email),
+			csvText(pledge.RefundAddress()),
+			timestamp,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvText keeps spreadsheets from evaluating text as a formula
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
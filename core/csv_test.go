@@ -0,0 +1,43 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPledgesToCSV(t *testing.T) {
+	project, err := NewProject("CSV Test", "Testing CSV export", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	full := createSignedTestPledge(t, project, 25000000)
+	require.NoError(t, full.SetMemo("Good luck, team"))
+	require.NoError(t, full.SetContactInfo("Alice", "alice@example.com"))
+	require.NoError(t, full.SetRefundAddress("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"))
+
+	bare := createSignedTestPledge(t, project, 10000000)
+	require.NoError(t, bare.SetMemo("=HYPERLINK(\"http://example.com\")"))
+
+	var buf bytes.Buffer
+	require.NoError(t, PledgesToCSV(&buf, []*Pledge{full, bare}))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, pledgeCSVHeader, rows[0])
+
+	assert.Equal(t, []string{
+		full.ID(), "0.25000000", "Good luck, team", "Alice", "alice@example.com",
+		"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", full.Time().UTC().Format(time.RFC3339),
+	}, rows[1])
+
+	// Optional fields are empty and formulas are defused
+	assert.Equal(t, bare.ID(), rows[2][0])
+	assert.Equal(t, "0.10000000", rows[2][1])
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", rows[2][2])
+	assert.Equal(t, []string{"", "", ""}, rows[2][3:6])
+}
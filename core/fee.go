@@ -0,0 +1,142 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultFeeRate is the default fee rate in satoshis per kilobyte
+const DefaultFeeRate = uint64(50)
+
+// MaxFeeRate is the highest fee rate, in satoshis per kilobyte, a pledge
+// may pay its share of the claim fee at
+const MaxFeeRate = uint64(1000)
+
+// DustThreshold is the smallest P2PKH output value, in satoshis, that
+// nodes will relay
+const DustThreshold = uint64(546)
+
+// p2pkhOutputSize is the size of a P2PKH output: satoshis, script length
+// and the 25 byte script
+const p2pkhOutputSize = 8 + 1 + 25
+
+// p2pkhUnlockingScriptSize is the typical size of a signed P2PKH unlocking
+// script: <push> <DER signature + sighash byte> <push> <compressed pubkey>
+const p2pkhUnlockingScriptSize = 107
+
+// EstimateFee estimates the fee for a transaction at the given rate (sat/KB)
+func EstimateFee(tx *transaction.Transaction, satPerKB uint64) uint64 {
+	size := uint64(estimateSize(tx))
+	// Round up so we never underpay
+	return (size*satPerKB + 999) / 1000
+}
+
+// FeeAllowance is how much a pledge spending n inputs must carry above its
+// amount toward the claim fee at feeRate sat/KB: the fee for its own inputs
+// plus the claim overhead (version, lock time and project outputs). The
+// amount counts towards the goal and the allowance pays the miners, so the
+// project outputs receive exactly the goal.
+//
+// The claim fee is the whole transaction size times the rate, rounded up.
+// Each allowance rounds its parts up separately, and a sum of rounded-up
+// parts is never less than the rounded-up sum, so pledges that all carry
+// their allowance cover the fee at that rate however many there are. No
+// pledge knows how many others there will be, so each pays the whole
+// overhead; Combine accepts the resulting surplus, up to MaxFeeContribution
+// per pledge.
+func FeeAllowance(project *Project, n int, feeRate uint64) (uint64, error) {
+	overhead, err := ClaimOverheadFee(project, feeRate)
+	if err != nil {
+		return 0, err
+	}
+	return inputsFee(n, feeRate) + overhead, nil
+}
+
+// MaxFeeContribution is the most a pledge spending n inputs may carry above
+// its amount for Combine to pass it on to the miners: its FeeAllowance at
+// MaxFeeRate, plus the fee for a change transaction splitting its inputs,
+// plus change under DustThreshold. A pledge made at any rate up to
+// MaxFeeRate stays within it, including one made without change because
+// the change would have been dust or couldn't pay for its own transaction.
+func MaxFeeContribution(project *Project, n int) (uint64, error) {
+	outputs, err := project.Outputs()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get project outputs: %w", err)
+	}
+	return maxFeeContribution(outputs, n), nil
+}
+
+// maxFeeContribution is MaxFeeContribution for a claim paying outputs
+func maxFeeContribution(outputs []*transaction.TransactionOutput, n int) uint64 {
+	return inputsFee(n, MaxFeeRate) + overheadFee(outputs, MaxFeeRate) + changeTxFee(n, MaxFeeRate) + DustThreshold
+}
+
+// changeTxFee is the fee at feeRate for a transaction splitting n P2PKH
+// inputs into two P2PKH outputs, as NewPledgeWithChange builds
+func changeTxFee(n int, feeRate uint64) uint64 {
+	size := 4 + varIntSize(uint64(n)) + n*p2pkhInputSize + 1 + 2*p2pkhOutputSize + 4
+	return (uint64(size)*feeRate + 999) / 1000
+}
+
+// ClaimOverheadFee is the fee at feeRate sat/KB for the part of a claim
+// transaction that doesn't depend on its pledges
+func ClaimOverheadFee(project *Project, feeRate uint64) (uint64, error) {
+	outputs, err := project.Outputs()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get project outputs: %w", err)
+	}
+	return overheadFee(outputs, feeRate), nil
+}
+
+// overheadFee is the fee for a transaction with the given outputs and no
+// inputs
+func overheadFee(outputs []*transaction.TransactionOutput, feeRate uint64) uint64 {
+	tx := transaction.NewTransaction()
+	for _, out := range outputs {
+		tx.AddOutput(out)
+	}
+	return EstimateFee(tx, feeRate)
+}
+
+// estimateSize returns the serialized size of a transaction in bytes.
+// Unsigned inputs are assumed to be P2PKH.
+func estimateSize(tx *transaction.Transaction) int {
+	size := 4 // version
+	size += varIntSize(uint64(len(tx.Inputs)))
+	for _, input := range tx.Inputs {
+		scriptLen := p2pkhUnlockingScriptSize
+		if input.UnlockingScript != nil && len(*input.UnlockingScript) > 0 {
+			scriptLen = len(*input.UnlockingScript)
+		}
+		// txid + vout + script length + script + sequence
+		size += 32 + 4 + varIntSize(uint64(scriptLen)) + scriptLen + 4
+	}
+
+	size += varIntSize(uint64(len(tx.Outputs)))
+	for _, output := range tx.Outputs {
+		scriptLen := 0
+		if output.LockingScript != nil {
+			scriptLen = len(*output.LockingScript)
+		}
+		// satoshis + script length + script
+		size += 8 + varIntSize(uint64(scriptLen)) + scriptLen
+	}
+
+	size += 4 // lock time
+	return size
+}
+
+// varIntSize returns the number of bytes needed to encode n as a varint
+func varIntSize(n uint64) int {
+	switch {
+	case n < 0xfd:
+		return 1
+	case n <= 0xffff:
+		return 3
+	case n <= 0xffffffff:
+		return 5
+	default:
+		return 9
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateFee(t *testing.T) {
+	lockingScript, err := script.NewFromHex("76a914" + "1234567890123456789012345678901234567890" + "88ac")
+	require.NoError(t, err)
+
+	buildTx := func(inputs, outputs int) *transaction.Transaction {
+		tx := transaction.NewTransaction()
+		for i := 0; i < inputs; i++ {
+			tx.AddInput(&transaction.TransactionInput{
+				SourceTXID:       &chainhash.Hash{},
+				SourceTxOutIndex: uint32(i),
+				SequenceNumber:   0xffffffff,
+			})
+		}
+		for i := 0; i < outputs; i++ {
+			tx.AddOutput(&transaction.TransactionOutput{
+				Satoshis:      1000,
+				LockingScript: lockingScript,
+			})
+		}
+		return tx
+	}
+
+	testCases := []struct {
+		name     string
+		inputs   int
+		outputs  int
+		expected int
+	}{
+		{name: "one input one output", inputs: 1, outputs: 1, expected: 192},
+		{name: "two inputs two outputs", inputs: 2, outputs: 2, expected: 374},
+		{name: "ten inputs one output", inputs: 10, outputs: 1, expected: 1524},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := buildTx(tc.inputs, tc.outputs)
+			assert.Equal(t, tc.expected, estimateSize(tx))
+
+			// At 1000 sat/KB the fee equals the size in bytes
+			assert.Equal(t, uint64(tc.expected), EstimateFee(tx, 1000))
+		})
+	}
+
+	t.Run("default rate rounds up", func(t *testing.T) {
+		tx := buildTx(1, 1)
+		// 192 bytes at 50 sat/KB = 9.6 satoshis
+		assert.Equal(t, uint64(10), EstimateFee(tx, DefaultFeeRate))
+	})
+
+	t.Run("fee grows with input count", func(t *testing.T) {
+		small := EstimateFee(buildTx(1, 1), DefaultFeeRate)
+		large := EstimateFee(buildTx(50, 1), DefaultFeeRate)
+		assert.Greater(t, large, small)
+	})
+}
+
+func TestFeeAllowance(t *testing.T) {
+	project, err := NewProject("Fee Test", "Testing fee allowances", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", NetworkMainnet)
+	require.NoError(t, err)
+	outputs, err := project.Outputs()
+	require.NoError(t, err)
+
+	t.Run("inputs plus overhead", func(t *testing.T) {
+		// One P2PKH output: 4 + 1 + 1 + 34 + 4 = 44 bytes, or 2.2 satoshis
+		overhead, err := ClaimOverheadFee(project, DefaultFeeRate)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), overhead)
+
+		// Each 148 byte input is 7.4 satoshis
+		allowance, err := FeeAllowance(project, 1, DefaultFeeRate)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8+3), allowance)
+		allowance, err = FeeAllowance(project, 2, DefaultFeeRate)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(15+3), allowance)
+	})
+
+	t.Run("allowances cover any claim", func(t *testing.T) {
+		for _, rate := range []uint64{1, DefaultFeeRate, 1000, 12345} {
+			for _, inputs := range [][]int{{1}, {1, 1}, {3, 1, 2}, {1, 1, 1, 1, 1, 1, 1, 1, 1, 1}} {
+				tx := transaction.NewTransaction()
+				total := uint64(0)
+				for _, n := range inputs {
+					allowance, err := FeeAllowance(project, n, rate)
+					require.NoError(t, err)
+					total += allowance
+					for i := 0; i < n; i++ {
+						tx.AddInput(&transaction.TransactionInput{SourceTXID: &chainhash.Hash{}, SequenceNumber: 0xffffffff})
+					}
+				}
+				for _, out := range outputs {
+					tx.AddOutput(out)
+				}
+
+				fee := EstimateFee(tx, rate)
+				assert.GreaterOrEqual(t, total, fee, "rate %d, inputs %v", rate, inputs)
+			}
+		}
+	})
+
+	t.Run("maximum contribution covers a pledge without change", func(t *testing.T) {
+		// Without change, a pledge carries its allowance for a single input
+		// plus what the change transaction would have left: its fee and
+		// change under the dust threshold
+		for _, n := range []int{1, 2, 5, 20} {
+			for _, rate := range []uint64{1, DefaultFeeRate, MaxFeeRate} {
+				allowance, err := FeeAllowance(project, 1, rate)
+				require.NoError(t, err)
+				withoutChange := allowance + changeTxFee(n, rate) + DustThreshold - 1
+
+				limit, err := MaxFeeContribution(project, n)
+				require.NoError(t, err)
+				assert.LessOrEqual(t, withoutChange, limit, "%d inputs at %d sat/KB", n, rate)
+			}
+		}
+		assert.Equal(t, inputsFee(1, MaxFeeRate)+overheadFee(outputs, MaxFeeRate)+changeTxFee(1, MaxFeeRate)+DustThreshold, maxFeeContribution(outputs, 1))
+	})
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.pledge")
+
+	t.Run("creates and replaces", func(t *testing.T) {
+		require.NoError(t, AtomicWriteFile(path, []byte("first"), 0644))
+		require.NoError(t, AtomicWriteFile(path, []byte("second"), 0600))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "second", string(data))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("interrupted before rename", func(t *testing.T) {
+		require.NoError(t, AtomicWriteFile(path, []byte("original"), 0644))
+
+		var tmpData []byte
+		renameFile = func(oldpath, newpath string) error {
+			// The new data is complete on disk, but not yet in place
+			var err error
+			tmpData, err = os.ReadFile(oldpath)
+			require.NoError(t, err)
+			return errors.New("interrupted")
+		}
+		defer func() { renameFile = os.Rename }()
+
+		err := AtomicWriteFile(path, []byte("replacement"), 0644)
+		assert.ErrorContains(t, err, "interrupted")
+		assert.Equal(t, "replacement", string(tmpData))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "original", string(data))
+
+		// The temp file is cleaned up
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "test.pledge", entries[0].Name())
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		err := AtomicWriteFile(filepath.Join(dir, "missing", "test.pledge"), []byte("data"), 0644)
+		assert.Error(t, err)
+	})
+}
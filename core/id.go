@@ -0,0 +1,88 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// IDHasher turns the bytes identifying a project or pledge into its ID, a
+// hex string of at least MinIDBytes bytes
+type IDHasher interface {
+	HashID(data []byte) string
+}
+
+// IDHasherFunc adapts a plain function to an IDHasher
+type IDHasherFunc func(data []byte) string
+
+// HashID calls f(data)
+func (f IDHasherFunc) HashID(data []byte) string {
+	return f(data)
+}
+
+// SHA256Hasher is the default IDHasher: hex-encoded SHA-256
+var SHA256Hasher IDHasher = IDHasherFunc(func(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+})
+
+// DoubleSHA256Hasher hashes twice, as Bitcoin does for txids. The hex is
+// in hash order, not the reversed order txids are displayed in.
+var DoubleSHA256Hasher IDHasher = IDHasherFunc(func(data []byte) string {
+	first := sha256.Sum256(data)
+	hash := sha256.Sum256(first[:])
+	return hex.EncodeToString(hash[:])
+})
+
+// MinIDBytes is the shortest ID, in bytes before hex encoding, a hasher may
+// produce. Shorter IDs collide too easily and break callers that show a
+// prefix of them.
+const MinIDBytes = 8
+
+// TruncatedSHA256Hasher returns an IDHasher that keeps the first n bytes of
+// the SHA-256 hash, for systems with shorter identifiers. n must be between
+// MinIDBytes and the full 32 bytes.
+func TruncatedSHA256Hasher(n int) (IDHasher, error) {
+	if n < MinIDBytes || n > sha256.Size {
+		return nil, fmt.Errorf("ID length must be between %d and %d bytes, got %d", MinIDBytes, sha256.Size, n)
+	}
+	return IDHasherFunc(func(data []byte) string {
+		hash := sha256.Sum256(data)
+		return hex.EncodeToString(hash[:n])
+	}), nil
+}
+
+var (
+	idHasherMu sync.RWMutex
+	// idHasher computes project and pledge IDs
+	idHasher = SHA256Hasher
+)
+
+// SetIDHasher changes how project and pledge IDs are computed, or restores
+// the SHA-256 default when h is nil. It fails if h doesn't produce hex of at
+// least MinIDBytes bytes. IDs are computed when projects and pledges are
+// created or loaded, so set it once at startup: changing it later leaves
+// existing objects with IDs from the old hasher.
+func SetIDHasher(h IDHasher) error {
+	if h == nil {
+		h = SHA256Hasher
+	}
+	id := h.HashID([]byte("lighthouse"))
+	if decoded, err := hex.DecodeString(id); err != nil || len(decoded) < MinIDBytes {
+		return fmt.Errorf("ID hasher must produce hex of at least %d bytes, got %q", MinIDBytes, id)
+	}
+
+	idHasherMu.Lock()
+	defer idHasherMu.Unlock()
+	idHasher = h
+	return nil
+}
+
+// hashID computes an ID with the configured hasher
+func hashID(data []byte) string {
+	idHasherMu.RLock()
+	h := idHasher
+	idHasherMu.RUnlock()
+	return h.HashID(data)
+}
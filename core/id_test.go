@@ -0,0 +1,67 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDHashers(t *testing.T) {
+	data := []byte("abc")
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", SHA256Hasher.HashID(data))
+	assert.Equal(t, "4f8b42c22dd3729b519ba6f68d2da7cc5b2d606d05daed5ad5128cc03e6c6358", DoubleSHA256Hasher.HashID(data))
+
+	truncated, err := TruncatedSHA256Hasher(16)
+	require.NoError(t, err)
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223", truncated.HashID(data))
+	full, err := TruncatedSHA256Hasher(32)
+	require.NoError(t, err)
+	assert.Equal(t, SHA256Hasher.HashID(data), full.HashID(data))
+
+	for _, n := range []int{-1, 0, MinIDBytes - 1, 33} {
+		_, err := TruncatedSHA256Hasher(n)
+		assert.Error(t, err, n)
+	}
+
+	// The default is SHA-256
+	assert.Equal(t, SHA256Hasher.HashID(data), hashID(data))
+}
+
+func TestSetIDHasher(t *testing.T) {
+	defer func() { require.NoError(t, SetIDHasher(nil)) }()
+
+	newProject := func() *Project {
+		project, err := NewProject("Hasher Test", "Testing ID hashers", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+		require.NoError(t, err)
+		return project
+	}
+	defaultID := newProject().ID()
+	assert.Len(t, defaultID, 64)
+
+	hasher, err := TruncatedSHA256Hasher(8)
+	require.NoError(t, err)
+	require.NoError(t, SetIDHasher(hasher))
+	truncated := newProject()
+	assert.Equal(t, defaultID[:16], truncated.ID())
+
+	// IDs are recomputed with the current hasher on load
+	data, err := truncated.Serialize()
+	require.NoError(t, err)
+	custom := strings.Repeat("ab", MinIDBytes)
+	require.NoError(t, SetIDHasher(IDHasherFunc(func(data []byte) string { return custom })))
+	loaded, err := LoadProject(data)
+	require.NoError(t, err)
+	assert.Equal(t, custom, loaded.ID())
+
+	// Hashers that don't produce long enough hex IDs are refused
+	for _, id := range []string{"custom", "abcd", ""} {
+		id := id
+		assert.Error(t, SetIDHasher(IDHasherFunc(func(data []byte) string { return id })), id)
+	}
+	assert.Equal(t, custom, newProject().ID())
+
+	require.NoError(t, SetIDHasher(nil))
+	assert.Equal(t, defaultID, newProject().ID())
+}
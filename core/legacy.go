@@ -0,0 +1,249 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	pb "github.com/yourusername/lighthouse/core/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The original bitcoinj-based Lighthouse stores projects as a BIP70-style
+// envelope (LHProtos.proto):
+//
+//	Project        { payment_details_version = 1; serialized_payment_details = 2;
+//	                 pki_type = 3; pki_data = 4; signature = 5 }
+//	ProjectDetails { network = 1; outputs = 2; time = 3; expires = 4; memo = 5;
+//	                 payment_url = 6; merchant_data = 7; extra_details = 101 }
+//	ExtraDetails   { title = 1; cover_image = 2; auth_key = 3;
+//	                 auth_key_index = 4; min_pledge_size = 5 }
+//	Output         { amount = 1; script = 2 }
+//
+// Legacy pledges embed whole dependency transactions and are not imported;
+// pledgers should pledge again against the imported project.
+
+// legacyField is one decoded field of a protobuf message
+type legacyField struct {
+	num    protowire.Number
+	varint uint64
+	bytes  []byte
+}
+
+// parseLegacyMessage decodes the top level fields of a protobuf message,
+// keeping repeated fields in order
+func parseLegacyMessage(data []byte) ([]legacyField, error) {
+	var fields []legacyField
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		field := legacyField{num: num}
+		switch typ {
+		case protowire.VarintType:
+			field.varint, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			field.bytes, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// ImportLegacyProject converts a project file from the original Lighthouse
+// app into this package's format. Fields with no equivalent are dropped; use
+// ImportLegacyProjectWithWarnings to find out which.
+func ImportLegacyProject(data []byte) (*Project, error) {
+	project, _, err := ImportLegacyProjectWithWarnings(data)
+	return project, err
+}
+
+// ImportLegacyProjectWithWarnings converts a legacy Lighthouse project and
+// describes each field that could not be carried over
+func ImportLegacyProjectWithWarnings(data []byte) (*Project, []string, error) {
+	fields, err := parseLegacyMessage(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse legacy project: %w", err)
+	}
+
+	var (
+		details  []byte
+		warnings []string
+	)
+	for _, field := range fields {
+		switch field.num {
+		case 1: // payment_details_version
+		case 2:
+			details = field.bytes
+		case 3:
+			if pkiType := string(field.bytes); pkiType != "" && pkiType != "none" {
+				warnings = append(warnings, fmt.Sprintf("dropped %s PKI signature: certificate signing is not supported", pkiType))
+			}
+		case 4: // pki_data, reported with pki_type
+		case 5:
+			warnings = append(warnings, "dropped legacy signature: sign the project again with \"project update\"")
+		default:
+			warnings = append(warnings, fmt.Sprintf("dropped unknown project field %d", field.num))
+		}
+	}
+	if details == nil {
+		return nil, nil, errors.New("legacy project has no payment details")
+	}
+
+	proj := &pb.Project{
+		Version: MaxSupportedVersion,
+		Details: &pb.ProjectDetails{Network: NetworkMainnet},
+		Extra:   &pb.ProjectExtraDetails{MinPledgeAmount: 10000},
+	}
+	detailWarnings, err := importLegacyDetails(details, proj)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings = append(warnings, detailWarnings...)
+
+	if proj.Extra.Title == "" {
+		return nil, nil, errors.New("legacy project has no title")
+	}
+	if len(proj.Details.Outputs) == 0 {
+		return nil, nil, errors.New("legacy project has no outputs")
+	}
+
+	goalAmount := uint64(0)
+	for i, output := range proj.Details.Outputs {
+		if output.Amount == 0 {
+			return nil, nil, fmt.Errorf("output %d amount must be greater than 0", i)
+		}
+		if goalAmount > math.MaxUint64-output.Amount {
+			return nil, nil, errors.New("goal amount overflows")
+		}
+		goalAmount += output.Amount
+	}
+	if proj.Extra.MinPledgeAmount > goalAmount {
+		warnings = append(warnings, fmt.Sprintf("dropped minimum pledge %d: exceeds goal amount %d", proj.Extra.MinPledgeAmount, goalAmount))
+		proj.Extra.MinPledgeAmount = 10000
+	}
+
+	// Re-apply the cover image through the normal size and format checks
+	coverImage := proj.Extra.CoverImage
+	proj.Extra.CoverImage = nil
+
+	project, err := projectFromPB(proj)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(coverImage) > 0 {
+		if err := project.SetCoverImage(coverImage); err != nil {
+			warnings = append(warnings, fmt.Sprintf("dropped cover image: %v", err))
+		}
+	}
+
+	return project, warnings, nil
+}
+
+// importLegacyDetails maps legacy ProjectDetails and ExtraDetails onto proj
+func importLegacyDetails(data []byte, proj *pb.Project) ([]string, error) {
+	fields, err := parseLegacyMessage(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse legacy project details: %w", err)
+	}
+
+	var warnings []string
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			switch network := string(field.bytes); network {
+			case "main":
+				proj.Details.Network = NetworkMainnet
+			case "test":
+				proj.Details.Network = NetworkTestnet
+			default:
+				return nil, fmt.Errorf("unsupported legacy network %q", network)
+			}
+		case 2:
+			output, err := importLegacyOutput(field.bytes)
+			if err != nil {
+				return nil, err
+			}
+			proj.Details.Outputs = append(proj.Details.Outputs, output)
+		case 3:
+			proj.Details.Time = timestamppb.New(time.Unix(int64(field.varint), 0))
+		case 4:
+			proj.Details.Expires = timestamppb.New(time.Unix(int64(field.varint), 0))
+		case 5:
+			proj.Details.Memo = string(field.bytes)
+		case 6:
+			proj.Details.PaymentUrl = string(field.bytes)
+		case 7:
+			proj.Details.MerchantData = field.bytes
+		case 101:
+			extraWarnings, err := importLegacyExtra(field.bytes, proj.Extra)
+			if err != nil {
+				return nil, err
+			}
+			warnings = append(warnings, extraWarnings...)
+		default:
+			warnings = append(warnings, fmt.Sprintf("dropped unknown details field %d", field.num))
+		}
+	}
+	return warnings, nil
+}
+
+// importLegacyExtra maps legacy ExtraDetails onto extra
+func importLegacyExtra(data []byte, extra *pb.ProjectExtraDetails) ([]string, error) {
+	fields, err := parseLegacyMessage(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse legacy extra details: %w", err)
+	}
+
+	var warnings []string
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			extra.Title = string(field.bytes)
+		case 2:
+			extra.CoverImage = field.bytes
+		case 3:
+			extra.AuthKey = field.bytes
+		case 4:
+			warnings = append(warnings, "dropped auth key index: it refers to the legacy app's wallet")
+		case 5:
+			extra.MinPledgeAmount = field.varint
+		default:
+			warnings = append(warnings, fmt.Sprintf("dropped unknown extra details field %d", field.num))
+		}
+	}
+	return warnings, nil
+}
+
+// importLegacyOutput decodes a legacy Output
+func importLegacyOutput(data []byte) (*pb.Output, error) {
+	fields, err := parseLegacyMessage(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse legacy output: %w", err)
+	}
+
+	output := &pb.Output{}
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			output.Amount = field.varint
+		case 2:
+			output.Script = field.bytes
+		}
+	}
+	if len(output.Script) == 0 {
+		return nil, errors.New("legacy output has no script")
+	}
+	return output, nil
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// legacyProjectOptions describes a project in the original Lighthouse format
+type legacyProjectOptions struct {
+	network       string
+	title         string
+	minPledge     uint64
+	authKeyIndex  bool
+	pkiType       string
+	signature     bool
+	outputAmounts []uint64
+}
+
+// buildLegacyProject encodes a project the way the original Lighthouse app does
+func buildLegacyProject(t *testing.T, opts legacyProjectOptions) []byte {
+	addr, err := script.NewAddressFromString("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH")
+	require.NoError(t, err)
+	lockingScript, err := p2pkh.Lock(addr)
+	require.NoError(t, err)
+
+	var extra []byte
+	extra = protowire.AppendTag(extra, 1, protowire.BytesType)
+	extra = protowire.AppendString(extra, opts.title)
+	if opts.authKeyIndex {
+		extra = protowire.AppendTag(extra, 4, protowire.VarintType)
+		extra = protowire.AppendVarint(extra, 3)
+	}
+	if opts.minPledge > 0 {
+		extra = protowire.AppendTag(extra, 5, protowire.VarintType)
+		extra = protowire.AppendVarint(extra, opts.minPledge)
+	}
+
+	var details []byte
+	details = protowire.AppendTag(details, 1, protowire.BytesType)
+	details = protowire.AppendString(details, opts.network)
+	for _, amount := range opts.outputAmounts {
+		var output []byte
+		output = protowire.AppendTag(output, 1, protowire.VarintType)
+		output = protowire.AppendVarint(output, amount)
+		output = protowire.AppendTag(output, 2, protowire.BytesType)
+		output = protowire.AppendBytes(output, lockingScript.Bytes())
+
+		details = protowire.AppendTag(details, 2, protowire.BytesType)
+		details = protowire.AppendBytes(details, output)
+	}
+	details = protowire.AppendTag(details, 3, protowire.VarintType)
+	details = protowire.AppendVarint(details, 1420070400)
+	details = protowire.AppendTag(details, 4, protowire.VarintType)
+	details = protowire.AppendVarint(details, 1422748800)
+	details = protowire.AppendTag(details, 5, protowire.BytesType)
+	details = protowire.AppendString(details, "A legacy campaign")
+	details = protowire.AppendTag(details, 101, protowire.BytesType)
+	details = protowire.AppendBytes(details, extra)
+
+	var project []byte
+	project = protowire.AppendTag(project, 1, protowire.VarintType)
+	project = protowire.AppendVarint(project, 1)
+	project = protowire.AppendTag(project, 2, protowire.BytesType)
+	project = protowire.AppendBytes(project, details)
+	if opts.pkiType != "" {
+		project = protowire.AppendTag(project, 3, protowire.BytesType)
+		project = protowire.AppendString(project, opts.pkiType)
+	}
+	if opts.signature {
+		project = protowire.AppendTag(project, 5, protowire.BytesType)
+		project = protowire.AppendBytes(project, []byte{0x30, 0x44})
+	}
+	return project
+}
+
+func TestImportLegacyProject(t *testing.T) {
+	t.Run("maps legacy fields", func(t *testing.T) {
+		data := buildLegacyProject(t, legacyProjectOptions{
+			network:       "main",
+			title:         "Legacy Project",
+			minPledge:     50000,
+			outputAmounts: []uint64{60000000, 40000000},
+		})
+
+		project, warnings, err := ImportLegacyProjectWithWarnings(data)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+
+		assert.Equal(t, "Legacy Project", project.Title())
+		assert.Equal(t, "A legacy campaign", project.Description())
+		assert.Equal(t, NetworkMainnet, project.Network())
+		assert.Equal(t, uint64(100000000), project.GoalAmount())
+		assert.Equal(t, uint64(50000), project.MinPledgeAmount())
+		assert.True(t, project.Expires().Equal(time.Unix(1422748800, 0)))
+
+		outputs, err := project.Outputs()
+		require.NoError(t, err)
+		require.Len(t, outputs, 2)
+		assert.Equal(t, uint64(60000000), outputs[0].Satoshis)
+	})
+
+	t.Run("warns about dropped fields", func(t *testing.T) {
+		data := buildLegacyProject(t, legacyProjectOptions{
+			network:       "test",
+			title:         "Signed Legacy Project",
+			authKeyIndex:  true,
+			pkiType:       "x509+sha256",
+			signature:     true,
+			outputAmounts: []uint64{100000000},
+		})
+
+		project, warnings, err := ImportLegacyProjectWithWarnings(data)
+		require.NoError(t, err)
+		assert.Equal(t, NetworkTestnet, project.Network())
+		assert.Len(t, warnings, 3)
+	})
+
+	t.Run("invalid projects", func(t *testing.T) {
+		_, err := ImportLegacyProject([]byte{0xff})
+		assert.Error(t, err)
+
+		_, err = ImportLegacyProject(buildLegacyProject(t, legacyProjectOptions{network: "main", title: "No Outputs"}))
+		assert.Error(t, err)
+
+		_, err = ImportLegacyProject(buildLegacyProject(t, legacyProjectOptions{network: "regtest", title: "Regtest", outputAmounts: []uint64{1000}}))
+		assert.Error(t, err)
+	})
+}
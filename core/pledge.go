@@ -1,35 +1,103 @@
 package core
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
+	"bytes"
 	"errors"
 	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ecies "github.com/bsv-blockchain/go-sdk/compat/ecies"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
 	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
 	pb "github.com/yourusername/lighthouse/core/proto"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	// finalSequence marks an input as final, disabling nLockTime
+	finalSequence = 0xffffffff
+
+	// lockTimeThreshold is where nLockTime switches from block heights to timestamps
+	lockTimeThreshold = 500000000
+)
+
+// Limits on pledger-supplied text, counted in characters (runes) after
+// control characters are stripped
+const (
+	MaxMemoLength         = 500
+	MaxContactNameLength  = 100
+	MaxContactEmailLength = 254
+)
+
+// ErrTextTooLong is returned when a memo or contact field exceeds its limit
+var ErrTextTooLong = errors.New("text too long")
+
+// ErrInvalidEmail is returned for a contact email that isn't a plain address
+var ErrInvalidEmail = errors.New("invalid email address")
+
+// ErrDustChange is returned when the change from a pledge's UTXOs would be
+// too small to relay
+var ErrDustChange = errors.New("change below dust threshold")
+
+// ErrFeeRateTooHigh is returned for a pledge fee rate above MaxFeeRate
+var ErrFeeRateTooHigh = errors.New("fee rate too high")
+
+// ErrFeeContributionTooHigh is returned for a pledge carrying more than its
+// MaxFeeContribution above its amount
+var ErrFeeContributionTooHigh = errors.New("fee contribution too high")
+
+// ErrNoEncryptedContact is returned when decrypting a pledge without encrypted contact info
+var ErrNoEncryptedContact = errors.New("pledge has no encrypted contact info")
+
+// ErrIncompatibleSigHash is returned for a pledge input whose signature
+// would be invalidated by combining it with other pledges
+var ErrIncompatibleSigHash = errors.New("signature cannot be combined with other pledges")
+
+// ErrIncompletePledge is returned for a pledge from a newer version that
+// lacks data this version needs to rebuild its transaction
+var ErrIncompletePledge = errors.New("pledge is incomplete")
+
+// ErrInvalidRefundAddress is returned for a refund address that can't be paid to
+var ErrInvalidRefundAddress = errors.New("invalid refund address")
+
 // Pledge represents a contribution to a project
 type Pledge struct {
 	pb        *pb.Pledge
 	id        string
 	amount    uint64
 	tx        *transaction.Transaction
+	changeTx  *transaction.Transaction
 }
 
-// NewPledge creates a new pledge for a project
+// NewPledge creates a new pledge for a project. The UTXOs must cover the
+// amount plus its FeeAllowance at DefaultFeeRate.
 func NewPledge(project *Project, amount uint64, utxos []*transaction.UTXO) (*Pledge, error) {
+	return NewPledgeWithFeeRate(project, amount, utxos, DefaultFeeRate)
+}
+
+// NewPledgeWithFeeRate creates a pledge whose UTXOs cover the amount plus
+// its share of a claim fee at feeRate sat/KB, as given by FeeAllowance.
+// Anything above that also goes to the miners, up to MaxFeeContribution;
+// UTXOs carrying more are rejected with ErrFeeContributionTooHigh.
+func NewPledgeWithFeeRate(project *Project, amount uint64, utxos []*transaction.UTXO, feeRate uint64) (*Pledge, error) {
 	if amount < project.MinPledgeAmount() {
 		return nil, fmt.Errorf("pledge amount %d is less than minimum %d", amount, project.MinPledgeAmount())
 	}
+	if feeRate > MaxFeeRate {
+		return nil, fmt.Errorf("%w: %d sat/KB, maximum is %d", ErrFeeRateTooHigh, feeRate, MaxFeeRate)
+	}
 
 	// Create a transaction with SIGHASH_ANYONECANPAY inputs
 	tx := transaction.NewTransaction()
@@ -40,15 +108,24 @@ func NewPledge(project *Project, amount uint64, utxos []*transaction.UTXO) (*Ple
 		return nil, fmt.Errorf("failed to add inputs: %w", err)
 	}
 	
+	// Every UTXO becomes an input, so count them all
 	for _, utxo := range utxos {
 		totalInput += utxo.Satoshis
-		if totalInput >= amount {
-			break
-		}
 	}
 
-	if totalInput < amount {
-		return nil, fmt.Errorf("insufficient funds: have %d, need %d", totalInput, amount)
+	allowance, err := FeeAllowance(project, len(utxos), feeRate)
+	if err != nil {
+		return nil, err
+	}
+	if totalInput < amount+allowance {
+		return nil, fmt.Errorf("%w: have %d, need %d (%d plus %d toward the claim fee)", ErrInsufficientFunds, totalInput, amount+allowance, amount, allowance)
+	}
+	limit, err := MaxFeeContribution(project, len(utxos))
+	if err != nil {
+		return nil, err
+	}
+	if totalInput-amount > limit {
+		return nil, fmt.Errorf("%w: %d above the pledge, maximum is %d (pledge with change instead)", ErrFeeContributionTooHigh, totalInput-amount, limit)
 	}
 
 	// Add project outputs
@@ -57,36 +134,46 @@ func NewPledge(project *Project, amount uint64, utxos []*transaction.UTXO) (*Ple
 		return nil, fmt.Errorf("failed to get project outputs: %w", err)
 	}
 
-	// For a pledge, we create outputs proportional to the pledge amount
-	// relative to the project goal
-	pledgeRatio := float64(amount) / float64(project.GoalAmount())
-	
+	// Assurance contract: the pledge commits to the full project outputs,
+	// not a share of them. The SIGHASH_ALL|ANYONECANPAY signature covers
+	// these outputs and only this pledge's inputs, so it stays valid once
+	// combined with other pledges and can't be spent any other way.
 	for _, out := range outputs {
-		pledgeOutput := &transaction.TransactionOutput{
-			Satoshis:      uint64(float64(out.Satoshis) * pledgeRatio),
-			LockingScript: out.LockingScript,
-		}
-		tx.AddOutput(pledgeOutput)
+		tx.AddOutput(out)
 	}
 
 	// Create the pledge protobuf
 	pledge := &pb.Pledge{
 		ProjectId: []byte(project.ID()),
 		Time:      timestamppb.Now(),
+		Amount:    amount,
+		Network:   project.Network(),
 	}
 
-	// Store input information
-	for _, input := range tx.Inputs {
+	// Store input information. Inputs are added in UTXO order.
+	for i, input := range tx.Inputs {
 		pbInput := &pb.Input{
 			TxHash:      input.SourceTXID[:],
 			OutputIndex: input.SourceTxOutIndex,
 			Sequence:    input.SequenceNumber,
+			Satoshis:    utxos[i].Satoshis,
+		}
+		if utxos[i].LockingScript != nil {
+			pbInput.SourceLockingScript = utxos[i].LockingScript.Bytes()
 		}
 		
 		// We'll add the unlock script after signing
 		pledge.Inputs = append(pledge.Inputs, pbInput)
 	}
 
+	// Store the outputs this pledge commits to
+	for _, output := range tx.Outputs {
+		pledge.Outputs = append(pledge.Outputs, &pb.Output{
+			Amount: output.Satoshis,
+			Script: output.LockingScript.Bytes(),
+		})
+	}
+
 	p := &Pledge{
 		pb:     pledge,
 		amount: amount,
@@ -97,27 +184,123 @@ func NewPledge(project *Project, amount uint64, utxos []*transaction.UTXO) (*Ple
 	return p, nil
 }
 
-// Sign signs the pledge with SIGHASH_ANYONECANPAY flag
+// NewPledgeWithChange creates a pledge from UTXOs worth more than the
+// pledge, returning the excess to changeAddress. Every pledge signs the
+// same project outputs, so change can't go in the pledge transaction.
+// Instead a change transaction splits the UTXOs into an output of exactly
+// the pledge plus its share of the claim fee, which the pledge spends, and
+// a change output to changeAddress. The pledged output pays the address of
+// the first UTXO, whose key signs the pledge, so changeAddress can belong
+// to someone else. feeRate is in sat/KB.
+func NewPledgeWithChange(project *Project, amount uint64, utxos []*transaction.UTXO, changeAddress string, feeRate uint64) (*Pledge, error) {
+	if feeRate > MaxFeeRate {
+		return nil, fmt.Errorf("%w: %d sat/KB, maximum is %d", ErrFeeRateTooHigh, feeRate, MaxFeeRate)
+	}
+	if _, err := DetectNetwork(changeAddress); err != nil {
+		return nil, fmt.Errorf("invalid change address: %w", err)
+	}
+	addr, err := script.NewAddressFromString(changeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid change address: %w", err)
+	}
+	lockingScript, err := p2pkh.Lock(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create change script: %w", err)
+	}
+	if len(utxos) == 0 {
+		return nil, fmt.Errorf("%w: no UTXOs", ErrInsufficientFunds)
+	}
+	if utxos[0].LockingScript == nil || !utxos[0].LockingScript.IsP2PKH() {
+		return nil, errors.New("first UTXO must be P2PKH, since its key signs the pledge")
+	}
+	pledgeScript := script.Script(append([]byte(nil), *utxos[0].LockingScript...))
+
+	changeTx := transaction.NewTransaction()
+	if err := changeTx.AddInputsFromUTXOs(utxos...); err != nil {
+		return nil, fmt.Errorf("failed to add inputs: %w", err)
+	}
+	totalInput := uint64(0)
+	for _, utxo := range utxos {
+		totalInput += utxo.Satoshis
+	}
+
+	allowance, err := FeeAllowance(project, 1, feeRate)
+	if err != nil {
+		return nil, err
+	}
+	pledgeValue := amount + allowance
+	changeTx.AddOutput(&transaction.TransactionOutput{Satoshis: pledgeValue, LockingScript: &pledgeScript})
+	changeTx.AddOutput(&transaction.TransactionOutput{LockingScript: lockingScript})
+
+	fee := EstimateFee(changeTx, feeRate)
+	if totalInput < pledgeValue+fee {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrInsufficientFunds, totalInput, pledgeValue+fee)
+	}
+	change := totalInput - pledgeValue - fee
+	if change < DustThreshold {
+		return nil, fmt.Errorf("%w: %d satoshis of change is under %d, pledge without change instead", ErrDustChange, change, DustThreshold)
+	}
+	changeTx.Outputs[1].Satoshis = change
+
+	// The change txid isn't final until it is signed, so Sign fills it in
+	pledge, err := NewPledgeWithFeeRate(project, amount, []*transaction.UTXO{{
+		TxID:          &chainhash.Hash{},
+		Vout:          0,
+		LockingScript: &pledgeScript,
+		Satoshis:      pledgeValue,
+	}}, feeRate)
+	if err != nil {
+		return nil, err
+	}
+	pledge.changeTx = changeTx
+	return pledge, nil
+}
+
+// Sign signs the pledge with SIGHASH_ANYONECANPAY flag. For a pledge with
+// change, privateKeys sign the change transaction's inputs instead, and
+// the pledge input is signed with whichever of them owns the first UTXO.
 func (p *Pledge) Sign(privateKeys []*ec.PrivateKey) error {
 	if p.tx == nil {
 		return errors.New("no transaction to sign")
 	}
 
-	// Sign each input with SIGHASH_ANYONECANPAY
-	for i := range p.tx.Inputs {
-		if i >= len(privateKeys) {
-			return fmt.Errorf("no private key for input %d", i)
-		}
-		
-		// Create P2PKH unlocker with ANYONECANPAY flag
-		anyoneCanPayFlag := sighash.AllForkID | sighash.AnyOneCanPay
-		unlocker, err := p2pkh.Unlock(privateKeys[i], &anyoneCanPayFlag)
+	if p.changeTx != nil {
+		key, err := p.signChange(privateKeys)
 		if err != nil {
-			return fmt.Errorf("failed to create unlocker for input %d: %w", i, err)
+			return err
+		}
+		privateKeys = []*ec.PrivateKey{key}
+	}
+
+	signers := make([]Signer, len(privateKeys))
+	for i, key := range privateKeys {
+		signers[i] = &WIFSigner{Key: key}
+	}
+	return p.signInputs(signers)
+}
+
+// SignWith signs each pledge input with the signer at the same index, for
+// keys that can't be handed over as an ec.PrivateKey. A pledge with change
+// also needs its change transaction signed, so it must use Sign.
+func (p *Pledge) SignWith(signers ...Signer) error {
+	if p.tx == nil {
+		return errors.New("no transaction to sign")
+	}
+	if p.changeTx != nil {
+		return errors.New("pledge with change must be signed with Sign")
+	}
+	return p.signInputs(signers)
+}
+
+// signInputs signs every pledge input and checks the signers used a
+// sighash flag that survives combining
+func (p *Pledge) signInputs(signers []Signer) error {
+	for i := range p.tx.Inputs {
+		if i >= len(signers) {
+			return fmt.Errorf("no signer for input %d", i)
 		}
 
-		// Sign the input
-		unlockingScript, err := unlocker.Sign(p.tx, uint32(i))
+		unlockingScript, err := signers[i].SignInput(p.tx, uint32(i))
 		if err != nil {
 			return fmt.Errorf("failed to sign input %d: %w", i, err)
 		}
@@ -128,19 +311,159 @@ func (p *Pledge) Sign(privateKeys []*ec.PrivateKey) error {
 		p.pb.Inputs[i].UnlockScript = unlockingScript.Bytes()
 	}
 
-	return nil
+	// The ID covers the unlock scripts, so it must match a reloaded pledge
+	p.id = p.calculateID()
+
+	// An external signer may not have used ANYONECANPAY
+	return p.CheckSigHash()
 }
 
-// LoadPledge loads a pledge from serialized data
+// signChange signs the change transaction, points the pledge input at its
+// final txid and returns the key that can spend the pledged output
+func (p *Pledge) signChange(privateKeys []*ec.PrivateKey) (*ec.PrivateKey, error) {
+	for i := range p.changeTx.Inputs {
+		if i >= len(privateKeys) {
+			return nil, fmt.Errorf("no private key for change input %d", i)
+		}
+		unlocker, err := p2pkh.Unlock(privateKeys[i], nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create unlocker for change input %d: %w", i, err)
+		}
+		unlockingScript, err := unlocker.Sign(p.changeTx, uint32(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign change input %d: %w", i, err)
+		}
+		p.changeTx.Inputs[i].UnlockingScript = unlockingScript
+	}
+
+	txid := p.changeTx.TxID()
+	p.tx.Inputs[0].SourceTXID = txid
+	p.pb.Inputs[0].TxHash = txid[:]
+	p.pb.ChangeTx = p.changeTx.Bytes()
+
+	pledged := p.changeTx.Outputs[0].LockingScript.Bytes()
+	for _, key := range privateKeys {
+		if bytes.Equal(pledged[3:23], key.PubKey().Hash()) {
+			return key, nil
+		}
+	}
+	return nil, errors.New("no private key for the pledged output")
+}
+
+// ChangeTransaction returns the transaction that funds the pledge and
+// returns its change, or nil if the pledge spends its UTXOs directly. It
+// must be broadcast before the claim transaction.
+func (p *Pledge) ChangeTransaction() *transaction.Transaction {
+	return p.changeTx
+}
+
+// LoadPledge loads a pledge from serialized data. Fields added by newer
+// versions are kept but ignored; use LoadPledgeWithWarnings to see them.
 func LoadPledge(data []byte) (*Pledge, error) {
+	result, err := LoadPledgeWithWarnings(data)
+	if err != nil {
+		return nil, err
+	}
+	return result.Pledge, nil
+}
+
+// LoadPledgeResult is a loaded pledge and anything about it worth telling
+// the user that didn't stop it loading
+type LoadPledgeResult struct {
+	Pledge   *Pledge
+	Warnings []string
+}
+
+// LoadPledgeWithWarnings loads a pledge like LoadPledge, and warns about
+// any fields this version doesn't know, which usually means the pledge was
+// written by a newer version. Unknown fields are kept, so the pledge
+// serializes (and hashes to its ID) unchanged. If such a pledge is also
+// missing the amount, inputs or outputs, a newer version has probably
+// moved them elsewhere, and it fails with ErrIncompletePledge instead of
+// loading as a broken pledge.
+func LoadPledgeWithWarnings(data []byte) (*LoadPledgeResult, error) {
 	var pledge pb.Pledge
 	if err := proto.Unmarshal(data, &pledge); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal pledge: %w", err)
 	}
 
+	warnings := unknownFields(pledge.ProtoReflect(), "pledge")
+	if len(warnings) > 0 {
+		if err := checkPledgeComplete(&pledge); err != nil {
+			return nil, fmt.Errorf("%w: %v (it has fields this version doesn't understand, so it was probably written by a newer version)", ErrIncompletePledge, err)
+		}
+	}
+
+	p, err := pledgeFromPB(&pledge)
+	if err != nil {
+		return nil, err
+	}
+	return &LoadPledgeResult{Pledge: p, Warnings: warnings}, nil
+}
+
+// checkPledgeComplete checks a decoded pledge has the data needed to
+// rebuild its transaction
+func checkPledgeComplete(pledge *pb.Pledge) error {
+	if pledge.Amount == 0 {
+		return errors.New("no amount")
+	}
+	if len(pledge.Inputs) == 0 {
+		return errors.New("no inputs")
+	}
+	if len(pledge.Outputs) == 0 {
+		return errors.New("no outputs")
+	}
+	for i, input := range pledge.Inputs {
+		if len(input.TxHash) != chainhash.HashSize {
+			return fmt.Errorf("input %d has no transaction hash", i)
+		}
+	}
+	return nil
+}
+
+// unknownFields describes the fields in m and its nested messages that
+// aren't in this version's schema, naming each by its path from name
+func unknownFields(m protoreflect.Message, name string) []string {
+	var found []string
+	for b := m.GetUnknown(); len(b) > 0; {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			found = append(found, fmt.Sprintf("%s has malformed unknown data", name))
+			break
+		}
+		b = b[n:]
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			found = append(found, fmt.Sprintf("%s has malformed unknown data", name))
+			break
+		}
+		b = b[n:]
+		found = append(found, fmt.Sprintf("%s has unknown field %d", name, num))
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		field := name + "." + string(fd.Name())
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				found = append(found, unknownFields(list.Get(i).Message(), fmt.Sprintf("%s[%d]", field, i))...)
+			}
+		} else {
+			found = append(found, unknownFields(v.Message(), field)...)
+		}
+		return true
+	})
+	return found
+}
+
+// pledgeFromPB wraps a decoded pledge protobuf and rebuilds its transaction
+func pledgeFromPB(pledge *pb.Pledge) (*Pledge, error) {
 	// Reconstruct the transaction from the pledge data
 	tx := transaction.NewTransaction()
-	amount := uint64(0)
+	amount := pledge.Amount
 
 	// Add inputs
 	for _, input := range pledge.Inputs {
@@ -157,13 +480,41 @@ func LoadPledge(data []byte) (*Pledge, error) {
 			UnlockingScript:  &unlockScript,
 			SequenceNumber:   input.Sequence,
 		}
+		// The source output lets the signature be verified
+		if len(input.SourceLockingScript) > 0 {
+			lockingScript := script.Script(input.SourceLockingScript)
+			txInput.SetSourceTxOutput(&transaction.TransactionOutput{
+				Satoshis:      input.Satoshis,
+				LockingScript: &lockingScript,
+			})
+		}
 		tx.Inputs = append(tx.Inputs, txInput)
 	}
 
+	tx.LockTime = pledge.LockTime
+
+	var changeTx *transaction.Transaction
+	if len(pledge.ChangeTx) > 0 {
+		var err error
+		if changeTx, err = transaction.NewTransactionFromBytes(pledge.ChangeTx); err != nil {
+			return nil, fmt.Errorf("invalid change transaction: %w", err)
+		}
+	}
+
+	// Add outputs
+	for _, output := range pledge.Outputs {
+		lockScript := script.Script(output.Script)
+		tx.AddOutput(&transaction.TransactionOutput{
+			Satoshis:      output.Amount,
+			LockingScript: &lockScript,
+		})
+	}
+
 	p := &Pledge{
-		pb:     &pledge,
-		amount: amount,
-		tx:     tx,
+		pb:       pledge,
+		amount:   amount,
+		tx:       tx,
+		changeTx: changeTx,
 	}
 	p.id = p.calculateID()
 
@@ -183,8 +534,7 @@ func (p *Pledge) ID() string {
 // calculateID generates a unique ID from pledge data
 func (p *Pledge) calculateID() string {
 	data, _ := p.Serialize()
-	hash := sha256.Sum256(data)
-	return hex.EncodeToString(hash[:])
+	return hashID(data)
 }
 
 // Amount returns the pledged amount in satoshis
@@ -192,37 +542,295 @@ func (p *Pledge) Amount() uint64 {
 	return p.amount
 }
 
+// InputTotal returns the sum of the pledge's input values in satoshis
+func (p *Pledge) InputTotal() uint64 {
+	total := uint64(0)
+	for _, input := range p.pb.Inputs {
+		total += input.Satoshis
+	}
+	return total
+}
+
+// hasInputs reports whether the pledge spends any outputs, both in its
+// transaction and in the input values recorded alongside it
+func (p *Pledge) hasInputs() bool {
+	return p.tx != nil && len(p.tx.Inputs) > 0 && len(p.pb.Inputs) > 0
+}
+
+// FeeContribution returns how much the pledge's inputs carry above its
+// amount toward the claim fee
+func (p *Pledge) FeeContribution() uint64 {
+	if total := p.InputTotal(); total > p.amount {
+		return total - p.amount
+	}
+	return 0
+}
+
 // ProjectID returns the ID of the project this pledge is for
 func (p *Pledge) ProjectID() string {
 	return string(p.pb.ProjectId)
 }
 
-// SetMemo sets a message from the pledger
-func (p *Pledge) SetMemo(memo string) {
+// SetMemo sets a message from the pledger. Control characters other than
+// newlines and tabs are stripped, and memos longer than MaxMemoLength
+// characters are rejected.
+func (p *Pledge) SetMemo(memo string) error {
+	memo = sanitizeText(memo, true)
+	if n := utf8.RuneCountInString(memo); n > MaxMemoLength {
+		return fmt.Errorf("%w: memo is %d characters, limit is %d", ErrTextTooLong, n, MaxMemoLength)
+	}
 	p.pb.Memo = memo
 	p.id = p.calculateID()
+	return nil
 }
 
-// SetRefundAddress sets where to refund if project fails
-func (p *Pledge) SetRefundAddress(address string) {
+// Memo returns the message from the pledger, if any
+func (p *Pledge) Memo() string {
+	return p.pb.Memo
+}
+
+// SetRefundAddress sets where to refund if project fails. The address must
+// be valid and on the pledge's network; an empty address clears it, so
+// refunds go back to the pledge's input address.
+func (p *Pledge) SetRefundAddress(address string) error {
+	if address != "" {
+		network, err := DetectNetwork(address)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRefundAddress, err)
+		}
+		if p.pb.Network != "" && network != p.pb.Network {
+			return fmt.Errorf("%w: %s address for a %s pledge", ErrInvalidRefundAddress, network, p.pb.Network)
+		}
+	}
 	p.pb.RefundAddress = address
 	p.id = p.calculateID()
+	return nil
 }
 
-// SetContactInfo sets optional contact information
-func (p *Pledge) SetContactInfo(name, email string) {
+// Network returns the network the pledge was made on. Pledges created
+// before the network was recorded return an empty string.
+func (p *Pledge) Network() string {
+	return p.pb.Network
+}
+
+// RefundAddress returns where to refund if the project fails, if set
+func (p *Pledge) RefundAddress() string {
+	return p.pb.RefundAddress
+}
+
+// SetContactInfo sets optional contact information. Either field may be
+// empty; see sanitizeContact for the checks applied.
+func (p *Pledge) SetContactInfo(name, email string) error {
+	name, email, err := sanitizeContact(name, email)
+	if err != nil {
+		return err
+	}
 	p.pb.Contact = &pb.ContactInfo{
 		Name:  name,
 		Email: email,
 	}
+	p.pb.EncryptedContact = nil
+	p.id = p.calculateID()
+	return nil
+}
+
+// ContactInfo returns the pledger's plaintext contact details, if any.
+// Encrypted contact details are only available through DecryptContact.
+func (p *Pledge) ContactInfo() (name, email string) {
+	if p.pb.Contact == nil {
+		return "", ""
+	}
+	return p.pb.Contact.Name, p.pb.Contact.Email
+}
+
+// sanitizeContact strips control characters from contact details, enforces
+// the length limits and checks the email is a bare address
+func sanitizeContact(name, email string) (string, string, error) {
+	name = strings.TrimSpace(sanitizeText(name, false))
+	email = strings.TrimSpace(sanitizeText(email, false))
+
+	if n := utf8.RuneCountInString(name); n > MaxContactNameLength {
+		return "", "", fmt.Errorf("%w: contact name is %d characters, limit is %d", ErrTextTooLong, n, MaxContactNameLength)
+	}
+	if n := utf8.RuneCountInString(email); n > MaxContactEmailLength {
+		return "", "", fmt.Errorf("%w: contact email is %d characters, limit is %d", ErrTextTooLong, n, MaxContactEmailLength)
+	}
+	if email != "" {
+		// Require a bare address with a dotted domain, not "Name <addr>"
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email || !strings.Contains(email[strings.LastIndex(email, "@"):], ".") {
+			return "", "", fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+		}
+	}
+	return name, email, nil
+}
+
+// sanitizeText removes invalid UTF-8 and control characters, optionally
+// keeping newlines and tabs
+func sanitizeText(s string, multiline bool) string {
+	return strings.Map(func(r rune) rune {
+		if multiline && (r == '\n' || r == '\t') {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(s, ""))
+}
+
+// SetEncryptedContact encrypts contact information to the project's auth
+// key with ECIES, so only the campaign owner can read it. Any plaintext
+// contact info is cleared.
+func (p *Pledge) SetEncryptedContact(name, email string, projectPubKey *ec.PublicKey) error {
+	if projectPubKey == nil {
+		return ErrNoAuthKey
+	}
+	name, email, err := sanitizeContact(name, email)
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(&pb.ContactInfo{
+		Name:  name,
+		Email: email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal contact info: %w", err)
+	}
+
+	encrypted, err := ecies.ElectrumEncrypt(data, projectPubKey, nil, false)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt contact info: %w", err)
+	}
+
+	p.pb.Contact = nil
+	p.pb.EncryptedContact = encrypted
+	p.id = p.calculateID()
+	return nil
+}
+
+// HasEncryptedContact reports whether the pledge carries encrypted contact info
+func (p *Pledge) HasEncryptedContact() bool {
+	return len(p.pb.EncryptedContact) > 0
+}
+
+// DecryptContact decrypts contact info set with SetEncryptedContact using
+// the project owner's auth private key
+func (p *Pledge) DecryptContact(ownerKey *ec.PrivateKey) (name, email string, err error) {
+	if !p.HasEncryptedContact() {
+		return "", "", ErrNoEncryptedContact
+	}
+
+	data, err := ecies.ElectrumDecrypt(p.pb.EncryptedContact, ownerKey, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt contact info: %w", err)
+	}
+
+	var contact pb.ContactInfo
+	if err := proto.Unmarshal(data, &contact); err != nil {
+		return "", "", fmt.Errorf("failed to unmarshal contact info: %w", err)
+	}
+	return contact.Name, contact.Email, nil
+}
+
+// SetTimelock sets the pledge's nLockTime to a block height, or clears it
+// when height is 0. Inputs get a non-final sequence number so the lock time
+// is enforced.
+//
+// The lock applies to the claim transaction, which can't be mined before
+// that height; it is a "not claimable before" lock, not a deadline after
+// which funds free up. A pledge never locks the pledger's coins: they can
+// be reclaimed at any time by spending the pledged inputs elsewhere, e.g.
+// with the transactions from Contract.BuildRefunds.
+//
+// A SIGHASH_ALL|ANYONECANPAY signature commits to nLockTime and to the
+// signed input's own sequence number, so this clears any existing
+// signatures and the pledge must be signed again. The combined transaction
+// carries the same lock time, so all pledges in a contract must agree on it.
+func (p *Pledge) SetTimelock(height uint32) {
+	sequence := uint32(finalSequence)
+	if height > 0 {
+		sequence = finalSequence - 1
+	}
+
+	p.tx.LockTime = height
+	p.pb.LockTime = height
+	for i, input := range p.tx.Inputs {
+		input.SequenceNumber = sequence
+		input.UnlockingScript = nil
+		p.pb.Inputs[i].Sequence = sequence
+		p.pb.Inputs[i].UnlockScript = nil
+	}
 	p.id = p.calculateID()
 }
 
+// Time returns when the pledge was made, or the zero time if it wasn't
+// recorded. The time isn't signed, so it is only the pledger's claim.
+func (p *Pledge) Time() time.Time {
+	if p.pb.Time == nil || (p.pb.Time.Seconds == 0 && p.pb.Time.Nanos == 0) {
+		return time.Time{}
+	}
+	return p.pb.Time.AsTime()
+}
+
+// Timelock returns the block height the claim is locked until (nLockTime),
+// or 0 if none is set
+func (p *Pledge) Timelock() uint32 {
+	return p.pb.LockTime
+}
+
 // Transaction returns the underlying transaction
 func (p *Pledge) Transaction() *transaction.Transaction {
 	return p.tx
 }
 
+// CheckInputs looks up whether each input's output is still unspent. A
+// pledge with any spent input can never be claimed.
+func (p *Pledge) CheckInputs(checker UTXOChecker) ([]InputStatus, error) {
+	if p.tx == nil {
+		return nil, errors.New("no transaction")
+	}
+
+	statuses := make([]InputStatus, 0, len(p.tx.Inputs))
+	for i, input := range p.tx.Inputs {
+		unspent, err := p.inputUnspent(i, checker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check input %s: %w", outpointKey(input), err)
+		}
+		statuses = append(statuses, InputStatus{Outpoint: outpointKey(input), Unspent: unspent})
+	}
+	return statuses, nil
+}
+
+// inputUnspent asks checker whether the output input i spends exists, holds
+// the value and script recorded in the pledge, and is unspent. The output
+// of a change transaction only exists once the change transaction is
+// broadcast, which happens with the claim, so until then the outputs the
+// change transaction spends are checked instead.
+func (p *Pledge) inputUnspent(i int, checker UTXOChecker) (bool, error) {
+	input := p.tx.Inputs[i]
+	expected := input.SourceTxOutput()
+	if expected == nil && i < len(p.pb.Inputs) {
+		expected = &transaction.TransactionOutput{Satoshis: p.pb.Inputs[i].Satoshis}
+	}
+
+	unspent, err := checker.IsUnspent(input.SourceTXID.String(), input.SourceTxOutIndex, expected)
+	if err != nil || unspent {
+		return unspent, err
+	}
+	if p.changeTx == nil || !input.SourceTXID.IsEqual(p.changeTx.TxID()) {
+		return false, nil
+	}
+	for _, changeInput := range p.changeTx.Inputs {
+		unspent, err := checker.IsUnspent(changeInput.SourceTXID.String(), changeInput.SourceTxOutIndex, changeInput.SourceTxOutput())
+		if err != nil || !unspent {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // Validate checks if the pledge is valid
 func (p *Pledge) Validate() error {
 	if p.tx == nil {
@@ -237,13 +845,170 @@ func (p *Pledge) Validate() error {
 		return errors.New("no outputs")
 	}
 
-	// Check that all inputs have SIGHASH_ANYONECANPAY signatures
+	if err := p.validateTimelock(); err != nil {
+		return err
+	}
+
+	// The claimed amount must be backed by the inputs. Input values are
+	// covered by the BIP143 signatures, so misreporting them only produces
+	// a pledge that can never be combined.
+	if len(p.pb.Inputs) != len(p.tx.Inputs) {
+		return fmt.Errorf("pledge data has %d inputs but transaction has %d", len(p.pb.Inputs), len(p.tx.Inputs))
+	}
+	if total := p.InputTotal(); total < p.amount {
+		return fmt.Errorf("inputs total %d satoshis, less than pledged amount %d", total, p.amount)
+	}
+	if err := p.validateChange(); err != nil {
+		return err
+	}
+
+	return p.CheckSigHash()
+}
+
+// validateChange checks a change transaction funds the pledge's only input
+func (p *Pledge) validateChange() error {
+	if p.changeTx == nil {
+		return nil
+	}
+	if len(p.tx.Inputs) != 1 {
+		return fmt.Errorf("pledge with change has %d inputs, expected 1", len(p.tx.Inputs))
+	}
+
+	input := p.tx.Inputs[0]
+	if !input.SourceTXID.IsEqual(p.changeTx.TxID()) {
+		return errors.New("pledge input does not spend its change transaction")
+	}
+	if int(input.SourceTxOutIndex) >= len(p.changeTx.Outputs) {
+		return fmt.Errorf("change transaction has no output %d", input.SourceTxOutIndex)
+	}
+	if funded := p.changeTx.Outputs[input.SourceTxOutIndex].Satoshis; funded != p.pb.Inputs[0].Satoshis {
+		return fmt.Errorf("change transaction funds %d satoshis but pledge input claims %d", funded, p.pb.Inputs[0].Satoshis)
+	}
+	return nil
+}
+
+// VerifySignatures runs each input's unlocking script against the output
+// it spends, proving the signatures are valid for the pledge transaction.
+// Pledges created before source locking scripts were recorded can't be
+// verified and return an error.
+func (p *Pledge) VerifySignatures() error {
+	if p.tx == nil {
+		return errors.New("no transaction")
+	}
+
+	for i, input := range p.tx.Inputs {
+		if input.UnlockingScript == nil || len(*input.UnlockingScript) == 0 {
+			return fmt.Errorf("input %d is not signed", i)
+		}
+		source := input.SourceTxOutput()
+		if source == nil || source.LockingScript == nil || len(*source.LockingScript) == 0 {
+			return fmt.Errorf("input %d has no source locking script to verify against", i)
+		}
+
+		err := interpreter.NewEngine().Execute(
+			interpreter.WithTx(p.tx, i, source),
+			interpreter.WithForkID(),
+			interpreter.WithAfterGenesis(),
+		)
+		if err != nil {
+			return fmt.Errorf("input %d has an invalid signature: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckSigHash checks that every input is signed with
+// SIGHASH_ALL|ANYONECANPAY|FORKID, so the pledge stays valid when combined
+// with other pledges while still committing to the project outputs
+func (p *Pledge) CheckSigHash() error {
+	if p.tx == nil {
+		return errors.New("no transaction")
+	}
+
 	for i, input := range p.tx.Inputs {
 		if input.UnlockingScript == nil || len(*input.UnlockingScript) == 0 {
 			return fmt.Errorf("input %d is not signed", i)
 		}
-		// TODO: Actually verify the signature has ANYONECANPAY flag
+
+		flag, err := signatureSigHashFlag(input.UnlockingScript)
+		if err != nil {
+			return fmt.Errorf("input %d: %w", i, err)
+		}
+		if flag&sighash.AnyOneCanPay == 0 {
+			return fmt.Errorf("%w: input %d is not signed with SIGHASH_ANYONECANPAY", ErrIncompatibleSigHash, i)
+		}
+		// NONE or SINGLE would leave the project outputs open to change
+		if flag&sigHashBaseMask != sighash.All {
+			return fmt.Errorf("%w: input %d is not signed with SIGHASH_ALL (flag 0x%02x)", ErrIncompatibleSigHash, i, uint32(flag))
+		}
+		if flag&sighash.ForkID == 0 {
+			return fmt.Errorf("%w: input %d is not signed with SIGHASH_FORKID", ErrIncompatibleSigHash, i)
+		}
 	}
 
 	return nil
+}
+
+// CheckOutputs checks that the pledge commits to exactly the project's outputs
+func (p *Pledge) CheckOutputs(project *Project) error {
+	expected, err := project.Outputs()
+	if err != nil {
+		return fmt.Errorf("failed to get project outputs: %w", err)
+	}
+
+	if len(p.tx.Outputs) != len(expected) {
+		return fmt.Errorf("pledge has %d outputs but project has %d", len(p.tx.Outputs), len(expected))
+	}
+	for i, out := range p.tx.Outputs {
+		if out.Satoshis != expected[i].Satoshis {
+			return fmt.Errorf("output %d pays %d satoshis but project expects %d", i, out.Satoshis, expected[i].Satoshis)
+		}
+		if !bytes.Equal(out.LockingScript.Bytes(), expected[i].LockingScript.Bytes()) {
+			return fmt.Errorf("output %d pays a different script than the project", i)
+		}
+	}
+
+	return nil
+}
+
+// validateTimelock checks the lock time is a block height that the
+// transaction actually enforces
+func (p *Pledge) validateTimelock() error {
+	if p.tx.LockTime != p.pb.LockTime {
+		return fmt.Errorf("transaction lock time %d does not match pledge lock time %d", p.tx.LockTime, p.pb.LockTime)
+	}
+	if p.tx.LockTime == 0 {
+		return nil
+	}
+
+	if p.tx.LockTime >= lockTimeThreshold {
+		return fmt.Errorf("lock time %d is a timestamp, expected a block height", p.tx.LockTime)
+	}
+	// nLockTime is ignored when every input is final
+	for i, input := range p.tx.Inputs {
+		if input.SequenceNumber == finalSequence {
+			return fmt.Errorf("input %d has a final sequence number, so the lock time is not enforced", i)
+		}
+	}
+	return nil
+}
+
+// sigHashBaseMask selects the ALL, NONE or SINGLE part of a sighash flag
+const sigHashBaseMask sighash.Flag = 0x1f
+
+// signatureSigHashFlag extracts the sighash flag from the signature pushed
+// first in a P2PKH unlocking script
+func signatureSigHashFlag(unlockingScript *script.Script) (sighash.Flag, error) {
+	chunks, err := unlockingScript.Chunks()
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse unlocking script: %w", err)
+	}
+	if len(chunks) == 0 || len(chunks[0].Data) == 0 {
+		return 0, errors.New("unlocking script has no signature")
+	}
+
+	// The sighash flag is the last byte of the pushed signature
+	sig := chunks[0].Data
+	return sighash.Flag(sig[len(sig)-1]), nil
 }
\ No newline at end of file
@@ -0,0 +1,697 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/yourusername/lighthouse/core/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPledgeSigHashValidation(t *testing.T) {
+	project, err := NewProject(
+		"Signature Test",
+		"Testing pledge signatures",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	t.Run("ANYONECANPAY signature passes", func(t *testing.T) {
+		pledge, err := NewPledge(project, 25000000, withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 25000000)))
+		require.NoError(t, err)
+
+		// Unsigned pledges are rejected
+		err = pledge.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not signed")
+
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey}))
+		assert.NoError(t, pledge.Validate())
+	})
+
+	t.Run("plain SIGHASH_ALL signature fails", func(t *testing.T) {
+		pledge, err := NewPledge(project, 25000000, withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 25000000)))
+		require.NoError(t, err)
+
+		allFlag := sighash.AllForkID
+		unlocker, err := p2pkh.Unlock(privKey, &allFlag)
+		require.NoError(t, err)
+		unlockingScript, err := unlocker.Sign(pledge.Transaction(), 0)
+		require.NoError(t, err)
+		pledge.Transaction().Inputs[0].UnlockingScript = unlockingScript
+
+		err = pledge.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SIGHASH_ANYONECANPAY")
+	})
+}
+
+func TestPledgeSerialization(t *testing.T) {
+	project, err := NewProject(
+		"Serialization Test",
+		"Test Description",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	pledge, err := NewPledge(project, 25000000, withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 25000000)))
+	require.NoError(t, err)
+
+	data, err := pledge.Serialize()
+	require.NoError(t, err)
+
+	loaded, err := LoadPledge(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, pledge.ID(), loaded.ID())
+	assert.Equal(t, pledge.ProjectID(), loaded.ProjectID())
+	assert.Equal(t, pledge.Amount(), loaded.Amount())
+	assert.Equal(t, uint64(25000000), loaded.Amount())
+
+	t.Run("signed pledge validates after load", func(t *testing.T) {
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey}))
+
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+
+		loaded, err := LoadPledge(data)
+		require.NoError(t, err)
+
+		require.Len(t, loaded.Transaction().Outputs, len(pledge.Transaction().Outputs))
+		for i, out := range pledge.Transaction().Outputs {
+			assert.Equal(t, out.Satoshis, loaded.Transaction().Outputs[i].Satoshis)
+			assert.Equal(t, out.LockingScript.Bytes(), loaded.Transaction().Outputs[i].LockingScript.Bytes())
+		}
+		assert.NoError(t, loaded.Validate())
+	})
+}
+
+func TestLoadPledgeUnknownFields(t *testing.T) {
+	project, err := NewProject("Compat Test", "Testing pledges from newer versions", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	pledge := createSignedTestPledge(t, project, 25000000)
+
+	// withUnknown adds a field to msg that only a newer version would know
+	withUnknown := func(msg proto.Message, num protowire.Number, value string) {
+		m := msg.ProtoReflect()
+		b := protowire.AppendTag(m.GetUnknown(), num, protowire.BytesType)
+		m.SetUnknown(protowire.AppendString(b, value))
+	}
+
+	t.Run("current pledge has no warnings", func(t *testing.T) {
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		result, err := LoadPledgeWithWarnings(data)
+		require.NoError(t, err)
+		assert.Empty(t, result.Warnings)
+		assert.Equal(t, pledge.ID(), result.Pledge.ID())
+	})
+
+	t.Run("newer fields are kept and reported", func(t *testing.T) {
+		newer := proto.Clone(pledge.pb).(*pb.Pledge)
+		withUnknown(newer, 100, "future pledge data")
+		withUnknown(newer.Inputs[0], 50, "future input data")
+		data, err := proto.Marshal(newer)
+		require.NoError(t, err)
+
+		result, err := LoadPledgeWithWarnings(data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"pledge has unknown field 100",
+			"pledge.inputs[0] has unknown field 50",
+		}, result.Warnings)
+
+		loaded := result.Pledge
+		assert.Equal(t, pledge.Amount(), loaded.Amount())
+		assert.Equal(t, pledge.ProjectID(), loaded.ProjectID())
+		require.Len(t, loaded.Transaction().Inputs, 1)
+		assert.Equal(t, pledge.Transaction().Inputs[0].SourceTXID, loaded.Transaction().Inputs[0].SourceTXID)
+		assert.Equal(t, pledge.Transaction().TxID(), loaded.Transaction().TxID())
+		assert.NoError(t, loaded.Validate())
+		assert.NoError(t, loaded.VerifySignatures())
+
+		// Unknown fields survive a round trip, so the ID is stable
+		again, err := loaded.Serialize()
+		require.NoError(t, err)
+		assert.Equal(t, hashID(data), loaded.ID())
+		assert.Equal(t, hashID(again), loaded.ID())
+
+		plain, err := LoadPledge(data)
+		require.NoError(t, err)
+		assert.Equal(t, loaded.ID(), plain.ID())
+	})
+
+	t.Run("newer pledge missing required data", func(t *testing.T) {
+		for name, strip := range map[string]func(*pb.Pledge){
+			"amount":  func(p *pb.Pledge) { p.Amount = 0 },
+			"inputs":  func(p *pb.Pledge) { p.Inputs = nil },
+			"outputs": func(p *pb.Pledge) { p.Outputs = nil },
+			"txid":    func(p *pb.Pledge) { p.Inputs[0].TxHash = nil },
+		} {
+			newer := proto.Clone(pledge.pb).(*pb.Pledge)
+			withUnknown(newer, 100, "moved elsewhere")
+			strip(newer)
+			data, err := proto.Marshal(newer)
+			require.NoError(t, err)
+
+			_, err = LoadPledgeWithWarnings(data)
+			assert.ErrorIs(t, err, ErrIncompletePledge, name)
+			_, err = LoadPledge(data)
+			assert.ErrorIs(t, err, ErrIncompletePledge, name)
+		}
+	})
+}
+
+// createSignedTestPledge creates a pledge funded by a fresh key and signs it
+func createSignedTestPledge(t *testing.T, project *Project, amount uint64) *Pledge {
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	pledge, err := NewPledge(project, amount, withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, amount)))
+	require.NoError(t, err)
+	require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey}))
+
+	return pledge
+}
+
+// withFeeAllowance adds the fee allowance NewPledge requires for a pledge
+// spending utxos to the last of them
+func withFeeAllowance(t testing.TB, project *Project, utxos []*transaction.UTXO) []*transaction.UTXO {
+	allowance, err := FeeAllowance(project, len(utxos), DefaultFeeRate)
+	require.NoError(t, err)
+	utxos[len(utxos)-1].Satoshis += allowance
+	return utxos
+}
+
+// createTestKeyUTXOs creates a UTXO with a random txid locked to the key's address
+func createTestKeyUTXOs(t testing.TB, privKey *ec.PrivateKey, satoshis uint64) []*transaction.UTXO {
+	address, err := script.NewAddressFromPublicKey(privKey.PubKey(), true)
+	require.NoError(t, err)
+	lockingScript, err := p2pkh.Lock(address)
+	require.NoError(t, err)
+
+	txid := make([]byte, 32)
+	_, err = rand.Read(txid)
+	require.NoError(t, err)
+
+	utxo, err := transaction.NewUTXO(hex.EncodeToString(txid), 0, lockingScript.String(), satoshis)
+	require.NoError(t, err)
+
+	return []*transaction.UTXO{utxo}
+}
+
+func TestPledgeCommitsToProjectOutputs(t *testing.T) {
+	// Odd amounts that proportional scaling could not split without dust
+	project, err := NewProjectWithOutputs("Dust Test", "Testing pledge outputs", []ProjectOutput{
+		{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 66666667},
+		{Address: "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", Amount: 33333334},
+	})
+	require.NoError(t, err)
+
+	projectOutputs, err := project.Outputs()
+	require.NoError(t, err)
+
+	// Three pledges summing exactly to the goal of 100000001
+	contract := NewContract(project)
+	contract.SetFeeRate(0)
+	for _, amount := range []uint64{33333333, 33333334, 33333334} {
+		pledge := createSignedTestPledge(t, project, amount)
+
+		// Each pledge signs over the real project outputs
+		require.Len(t, pledge.Transaction().Outputs, len(projectOutputs))
+		for i, out := range pledge.Transaction().Outputs {
+			assert.Equal(t, projectOutputs[i].Satoshis, out.Satoshis)
+			assert.Equal(t, projectOutputs[i].LockingScript.Bytes(), out.LockingScript.Bytes())
+		}
+
+		require.NoError(t, contract.AddPledge(pledge))
+	}
+	require.Equal(t, project.GoalAmount(), contract.TotalPledged())
+
+	tx, err := contract.Combine()
+	require.NoError(t, err)
+
+	require.Len(t, tx.Outputs, len(projectOutputs))
+	for i, out := range tx.Outputs {
+		assert.Equal(t, projectOutputs[i].Satoshis, out.Satoshis)
+		assert.Equal(t, projectOutputs[i].LockingScript.Bytes(), out.LockingScript.Bytes())
+	}
+}
+
+func TestPledgeTimelock(t *testing.T) {
+	project, err := NewProject(
+		"Timelock Test",
+		"Testing pledge timelocks",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	newTimelockedPledge := func(height uint32) *Pledge {
+		pledge, err := NewPledge(project, 50000000, withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 50000000)))
+		require.NoError(t, err)
+		pledge.SetTimelock(height)
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey}))
+		return pledge
+	}
+
+	t.Run("timelock survives serialization", func(t *testing.T) {
+		pledge := newTimelockedPledge(850000)
+		require.NoError(t, pledge.Validate())
+		assert.Equal(t, uint32(850000), pledge.Timelock())
+		assert.Less(t, pledge.Transaction().Inputs[0].SequenceNumber, uint32(finalSequence))
+
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadPledge(data)
+		require.NoError(t, err)
+
+		assert.Equal(t, pledge.ID(), loaded.ID())
+		assert.Equal(t, uint32(850000), loaded.Transaction().LockTime)
+		assert.NoError(t, loaded.Validate())
+	})
+
+	t.Run("setting a timelock clears signatures", func(t *testing.T) {
+		pledge := newTimelockedPledge(0)
+		require.NoError(t, pledge.Validate())
+
+		pledge.SetTimelock(850000)
+		err := pledge.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not signed")
+	})
+
+	t.Run("final sequence does not enforce the lock time", func(t *testing.T) {
+		pledge := newTimelockedPledge(850000)
+		pledge.Transaction().Inputs[0].SequenceNumber = finalSequence
+
+		err := pledge.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "final sequence")
+	})
+
+	t.Run("timestamps are rejected", func(t *testing.T) {
+		pledge := newTimelockedPledge(lockTimeThreshold + 1)
+		err := pledge.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "block height")
+	})
+
+	t.Run("contract rejects mismatched timelocks", func(t *testing.T) {
+		contract := NewContract(project)
+		contract.SetFeeRate(0)
+		require.NoError(t, contract.AddPledge(newTimelockedPledge(850000)))
+		assert.Error(t, contract.AddPledge(newTimelockedPledge(850001)))
+		require.NoError(t, contract.AddPledge(newTimelockedPledge(850000)))
+
+		tx, err := contract.Combine()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(850000), tx.LockTime)
+	})
+}
+
+func TestPledgeInputValues(t *testing.T) {
+	project, err := NewProject(
+		"Input Value Test",
+		"Testing pledge input values",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	t.Run("input values survive serialization", func(t *testing.T) {
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		utxos := withFeeAllowance(t, project, append(createTestKeyUTXOs(t, privKey, 15000000), createTestKeyUTXOs(t, privKey, 10000000)...))
+		total := utxos[0].Satoshis + utxos[1].Satoshis
+
+		pledge, err := NewPledge(project, 25000000, utxos)
+		require.NoError(t, err)
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey, privKey}))
+		assert.Equal(t, total, pledge.InputTotal())
+
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadPledge(data)
+		require.NoError(t, err)
+		assert.Equal(t, total, loaded.InputTotal())
+		assert.NoError(t, loaded.Validate())
+	})
+
+	t.Run("amount larger than inputs is rejected", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 25000000)
+
+		// Simulate a tampered pledge file claiming more than it spends
+		pledge.pb.Inputs[0].Satoshis = 1000
+
+		err := pledge.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "less than pledged amount")
+
+		contract := NewContract(project)
+		assert.Error(t, contract.AddPledge(pledge))
+	})
+}
+
+func TestPledgeCheckOutputs(t *testing.T) {
+	project, err := NewProject("Output Check", "Testing output checks", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	other, err := NewProject("Other Project", "Testing output checks", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", NetworkMainnet)
+	require.NoError(t, err)
+
+	pledge := createSignedTestPledge(t, project, 25000000)
+	assert.NoError(t, pledge.CheckOutputs(project))
+
+	err = pledge.CheckOutputs(other)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "different script")
+
+	pledge.Transaction().Outputs[0].Satoshis--
+	err = pledge.CheckOutputs(project)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "project expects")
+}
+
+func TestPledgeEncryptedContact(t *testing.T) {
+	project, err := NewProject("Contact Test", "Testing contact encryption", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	ownerKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	t.Run("owner can decrypt", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 25000000)
+		require.NoError(t, pledge.SetContactInfo("Alice", "alice@example.com"))
+		require.NoError(t, pledge.SetEncryptedContact("Alice", "alice@example.com", ownerKey.PubKey()))
+		assert.True(t, pledge.HasEncryptedContact())
+
+		// The plaintext copy is gone and the ciphertext survives a roundtrip
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "alice@example.com")
+
+		loaded, err := LoadPledge(data)
+		require.NoError(t, err)
+		name, email, err := loaded.DecryptContact(ownerKey)
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", name)
+		assert.Equal(t, "alice@example.com", email)
+	})
+
+	t.Run("other keys cannot decrypt", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 25000000)
+		require.NoError(t, pledge.SetEncryptedContact("Bob", "bob@example.com", ownerKey.PubKey()))
+
+		otherKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		_, _, err = pledge.DecryptContact(otherKey)
+		assert.Error(t, err)
+	})
+
+	t.Run("no encrypted contact", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 25000000)
+		_, _, err := pledge.DecryptContact(ownerKey)
+		assert.ErrorIs(t, err, ErrNoEncryptedContact)
+
+		assert.ErrorIs(t, pledge.SetEncryptedContact("Carol", "", nil), ErrNoAuthKey)
+	})
+}
+
+func TestPledgeTextLimits(t *testing.T) {
+	project, err := NewProject("Memo Test", "Testing memo limits", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	pledge := createSignedTestPledge(t, project, 25000000)
+
+	t.Run("memo length is counted in runes", func(t *testing.T) {
+		// "é" is two bytes and "😀" four, so both memos are over 500 bytes
+		require.NoError(t, pledge.SetMemo(strings.Repeat("é", MaxMemoLength)))
+		require.NoError(t, pledge.SetMemo(strings.Repeat("😀", MaxMemoLength)))
+
+		err := pledge.SetMemo(strings.Repeat("é", MaxMemoLength+1))
+		assert.ErrorIs(t, err, ErrTextTooLong)
+	})
+
+	t.Run("memo control characters are stripped", func(t *testing.T) {
+		require.NoError(t, pledge.SetMemo("Good\x1b[31m luck\x00!\nSee you\tsoon\r"))
+		assert.Equal(t, "Good[31m luck!\nSee you\tsoon", pledge.pb.Memo)
+
+		// Stripped characters don't count towards the limit
+		require.NoError(t, pledge.SetMemo(strings.Repeat("a", MaxMemoLength)+"\x07\x07"))
+	})
+
+	t.Run("contact name", func(t *testing.T) {
+		require.NoError(t, pledge.SetContactInfo(" Zoë\x1b \n", ""))
+		assert.Equal(t, "Zoë", pledge.pb.Contact.Name)
+
+		require.NoError(t, pledge.SetContactInfo(strings.Repeat("ö", MaxContactNameLength), ""))
+		err := pledge.SetContactInfo(strings.Repeat("ö", MaxContactNameLength+1), "")
+		assert.ErrorIs(t, err, ErrTextTooLong)
+	})
+
+	t.Run("contact email", func(t *testing.T) {
+		require.NoError(t, pledge.SetContactInfo("", "alice@example.com"))
+		assert.Equal(t, "alice@example.com", pledge.pb.Contact.Email)
+
+		for _, email := range []string{"alice", "alice@", "@example.com", "alice@localhost", "Alice <alice@example.com>", "a b@example.com"} {
+			err := pledge.SetContactInfo("", email)
+			assert.ErrorIs(t, err, ErrInvalidEmail, email)
+		}
+
+		long := strings.Repeat("a", MaxContactEmailLength) + "@example.com"
+		assert.ErrorIs(t, pledge.SetContactInfo("", long), ErrTextTooLong)
+
+		ownerKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		assert.ErrorIs(t, pledge.SetEncryptedContact("", "alice", ownerKey.PubKey()), ErrInvalidEmail)
+	})
+}
+
+func TestPledgeWithChange(t *testing.T) {
+	project, err := NewProject("Change Test", "Testing pledge change", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	address, err := script.NewAddressFromPublicKey(privKey.PubKey(), true)
+	require.NoError(t, err)
+	changeScript, err := p2pkh.Lock(address)
+	require.NoError(t, err)
+
+	amount := uint64(10000000)
+
+	t.Run("large input", func(t *testing.T) {
+		utxos := createTestKeyUTXOs(t, privKey, 5000000000)
+		pledge, err := NewPledgeWithChange(project, amount, utxos, address.AddressString, DefaultFeeRate)
+		require.NoError(t, err)
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey}))
+
+		// The pledge spends exactly the amount plus its claim fee share
+		allowance, err := FeeAllowance(project, 1, DefaultFeeRate)
+		require.NoError(t, err)
+		assert.Equal(t, amount, pledge.Amount())
+		assert.Equal(t, amount+allowance, pledge.InputTotal())
+		assert.Equal(t, allowance, pledge.FeeContribution())
+		require.NoError(t, pledge.CheckOutputs(project))
+		require.NoError(t, pledge.Validate())
+
+		changeTx := pledge.ChangeTransaction()
+		require.NotNil(t, changeTx)
+		require.Len(t, changeTx.Outputs, 2)
+		assert.Equal(t, pledge.InputTotal(), changeTx.Outputs[0].Satoshis)
+		assert.Equal(t, changeScript.Bytes(), changeTx.Outputs[1].LockingScript.Bytes())
+		assert.Equal(t, changeTx.TxID().String(), pledge.Transaction().Inputs[0].SourceTXID.String())
+
+		// Everything not pledged comes back, less a small fee
+		fee := 5000000000 - changeTx.Outputs[0].Satoshis - changeTx.Outputs[1].Satoshis
+		assert.Greater(t, fee, uint64(0))
+		assert.LessOrEqual(t, fee, EstimateFee(changeTx, DefaultFeeRate)+1)
+
+		// The change transaction survives a round trip
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadPledge(data)
+		require.NoError(t, err)
+		require.NoError(t, loaded.Validate())
+		require.NotNil(t, loaded.ChangeTransaction())
+		assert.Equal(t, changeTx.TxID().String(), loaded.ChangeTransaction().TxID().String())
+
+		contract := NewContract(project)
+		assert.NoError(t, contract.AddPledge(loaded))
+	})
+
+	t.Run("dust change", func(t *testing.T) {
+		// 100 satoshis left after the allowance and the change transaction fee
+		allowance, err := FeeAllowance(project, 1, DefaultFeeRate)
+		require.NoError(t, err)
+		utxos := createTestKeyUTXOs(t, privKey, amount+allowance+changeTxFee(1, DefaultFeeRate)+100)
+		_, err = NewPledgeWithChange(project, amount, utxos, address.AddressString, DefaultFeeRate)
+		assert.ErrorIs(t, err, ErrDustChange)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		utxos := createTestKeyUTXOs(t, privKey, amount)
+		_, err := NewPledgeWithChange(project, amount, utxos, address.AddressString, DefaultFeeRate)
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+	})
+
+	t.Run("invalid change address", func(t *testing.T) {
+		utxos := createTestKeyUTXOs(t, privKey, 5000000000)
+		_, err := NewPledgeWithChange(project, amount, utxos, "not-an-address", DefaultFeeRate)
+		assert.ErrorContains(t, err, "invalid change address")
+	})
+
+	t.Run("third-party change address", func(t *testing.T) {
+		// The pledger's change goes to an address they don't hold the key for
+		pledger, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		pledgerAddress, err := script.NewAddressFromPublicKey(pledger.PubKey(), true)
+		require.NoError(t, err)
+		pledgerScript, err := p2pkh.Lock(pledgerAddress)
+		require.NoError(t, err)
+
+		utxos := createTestKeyUTXOs(t, pledger, 5000000000)
+		pledge, err := NewPledgeWithChange(project, amount, utxos, address.AddressString, DefaultFeeRate)
+		require.NoError(t, err)
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{pledger}))
+		require.NoError(t, pledge.VerifySignatures())
+
+		changeTx := pledge.ChangeTransaction()
+		assert.Equal(t, pledgerScript.Bytes(), changeTx.Outputs[0].LockingScript.Bytes())
+		assert.Equal(t, changeScript.Bytes(), changeTx.Outputs[1].LockingScript.Bytes())
+	})
+
+	t.Run("key for pledged output required", func(t *testing.T) {
+		otherKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		utxos := createTestKeyUTXOs(t, otherKey, 5000000000)
+		pledge, err := NewPledgeWithChange(project, amount, utxos, address.AddressString, DefaultFeeRate)
+		require.NoError(t, err)
+		assert.ErrorContains(t, pledge.Sign([]*ec.PrivateKey{privKey}), "pledged output")
+	})
+}
+
+func TestPledgeVerifySignatures(t *testing.T) {
+	project, err := NewProject("Verify Test", "Testing signature verification", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	reload := func(t *testing.T, pledge *Pledge) *Pledge {
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadPledge(data)
+		require.NoError(t, err)
+		return loaded
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 50000)
+		assert.NoError(t, pledge.VerifySignatures())
+		assert.NoError(t, reload(t, pledge).VerifySignatures())
+	})
+
+	t.Run("tampered transaction", func(t *testing.T) {
+		loaded := reload(t, createSignedTestPledge(t, project, 50000))
+		loaded.Transaction().Outputs[0].Satoshis++
+		assert.ErrorContains(t, loaded.VerifySignatures(), "input 0 has an invalid signature")
+	})
+
+	t.Run("signed by the wrong key", func(t *testing.T) {
+		owner, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		other, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+
+		pledge, err := NewPledge(project, 50000, withFeeAllowance(t, project, createTestKeyUTXOs(t, owner, 50000)))
+		require.NoError(t, err)
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{other}))
+		assert.ErrorContains(t, reload(t, pledge).VerifySignatures(), "invalid signature")
+	})
+
+	t.Run("no source locking script", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 50000)
+		pledge.pb.Inputs[0].SourceLockingScript = nil
+		assert.ErrorContains(t, reload(t, pledge).VerifySignatures(), "no source locking script")
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		pledge, err := NewPledge(project, 50000, withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 50000)))
+		require.NoError(t, err)
+		assert.ErrorContains(t, pledge.VerifySignatures(), "not signed")
+	})
+}
+
+func TestPledgeCheckInputs(t *testing.T) {
+	project, err := NewProject("Status Test", "Testing input status", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", NetworkMainnet)
+	require.NoError(t, err)
+
+	pledge := createSignedTestPledge(t, project, 50000000)
+	input := pledge.Transaction().Inputs[0]
+	outpoint := fmt.Sprintf("%s:%d", input.SourceTXID.String(), input.SourceTxOutIndex)
+
+	t.Run("unspent", func(t *testing.T) {
+		statuses, err := pledge.CheckInputs(&mockUTXOChecker{})
+		require.NoError(t, err)
+		assert.Equal(t, []InputStatus{{Outpoint: outpoint, Unspent: true}}, statuses)
+	})
+
+	t.Run("spent", func(t *testing.T) {
+		statuses, err := pledge.CheckInputs(&mockUTXOChecker{spent: map[string]bool{outpoint: true}})
+		require.NoError(t, err)
+		assert.Equal(t, []InputStatus{{Outpoint: outpoint, Unspent: false}}, statuses)
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		_, err := pledge.CheckInputs(&mockUTXOChecker{err: errors.New("offline")})
+		assert.ErrorContains(t, err, outpoint)
+	})
+
+	t.Run("unbroadcast change transaction", func(t *testing.T) {
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		address, err := script.NewAddressFromPublicKey(privKey.PubKey(), true)
+		require.NoError(t, err)
+		withChange, err := NewPledgeWithChange(project, 50000000, createTestKeyUTXOs(t, privKey, 100000000), address.AddressString, DefaultFeeRate)
+		require.NoError(t, err)
+		require.NoError(t, withChange.Sign([]*ec.PrivateKey{privKey}))
+
+		// The change output doesn't exist until the claim broadcasts it, so
+		// the output the change transaction spends is checked instead
+		changeOutpoint := outpointKey(withChange.Transaction().Inputs[0])
+		funding := outpointKey(withChange.ChangeTransaction().Inputs[0])
+		statuses, err := withChange.CheckInputs(&mockUTXOChecker{spent: map[string]bool{changeOutpoint: true}})
+		require.NoError(t, err)
+		assert.Equal(t, []InputStatus{{Outpoint: changeOutpoint, Unspent: true}}, statuses)
+
+		statuses, err = withChange.CheckInputs(&mockUTXOChecker{spent: map[string]bool{changeOutpoint: true, funding: true}})
+		require.NoError(t, err)
+		assert.Equal(t, []InputStatus{{Outpoint: changeOutpoint, Unspent: false}}, statuses)
+	})
+}
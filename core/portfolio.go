@@ -0,0 +1,39 @@
+package core
+
+// PortfolioStats aggregates the funding status of several projects
+type PortfolioStats struct {
+	ProjectCount    int     `json:"projectCount"`
+	PledgeCount     int     `json:"pledgeCount"`
+	TotalGoal       uint64  `json:"totalGoal"`
+	TotalPledged    uint64  `json:"totalPledged"`
+	Claimable       int     `json:"claimable"`
+	Expired         int     `json:"expired"`
+	AverageProgress float64 `json:"averageProgress"`
+}
+
+// AggregateStatus summarizes a set of contracts. The average progress uses
+// each project's progress capped at 100%, so one over-funded project can't
+// hide others that are short.
+func AggregateStatus(contracts []*Contract) PortfolioStats {
+	var stats PortfolioStats
+	var progress float64
+	for _, contract := range contracts {
+		status := contract.GetStatus()
+		stats.ProjectCount++
+		stats.PledgeCount += status.PledgeCount
+		stats.TotalGoal += status.GoalAmount
+		stats.TotalPledged += status.TotalPledged
+		if status.CanClaim {
+			stats.Claimable++
+		}
+		if status.IsExpired {
+			stats.Expired++
+		}
+		progress += contract.ProgressClamped()
+	}
+
+	if stats.ProjectCount > 0 {
+		stats.AverageProgress = progress / float64(stats.ProjectCount)
+	}
+	return stats
+}
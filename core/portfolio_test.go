@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateStatus(t *testing.T) {
+	t.Run("no contracts", func(t *testing.T) {
+		stats := AggregateStatus(nil)
+		assert.Equal(t, PortfolioStats{}, stats)
+	})
+
+	t.Run("mixed projects", func(t *testing.T) {
+		newContract := func(title string, goal uint64, pledges ...uint64) *Contract {
+			project, err := NewProject(title, "Testing portfolio stats", goal, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+			require.NoError(t, err)
+			contract := NewContract(project)
+			for _, amount := range pledges {
+				require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, amount)))
+			}
+			return contract
+		}
+
+		funded := newContract("Funded", 100000000, 60000000, 60000000)
+		half := newContract("Half", 200000000, 100000000)
+		expired := newContract("Expired", 100000000)
+		expired.project.SetExpiry(time.Now().Add(-time.Hour))
+
+		stats := AggregateStatus([]*Contract{funded, half, expired})
+		assert.Equal(t, 3, stats.ProjectCount)
+		assert.Equal(t, 3, stats.PledgeCount)
+		assert.Equal(t, uint64(400000000), stats.TotalGoal)
+		assert.Equal(t, uint64(220000000), stats.TotalPledged)
+		assert.Equal(t, 1, stats.Claimable)
+		assert.Equal(t, 1, stats.Expired)
+		// 100% (capped from 120%), 50% and 0%
+		assert.InDelta(t, 50.0, stats.AverageProgress, 0.001)
+	})
+}
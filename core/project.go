@@ -1,12 +1,24 @@
 package core
 
 import (
+	"bytes"
+	"compress/gzip"
 	"crypto/sha256"
-	"encoding/hex"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
@@ -15,6 +27,72 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// ErrNoAuthKey is returned when a project has no owner auth key to sign or verify with
+var ErrNoAuthKey = errors.New("project has no auth key")
+
+// ErrInvalidAuthSignature is returned when a signature does not match the project's auth key
+var ErrInvalidAuthSignature = errors.New("invalid auth signature")
+
+// ErrNotSignedProject is returned by LoadSignedProject for data that isn't
+// a signed project bundle, such as a plain project file
+var ErrNotSignedProject = errors.New("not a signed project")
+
+// MaxCoverImageSize is the largest cover image SetCoverImage accepts, in
+// bytes. The image is stored in the project file but not hashed into its ID.
+var MaxCoverImageSize = 1024 * 1024
+
+// ErrCoverImageTooLarge is returned when a cover image exceeds MaxCoverImageSize
+var ErrCoverImageTooLarge = errors.New("cover image too large")
+
+// ErrNoCoverImage is returned when a project has no cover image
+var ErrNoCoverImage = errors.New("project has no cover image")
+
+// ErrCoverImageConflict is returned when setting an inline cover image on a
+// project with a cover image URL, or the other way round
+var ErrCoverImageConflict = errors.New("project cannot have both an inline cover image and a cover image URL")
+
+// ErrInvalidCoverImageURL is returned for a cover image URL that is not an
+// absolute http(s) or ipfs URL
+var ErrInvalidCoverImageURL = errors.New("invalid cover image URL")
+
+// MaxSupportedVersion is the newest project format version this build
+// understands. New projects are written with it.
+const MaxSupportedVersion = 1
+
+// ErrUnsupportedVersion is returned when loading a project written in a
+// newer format than MaxSupportedVersion, which may use fields this build
+// would silently drop
+var ErrUnsupportedVersion = errors.New("unsupported project version")
+
+// ErrInvalidProject is returned when loading a project that is missing
+// required fields, such as a hand-crafted or truncated file
+var ErrInvalidProject = errors.New("invalid project")
+
+// ErrDustOutput is returned for a project output too small for nodes to relay
+var ErrDustOutput = errors.New("output below dust threshold")
+
+// ErrInvalidLink is returned for a project link that frontends shouldn't render
+var ErrInvalidLink = errors.New("invalid link")
+
+// MaxLinks is the most links a project can carry
+const MaxLinks = 10
+
+// MaxDataOutputSize is the largest OP_RETURN payload AddDataOutput accepts,
+// in bytes. 80 bytes is relayed as standard by every node.
+var MaxDataOutputSize = 80
+
+// ErrProjectClosed is returned when pledging to a paused or cancelled project
+var ErrProjectClosed = errors.New("project is not accepting pledges")
+
+// ErrDataOutputTooLarge is returned for data over MaxDataOutputSize
+var ErrDataOutputTooLarge = errors.New("data output too large")
+
+// Supported networks
+const (
+	NetworkMainnet = "mainnet"
+	NetworkTestnet = "testnet"
+)
+
 // Project represents a crowdfunding project
 type Project struct {
 	pb       *pb.Project
@@ -22,38 +100,94 @@ type Project struct {
 	goalAmount uint64
 }
 
-// NewProject creates a new crowdfunding project
-func NewProject(title, description string, goalAmount uint64, address string) (*Project, error) {
-	if title == "" || description == "" {
-		return nil, errors.New("title and description are required")
-	}
+// ProjectOutput is a payout destination for a project
+type ProjectOutput struct {
+	Address string `json:"address,omitempty"`
+	Amount  uint64 `json:"amount"`
+}
+
+// NewProject creates a new crowdfunding project on the given network
+func NewProject(title, description string, goalAmount uint64, address, network string) (*Project, error) {
 	if goalAmount == 0 {
 		return nil, errors.New("goal amount must be greater than 0")
 	}
+	return newProject(title, description, []ProjectOutput{{Address: address, Amount: goalAmount}}, network)
+}
 
-	// Parse address
-	addr, err := script.NewAddressFromString(address)
+// NewProjectWithOutputs creates a project that pays out to several outputs.
+// The goal is the sum of the output amounts and the network is taken from
+// the first output's address.
+func NewProjectWithOutputs(title, description string, outputs []ProjectOutput) (*Project, error) {
+	if len(outputs) == 0 {
+		return nil, errors.New("at least one output is required")
+	}
+
+	network, err := DetectNetwork(outputs[0].Address)
 	if err != nil {
 		return nil, fmt.Errorf("invalid address: %w", err)
 	}
 
-	// Create P2PKH locking script from address
-	lockingScript, err := p2pkh.Lock(addr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create locking script: %w", err)
+	return newProject(title, description, outputs, network)
+}
+
+// newProject validates the outputs and builds the project protobuf
+func newProject(title, description string, outputs []ProjectOutput, network string) (*Project, error) {
+	if title == "" || description == "" {
+		return nil, errors.New("title and description are required")
+	}
+	if network != NetworkMainnet && network != NetworkTestnet {
+		return nil, fmt.Errorf("unsupported network: %q", network)
+	}
+
+	var pbOutputs []*pb.Output
+	goalAmount := uint64(0)
+	for i, output := range outputs {
+		if output.Amount == 0 {
+			return nil, fmt.Errorf("output %d amount must be greater than 0", i)
+		}
+		if output.Amount < DustThreshold {
+			return nil, fmt.Errorf("%w: output %d is %d satoshis, minimum is %d", ErrDustOutput, i, output.Amount, DustThreshold)
+		}
+		if goalAmount+output.Amount < goalAmount {
+			return nil, errors.New("goal amount overflows")
+		}
+
+		// Parse address
+		addr, err := script.NewAddressFromString(output.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %w", err)
+		}
+
+		// Make sure the address belongs to the project's network
+		addrNetwork, err := DetectNetwork(output.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %w", err)
+		}
+		if addrNetwork != network {
+			return nil, fmt.Errorf("address is for %s but project is for %s", addrNetwork, network)
+		}
+
+		// Create P2PKH locking script from address
+		lockingScript, err := p2pkh.Lock(addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create locking script: %w", err)
+		}
+
+		pbOutputs = append(pbOutputs, &pb.Output{
+			Amount: output.Amount,
+			Script: lockingScript.Bytes(),
+		})
+		goalAmount += output.Amount
 	}
 
 	// Create the project protobuf
 	proj := &pb.Project{
-		Version: 1,
+		Version: MaxSupportedVersion,
 		Details: &pb.ProjectDetails{
-			Network: "mainnet",
-			Outputs: []*pb.Output{{
-				Amount: goalAmount,
-				Script: lockingScript.Bytes(),
-			}},
-			Time: timestamppb.Now(),
-			Memo: description,
+			Network: network,
+			Outputs: pbOutputs,
+			Time:    timestamppb.Now(),
+			Memo:    description,
 		},
 		Extra: &pb.ProjectExtraDetails{
 			Title:           title,
@@ -70,39 +204,224 @@ func NewProject(title, description string, goalAmount uint64, address string) (*
 	return p, nil
 }
 
-// LoadProject loads a project from serialized data
+// DetectNetwork returns the network ("mainnet" or "testnet") an address belongs to
+func DetectNetwork(address string) (string, error) {
+	if _, err := script.NewAddressFromString(address); err != nil {
+		return "", err
+	}
+
+	version, err := addressVersion(address)
+	if err != nil {
+		return "", err
+	}
+	switch version {
+	case 0x00:
+		return NetworkMainnet, nil
+	case 0x6f:
+		return NetworkTestnet, nil
+	default:
+		return "", fmt.Errorf("unknown address version for %s", address)
+	}
+}
+
+// addressVersion decodes a base58check P2PKH address and returns its
+// version byte. go-sdk doesn't verify the checksum, so a mistyped address
+// would otherwise lock funds to a hash nobody controls.
+func addressVersion(address string) (byte, error) {
+	const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	n := new(big.Int)
+	for _, c := range address {
+		digit := strings.IndexRune(alphabet, c)
+		if digit < 0 {
+			return 0, fmt.Errorf("invalid character %q in address %s", c, address)
+		}
+		n.Mul(n, big.NewInt(58))
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	// Leading '1's encode leading zero bytes
+	zeros := len(address) - len(strings.TrimLeft(address, "1"))
+	decoded := append(make([]byte, zeros), n.Bytes()...)
+
+	// version (1) + pubkey hash (20) + checksum (4)
+	if len(decoded) != 25 {
+		return 0, fmt.Errorf("address %s decodes to %d bytes, expected 25", address, len(decoded))
+	}
+	first := sha256.Sum256(decoded[:21])
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], decoded[21:]) {
+		return 0, fmt.Errorf("address %s has a bad checksum", address)
+	}
+	return decoded[0], nil
+}
+
+// maxDecompressedProjectSize bounds how large a gzipped project may expand,
+// so a small file can't exhaust memory when loaded
+const maxDecompressedProjectSize = 16 * 1024 * 1024
+
+// gzipMagic starts every gzip stream. No valid protobuf starts with 0x1f,
+// which would be field 3 with the invalid wire type 7.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// LoadProject loads a project from serialized data, either raw protobuf or
+// gzip-compressed as written by SerializeCompressed
 func LoadProject(data []byte) (*Project, error) {
+	if bytes.HasPrefix(data, gzipMagic) {
+		var err error
+		if data, err = decompressProject(data); err != nil {
+			return nil, err
+		}
+	}
+
 	var proj pb.Project
 	if err := proto.Unmarshal(data, &proj); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal project: %w", err)
 	}
+	return projectFromPB(&proj)
+}
 
-	p := &Project{pb: &proj}
+// projectFromPB wraps a decoded project protobuf
+func projectFromPB(proj *pb.Project) (*Project, error) {
+	if proj.Version > MaxSupportedVersion {
+		return nil, fmt.Errorf("%w: project is version %d, this build supports up to version %d", ErrUnsupportedVersion, proj.Version, MaxSupportedVersion)
+	}
+
+	if proj.Details == nil {
+		return nil, fmt.Errorf("%w: missing payment details", ErrInvalidProject)
+	}
+	if len(proj.Details.Outputs) == 0 {
+		return nil, fmt.Errorf("%w: no outputs", ErrInvalidProject)
+	}
+	if len(proj.Extra.GetCoverImage()) > 0 && proj.Extra.GetCoverImageUrl() != "" {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProject, ErrCoverImageConflict)
+	}
+
+	p := &Project{pb: proj}
 	
-	// Calculate total goal amount from outputs
-	for _, output := range proj.Details.Outputs {
+	// Calculate total goal amount from outputs. A zero goal would make
+	// progress undefined, so crafted outputs are checked here. OP_RETURN
+	// data outputs carry no value.
+	for i, output := range proj.Details.Outputs {
+		if output.Amount == 0 && !isDataScript(output.Script) {
+			return nil, fmt.Errorf("%w: output %d amount must be greater than 0", ErrInvalidProject, i)
+		}
+		if p.goalAmount > math.MaxUint64-output.Amount {
+			return nil, fmt.Errorf("%w: goal amount overflows", ErrInvalidProject)
+		}
 		p.goalAmount += output.Amount
 	}
+	if p.goalAmount == 0 {
+		return nil, fmt.Errorf("%w: goal amount must be greater than 0", ErrInvalidProject)
+	}
 	
 	p.id = p.calculateID()
 	return p, nil
 }
 
+// Clone returns a deep copy of the project to use as the template for a new
+// campaign. The creation time is reset and the expiry, auth key and
+// signature are cleared. Its ID only differs from the original once the
+// title or outputs are changed, e.g. with SetTitle or SetPayoutAddress.
+func (p *Project) Clone() *Project {
+	clone := &Project{
+		pb:         proto.Clone(p.pb).(*pb.Project),
+		goalAmount: p.goalAmount,
+	}
+	if clone.pb.Details == nil {
+		clone.pb.Details = &pb.ProjectDetails{}
+	}
+	clone.pb.Details.Time = timestamppb.Now()
+	clone.pb.Details.Expires = nil
+	clone.pb.Signature = nil
+	if clone.pb.Extra != nil {
+		clone.pb.Extra.AuthKey = nil
+	}
+	clone.id = clone.calculateID()
+	return clone
+}
+
 // Serialize returns the project as protobuf bytes
 func (p *Project) Serialize() ([]byte, error) {
 	return proto.Marshal(p.pb)
 }
 
-// ID returns the unique project ID (hash of serialized data)
+// SerializeCompressed returns the project as gzipped protobuf bytes, which
+// is much smaller for projects with a cover image. LoadProject reads both.
+func (p *Project) SerializeCompressed() ([]byte, error) {
+	data, err := p.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress project: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress project: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressProject inflates a gzipped project, up to maxDecompressedProjectSize
+func decompressProject(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress project: %w", err)
+	}
+	defer zr.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(zr, maxDecompressedProjectSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress project: %w", err)
+	}
+	if len(raw) > maxDecompressedProjectSize {
+		return nil, fmt.Errorf("decompressed project exceeds %d bytes", maxDecompressedProjectSize)
+	}
+	return raw, nil
+}
+
+// ID returns the unique project ID. It is the CanonicalID, so updating the
+// description, expiry, minimum pledge, auth key or cover image does not
+// change it.
 func (p *Project) ID() string {
 	return p.id
 }
 
-// calculateID generates a unique ID from project data
+// calculateID generates the project ID from its canonical fields
 func (p *Project) calculateID() string {
-	data, _ := p.Serialize()
-	hash := sha256.Sum256(data)
-	return hex.EncodeToString(hash[:])
+	return p.CanonicalID()
+}
+
+// CanonicalID hashes the fields that identify a project: network, title,
+// goal and outputs. Creation time and mutable metadata are excluded, so the
+// same campaign always gets the same ID.
+func (p *Project) CanonicalID() string {
+	var h bytes.Buffer
+	writeField := func(data []byte) {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+		h.Write(length[:])
+		h.Write(data)
+	}
+
+	writeField([]byte(p.Network()))
+	writeField([]byte(p.Title()))
+
+	var amount [8]byte
+	binary.BigEndian.PutUint64(amount[:], p.goalAmount)
+	h.Write(amount[:])
+
+	if p.pb.Details != nil {
+		for _, output := range p.pb.Details.Outputs {
+			binary.BigEndian.PutUint64(amount[:], output.Amount)
+			h.Write(amount[:])
+			writeField(output.Script)
+		}
+	}
+
+	return hashID(h.Bytes())
 }
 
 // Title returns the project title
@@ -121,6 +440,14 @@ func (p *Project) Description() string {
 	return ""
 }
 
+// Network returns the network the project is on
+func (p *Project) Network() string {
+	if p.pb.Details != nil {
+		return p.pb.Details.Network
+	}
+	return ""
+}
+
 // GoalAmount returns the funding goal in satoshis
 func (p *Project) GoalAmount() uint64 {
 	return p.goalAmount
@@ -134,6 +461,41 @@ func (p *Project) MinPledgeAmount() uint64 {
 	return 10000 // Default 0.0001 BSV
 }
 
+// SetMinPledgeAmount sets the minimum pledge in satoshis
+func (p *Project) SetMinPledgeAmount(satoshis uint64) error {
+	if satoshis == 0 {
+		return errors.New("minimum pledge must be greater than 0")
+	}
+	if satoshis > p.goalAmount {
+		return fmt.Errorf("minimum pledge %d exceeds goal amount %d", satoshis, p.goalAmount)
+	}
+
+	if p.pb.Extra == nil {
+		p.pb.Extra = &pb.ProjectExtraDetails{}
+	}
+	p.pb.Extra.MinPledgeAmount = satoshis
+
+	return nil
+}
+
+// SetMinPledgePercent sets the minimum pledge to pct percent of the goal,
+// rounded to the nearest satoshi (at least 1). pct must be in (0, 100].
+func (p *Project) SetMinPledgePercent(pct float64) error {
+	if math.IsNaN(pct) || pct <= 0 || pct > 100 {
+		return fmt.Errorf("minimum pledge percent must be greater than 0 and at most 100, got %v", pct)
+	}
+
+	satoshis := uint64(math.Round(float64(p.goalAmount) * pct / 100))
+	if satoshis == 0 {
+		satoshis = 1
+	}
+	if satoshis > p.goalAmount {
+		satoshis = p.goalAmount
+	}
+
+	return p.SetMinPledgeAmount(satoshis)
+}
+
 // IsExpired checks if the project has expired
 func (p *Project) IsExpired() bool {
 	if p.pb.Details == nil || p.pb.Details.Expires == nil {
@@ -142,6 +504,22 @@ func (p *Project) IsExpired() bool {
 	return p.pb.Details.Expires.AsTime().Before(time.Now())
 }
 
+// SetExpiry sets when the project stops accepting pledges
+func (p *Project) SetExpiry(t time.Time) {
+	if p.pb.Details == nil {
+		p.pb.Details = &pb.ProjectDetails{}
+	}
+	p.pb.Details.Expires = timestamppb.New(t)
+}
+
+// Expires returns the project expiry time, or the zero time if none is set
+func (p *Project) Expires() time.Time {
+	if p.pb.Details == nil || p.pb.Details.Expires == nil {
+		return time.Time{}
+	}
+	return p.pb.Details.Expires.AsTime()
+}
+
 // Outputs returns the transaction outputs for this project
 func (p *Project) Outputs() ([]*transaction.TransactionOutput, error) {
 	if p.pb.Details == nil {
@@ -161,35 +539,730 @@ func (p *Project) Outputs() ([]*transaction.TransactionOutput, error) {
 	return outputs, nil
 }
 
+// URI returns a compact lighthouse: URI referencing the project, suitable
+// for QR codes. It carries the ID, goal and payout addresses rather than
+// the serialized project, which may include a large cover image.
+func (p *Project) URI() string {
+	params := url.Values{}
+	params.Set("goal", strconv.FormatUint(p.goalAmount, 10))
+
+	if p.pb.Details != nil {
+		mainnet := p.pb.Details.Network != NetworkTestnet
+		for _, out := range p.pb.Details.Outputs {
+			if address, err := outputAddress(out.Script, mainnet); err == nil {
+				params.Add("address", address)
+			}
+		}
+	}
+
+	return "lighthouse:" + p.id + "?" + params.Encode()
+}
+
+// OutputAddresses decodes each output's locking script back to the address
+// it pays, in output order. Outputs that aren't standard P2PKH have an empty
+// entry, since they have no address to show.
+func (p *Project) OutputAddresses() ([]string, error) {
+	if p.pb.Details == nil {
+		return nil, errors.New("project has no details")
+	}
+
+	mainnet := p.pb.Details.Network != NetworkTestnet
+	addresses := make([]string, len(p.pb.Details.Outputs))
+	for i, out := range p.pb.Details.Outputs {
+		if address, err := outputAddress(out.Script, mainnet); err == nil {
+			addresses[i] = address
+		}
+	}
+	return addresses, nil
+}
+
+// ProjectJSON is the form of a project served to frontends. Scripts are
+// shown as addresses and an inline cover image is only flagged, not
+// included; a cover image URL is passed through.
+type ProjectJSON struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Network     string          `json:"network"`
+	Goal        uint64          `json:"goal"`
+	GoalBSV     string          `json:"goalBsv"`
+	MinPledge   uint64          `json:"minPledge"`
+	Expires     *time.Time      `json:"expires,omitempty"`
+	IsExpired   bool            `json:"isExpired"`
+	Outputs     []ProjectOutput `json:"outputs"`
+	HasCover    bool            `json:"hasCoverImage"`
+	CoverURL    string          `json:"coverImageUrl,omitempty"`
+	Status      string          `json:"status"`
+	Category    string          `json:"category,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Milestones  []Milestone     `json:"milestones,omitempty"`
+	Links       []Link          `json:"links,omitempty"`
+}
+
+// JSON returns the project's frontend representation. Outputs that aren't
+// P2PKH have no address.
+func (p *Project) JSON() ProjectJSON {
+	result := ProjectJSON{
+		ID:          p.ID(),
+		Title:       p.Title(),
+		Description: p.Description(),
+		Network:     p.Network(),
+		Goal:        p.GoalAmount(),
+		GoalBSV:     SatoshisToBSV(p.GoalAmount()),
+		MinPledge:   p.MinPledgeAmount(),
+		IsExpired:   p.IsExpired(),
+		Outputs:     []ProjectOutput{},
+		HasCover:    p.HasCoverImage(),
+		CoverURL:    p.CoverImageRef(),
+		Status:      p.Status().String(),
+		Category:    p.Category(),
+		Tags:        p.Tags(),
+		Milestones:  p.Milestones(),
+		Links:       p.Links(),
+	}
+	if expires := p.Expires(); !expires.IsZero() {
+		expires = expires.UTC()
+		result.Expires = &expires
+	}
+	if p.pb.Details != nil {
+		addresses, _ := p.OutputAddresses()
+		for i, out := range p.pb.Details.Outputs {
+			result.Outputs = append(result.Outputs, ProjectOutput{Address: addresses[i], Amount: out.Amount})
+		}
+	}
+	return result
+}
+
+// MarshalJSON encodes the project as its ProjectJSON representation
+func (p *Project) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.JSON())
+}
+
+// outputAddress decodes a P2PKH locking script back to its address
+func outputAddress(lockingScript []byte, mainnet bool) (string, error) {
+	s := script.Script(lockingScript)
+	if !s.IsP2PKH() {
+		return "", errors.New("not a P2PKH script")
+	}
+
+	// OP_DUP OP_HASH160 <20 byte hash> OP_EQUALVERIFY OP_CHECKSIG
+	addr, err := script.NewAddressFromPublicKeyHash(lockingScript[3:23], mainnet)
+	if err != nil {
+		return "", err
+	}
+	return addr.AddressString, nil
+}
+
 // SetAuthKey sets the authentication key for project ownership
 func (p *Project) SetAuthKey(pubKey []byte) {
 	if p.pb.Extra == nil {
 		p.pb.Extra = &pb.ProjectExtraDetails{}
 	}
 	p.pb.Extra.AuthKey = pubKey
-	p.id = p.calculateID() // Recalculate ID
 }
 
-// SetCoverImage sets the project cover image
+// AuthKey returns the project owner's public key, if one is set
+func (p *Project) AuthKey() []byte {
+	if p.pb.Extra != nil {
+		return p.pb.Extra.AuthKey
+	}
+	return nil
+}
+
+// AuthSignature returns the owner's signature over the project, if signed
+func (p *Project) AuthSignature() []byte {
+	return p.pb.Signature
+}
+
+// SignAuth signs the project with the owner's auth key and stores the
+// signature in the project. The key must match the project's auth key.
+func (p *Project) SignAuth(privKey *ec.PrivateKey) error {
+	authKey := p.AuthKey()
+	if len(authKey) == 0 {
+		return ErrNoAuthKey
+	}
+	if !bytes.Equal(privKey.PubKey().Compressed(), authKey) {
+		return errors.New("private key does not match project auth key")
+	}
+
+	sig, err := privKey.Sign(p.authHash())
+	if err != nil {
+		return fmt.Errorf("failed to sign project: %w", err)
+	}
+
+	p.pb.Signature = sig.Serialize()
+	return nil
+}
+
+// VerifyAuthSignature checks that sig is a valid signature by the project's
+// auth key over the project's current contents
+func (p *Project) VerifyAuthSignature(sig []byte) error {
+	return p.verifyAuth(p.authHash(), sig)
+}
+
+// VerifyUpdate checks that updated, a new version of this project, was
+// signed by this version's auth key, as `project update` does. An owner can
+// rotate the auth key this way, but only with the old one.
+func (p *Project) VerifyUpdate(updated *Project) error {
+	sig := updated.AuthSignature()
+	if len(sig) == 0 {
+		return fmt.Errorf("%w: update is not signed", ErrInvalidAuthSignature)
+	}
+	return p.verifyAuth(updated.authHash(), sig)
+}
+
+// SignAuthMessage signs an arbitrary message, such as a server challenge,
+// with the project's auth key to prove ownership
+func (p *Project) SignAuthMessage(privKey *ec.PrivateKey, message []byte) ([]byte, error) {
+	authKey := p.AuthKey()
+	if len(authKey) == 0 {
+		return nil, ErrNoAuthKey
+	}
+	if !bytes.Equal(privKey.PubKey().Compressed(), authKey) {
+		return nil, errors.New("private key does not match project auth key")
+	}
+
+	hash := sha256.Sum256(message)
+	sig, err := privKey.Sign(hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	return sig.Serialize(), nil
+}
+
+// VerifyAuthMessage checks that sig is a signature by the project's auth key
+// over message, as produced by SignAuthMessage
+func (p *Project) VerifyAuthMessage(message, sig []byte) error {
+	hash := sha256.Sum256(message)
+	return p.verifyAuth(hash[:], sig)
+}
+
+// verifyAuth checks a DER signature by the project's auth key over hash
+func (p *Project) verifyAuth(hash, sig []byte) error {
+	authKey := p.AuthKey()
+	if len(authKey) == 0 {
+		return ErrNoAuthKey
+	}
+
+	pubKey, err := ec.ParsePubKey(authKey)
+	if err != nil {
+		return fmt.Errorf("invalid auth key: %w", err)
+	}
+	signature, err := ec.ParseDERSignature(sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAuthSignature, err)
+	}
+	if !signature.Verify(hash, pubKey) {
+		return ErrInvalidAuthSignature
+	}
+	return nil
+}
+
+// authHash returns the hash signed by the auth key: the serialized project
+// without its signature field
+func (p *Project) authHash() []byte {
+	unsigned := proto.Clone(p.pb).(*pb.Project)
+	unsigned.Signature = nil
+	data, _ := proto.Marshal(unsigned)
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
+
+// signedProjectDomain is prepended to the project bytes signed by
+// ExportSigned, so a bundle signature can't pass for any other auth
+// signature, such as a server challenge response, or the other way round
+const signedProjectDomain = "lighthouse signed project\n"
+
+// ExportSigned bundles the project with its creator's signature over it,
+// for distribution. privKey must match the project's auth key; recipients
+// check the bundle with LoadSignedProject.
+func (p *Project) ExportSigned(privKey *ec.PrivateKey) ([]byte, error) {
+	data, err := p.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := p.SignAuthMessage(privKey, append([]byte(signedProjectDomain), data...))
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&pb.SignedProject{
+		Project:   data,
+		Signature: sig,
+		AuthKey:   p.AuthKey(),
+	})
+}
+
+// LoadSignedProject loads a project bundled by ExportSigned. The bundled
+// key must be the project's auth key and must have signed exactly the
+// bundled project bytes, so any change to the project is detected.
+func LoadSignedProject(data []byte) (*Project, error) {
+	var bundle pb.SignedProject
+	if err := proto.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSignedProject, err)
+	}
+	if len(bundle.Project) == 0 {
+		return nil, ErrNotSignedProject
+	}
+
+	project, err := LoadProject(bundle.Project)
+	if err != nil {
+		return nil, err
+	}
+	if len(project.AuthKey()) == 0 {
+		return nil, ErrNoAuthKey
+	}
+	if !bytes.Equal(bundle.AuthKey, project.AuthKey()) {
+		return nil, fmt.Errorf("%w: bundle is signed by a different key than the project's auth key", ErrInvalidAuthSignature)
+	}
+	if err := project.VerifyAuthMessage(append([]byte(signedProjectDomain), bundle.Project...), bundle.Signature); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// SetTitle sets the project title. The title is part of the ID, so this
+// makes a different project: pledges to the old ID won't match it.
+func (p *Project) SetTitle(title string) error {
+	if title == "" {
+		return errors.New("title is required")
+	}
+	if p.pb.Extra == nil {
+		p.pb.Extra = &pb.ProjectExtraDetails{}
+	}
+	p.pb.Extra.Title = title
+	p.id = p.calculateID()
+	return nil
+}
+
+// SetPayoutAddress points a single-output project at a new address on the
+// same network. Like SetTitle it changes the project ID.
+func (p *Project) SetPayoutAddress(address string) error {
+	var payout *pb.Output
+	if p.pb.Details != nil {
+		for _, out := range p.pb.Details.Outputs {
+			if isDataScript(out.Script) {
+				continue
+			}
+			if payout != nil {
+				return errors.New("payout address can only be set on a project with one output")
+			}
+			payout = out
+		}
+	}
+	if payout == nil {
+		return errors.New("project has no payout output")
+	}
+
+	network, err := DetectNetwork(address)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	if network != p.Network() {
+		return fmt.Errorf("address is for %s but project is for %s", network, p.Network())
+	}
+	addr, err := script.NewAddressFromString(address)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	lockingScript, err := p2pkh.Lock(addr)
+	if err != nil {
+		return fmt.Errorf("failed to create locking script: %w", err)
+	}
+
+	payout.Script = lockingScript.Bytes()
+	p.id = p.calculateID()
+	return nil
+}
+
+// AddDataOutput appends a zero-value OP_FALSE OP_RETURN output carrying
+// data, such as a content hash, to the project outputs, so it is anchored
+// on-chain by the claim transaction. A project can have one data output.
+// The outputs are part of the ID, so add it before anyone pledges.
+func (p *Project) AddDataOutput(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("data output needs data")
+	}
+	if len(data) > MaxDataOutputSize {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrDataOutputTooLarge, len(data), MaxDataOutputSize)
+	}
+	if p.pb.Details == nil {
+		return errors.New("project has no details")
+	}
+	for _, out := range p.pb.Details.Outputs {
+		if isDataScript(out.Script) {
+			return errors.New("project already has a data output")
+		}
+	}
+
+	lockingScript := &script.Script{}
+	if err := lockingScript.AppendOpcodes(script.OpFALSE, script.OpRETURN); err != nil {
+		return fmt.Errorf("failed to create data script: %w", err)
+	}
+	if err := lockingScript.AppendPushData(data); err != nil {
+		return fmt.Errorf("failed to create data script: %w", err)
+	}
+
+	p.pb.Details.Outputs = append(p.pb.Details.Outputs, &pb.Output{
+		Amount: 0,
+		Script: lockingScript.Bytes(),
+	})
+	p.id = p.calculateID()
+	return nil
+}
+
+// isDataScript reports whether a locking script is an unspendable OP_RETURN
+// data carrier
+func isDataScript(lockingScript []byte) bool {
+	s := script.Script(lockingScript)
+	return s.IsData()
+}
+
+// SetDescription sets the project description
+func (p *Project) SetDescription(description string) error {
+	if description == "" {
+		return errors.New("description is required")
+	}
+	if p.pb.Details == nil {
+		p.pb.Details = &pb.ProjectDetails{}
+	}
+	p.pb.Details.Memo = description
+	return nil
+}
+
+// SetTags replaces the project's tags. Surrounding whitespace is trimmed
+// and empty or repeated tags (ignoring case) are dropped.
+func (p *Project) SetTags(tags ...string) {
+	var cleaned []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, tag)
+	}
+
+	if p.pb.Extra == nil {
+		p.pb.Extra = &pb.ProjectExtraDetails{}
+	}
+	p.pb.Extra.Tags = cleaned
+}
+
+// Tags returns the project's tags
+func (p *Project) Tags() []string {
+	if p.pb.Extra != nil {
+		return p.pb.Extra.Tags
+	}
+	return nil
+}
+
+// HasTag reports whether the project has a tag, ignoring case
+func (p *Project) HasTag(tag string) bool {
+	for _, t := range p.Tags() {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCategory sets the project's category
+func (p *Project) SetCategory(category string) {
+	if p.pb.Extra == nil {
+		p.pb.Extra = &pb.ProjectExtraDetails{}
+	}
+	p.pb.Extra.Category = strings.TrimSpace(category)
+}
+
+// Category returns the project's category
+func (p *Project) Category() string {
+	if p.pb.Extra != nil {
+		return p.pb.Extra.Category
+	}
+	return ""
+}
+
+// ProjectStatus is whether a project is taking pledges
+type ProjectStatus int32
+
+// Project statuses. A paused project takes no new pledges but its existing
+// pledges can still be claimed; a cancelled one can't be pledged to or
+// claimed at all.
+const (
+	ProjectActive    = ProjectStatus(pb.ProjectState_PROJECT_STATE_ACTIVE)
+	ProjectPaused    = ProjectStatus(pb.ProjectState_PROJECT_STATE_PAUSED)
+	ProjectCancelled = ProjectStatus(pb.ProjectState_PROJECT_STATE_CANCELLED)
+)
+
+// String returns the status name: active, paused or cancelled
+func (s ProjectStatus) String() string {
+	switch s {
+	case ProjectActive:
+		return "active"
+	case ProjectPaused:
+		return "paused"
+	case ProjectCancelled:
+		return "cancelled"
+	}
+	return fmt.Sprintf("unknown(%d)", int32(s))
+}
+
+// ParseProjectStatus parses a status name as returned by String
+func ParseProjectStatus(name string) (ProjectStatus, error) {
+	for _, s := range []ProjectStatus{ProjectActive, ProjectPaused, ProjectCancelled} {
+		if strings.EqualFold(name, s.String()) {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown project status %q (expected active, paused or cancelled)", name)
+}
+
+// SetStatus sets whether the project is taking pledges. Like the other
+// metadata, it doesn't change the project ID.
+func (p *Project) SetStatus(s ProjectStatus) {
+	if p.pb.Extra == nil {
+		p.pb.Extra = &pb.ProjectExtraDetails{}
+	}
+	p.pb.Extra.Status = pb.ProjectState(s)
+}
+
+// Status returns whether the project is taking pledges
+func (p *Project) Status() ProjectStatus {
+	return ProjectStatus(p.pb.Extra.GetStatus())
+}
+
+// CheckAcceptingPledges returns ErrProjectClosed unless the project is
+// active
+func (p *Project) CheckAcceptingPledges() error {
+	if s := p.Status(); s != ProjectActive {
+		return fmt.Errorf("%w: project is %s", ErrProjectClosed, s)
+	}
+	return nil
+}
+
+// Milestone is a stretch goal reached once the total pledged meets its threshold
+type Milestone struct {
+	Threshold   uint64 `json:"threshold"`
+	Description string `json:"description"`
+}
+
+// SetMilestones replaces the project's milestones, sorted by threshold.
+// Each needs a nonzero threshold, distinct from the others, and a description.
+func (p *Project) SetMilestones(milestones ...Milestone) error {
+	sorted := make([]Milestone, len(milestones))
+	copy(sorted, milestones)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Threshold < sorted[j].Threshold
+	})
+
+	pbMilestones := make([]*pb.Milestone, 0, len(sorted))
+	for i, m := range sorted {
+		description := strings.TrimSpace(m.Description)
+		if m.Threshold == 0 {
+			return errors.New("milestone threshold must be greater than zero")
+		}
+		if description == "" {
+			return fmt.Errorf("milestone at %s BSV needs a description", SatoshisToBSV(m.Threshold))
+		}
+		if i > 0 && m.Threshold == sorted[i-1].Threshold {
+			return fmt.Errorf("more than one milestone at %s BSV", SatoshisToBSV(m.Threshold))
+		}
+		pbMilestones = append(pbMilestones, &pb.Milestone{
+			Threshold:   m.Threshold,
+			Description: description,
+		})
+	}
+
+	if p.pb.Extra == nil {
+		p.pb.Extra = &pb.ProjectExtraDetails{}
+	}
+	p.pb.Extra.Milestones = pbMilestones
+	return nil
+}
+
+// Milestones returns the project's milestones in ascending threshold order
+func (p *Project) Milestones() []Milestone {
+	if p.pb.Extra == nil {
+		return nil
+	}
+	var milestones []Milestone
+	for _, m := range p.pb.Extra.Milestones {
+		milestones = append(milestones, Milestone{
+			Threshold:   m.Threshold,
+			Description: m.Description,
+		})
+	}
+	return milestones
+}
+
+// Link is a labeled website or social link shown with a project
+type Link struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// AddLink appends a link to the project. Only absolute http and https URLs
+// are accepted, so frontends can't be made to render javascript: or data:
+// links.
+func (p *Project) AddLink(label, rawURL string) error {
+	link := Link{Label: strings.TrimSpace(label), URL: strings.TrimSpace(rawURL)}
+	if err := validateLink(link); err != nil {
+		return err
+	}
+	if p.pb.Extra == nil {
+		p.pb.Extra = &pb.ProjectExtraDetails{}
+	}
+	if len(p.pb.Extra.Links) >= MaxLinks {
+		return fmt.Errorf("%w: a project can have at most %d links", ErrInvalidLink, MaxLinks)
+	}
+	p.pb.Extra.Links = append(p.pb.Extra.Links, &pb.Link{Label: link.Label, Url: link.URL})
+	return nil
+}
+
+// Links returns the project's links in the order they were added. Links in a
+// hand-crafted project file that AddLink would reject are left out.
+func (p *Project) Links() []Link {
+	if p.pb.Extra == nil {
+		return nil
+	}
+	var links []Link
+	for _, l := range p.pb.Extra.Links {
+		link := Link{Label: l.Label, URL: l.Url}
+		if validateLink(link) == nil {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// validateLink checks a link has a label and an absolute http(s) URL
+func validateLink(link Link) error {
+	if link.Label == "" {
+		return fmt.Errorf("%w: label is required", ErrInvalidLink)
+	}
+	if strings.IndexFunc(link.Label+link.URL, unicode.IsControl) >= 0 {
+		return fmt.Errorf("%w: contains control characters", ErrInvalidLink)
+	}
+
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLink, err)
+	}
+	if scheme := strings.ToLower(u.Scheme); scheme != "http" && scheme != "https" {
+		return fmt.Errorf("%w: %q must use http or https", ErrInvalidLink, link.URL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: %q has no host", ErrInvalidLink, link.URL)
+	}
+	return nil
+}
+
+// HasCoverImage reports whether the project has a cover image
+func (p *Project) HasCoverImage() bool {
+	return p.pb.Extra != nil && len(p.pb.Extra.CoverImage) > 0
+}
+
+// SetCoverImage sets the project cover image. The image must be JPEG, PNG,
+// GIF or WebP and no larger than MaxCoverImageSize.
 func (p *Project) SetCoverImage(imageData []byte) error {
-	// Basic validation - check for JPEG or PNG header
+	// Basic validation - check the header of a known format
 	if len(imageData) < 4 {
 		return errors.New("invalid image data")
 	}
-	
-	// Check for JPEG (FF D8 FF) or PNG (89 50 4E 47) headers
-	isJPEG := imageData[0] == 0xFF && imageData[1] == 0xD8 && imageData[2] == 0xFF
-	isPNG := imageData[0] == 0x89 && imageData[1] == 0x50 && imageData[2] == 0x4E && imageData[3] == 0x47
-	
-	if !isJPEG && !isPNG {
-		return errors.New("image must be JPEG or PNG format")
+	if len(imageData) > MaxCoverImageSize {
+		return fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrCoverImageTooLarge, len(imageData), MaxCoverImageSize)
+	}
+	if detectImageType(imageData) == "" {
+		return errors.New("image must be JPEG, PNG, GIF or WebP format")
+	}
+	if p.CoverImageRef() != "" {
+		return ErrCoverImageConflict
 	}
 
 	if p.pb.Extra == nil {
 		p.pb.Extra = &pb.ProjectExtraDetails{}
 	}
 	p.pb.Extra.CoverImage = imageData
-	p.id = p.calculateID() // Recalculate ID
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// CoverImage returns the cover image bytes and their MIME type
+func (p *Project) CoverImage() ([]byte, string, error) {
+	if !p.HasCoverImage() {
+		return nil, "", ErrNoCoverImage
+	}
+
+	data := p.pb.Extra.CoverImage
+	mimeType := detectImageType(data)
+	if mimeType == "" {
+		return nil, "", errors.New("cover image has an unrecognized format")
+	}
+	return data, mimeType, nil
+}
+
+// SetCoverImageURL points the project at a cover image hosted elsewhere,
+// which keeps the project file small enough to share, e.g. as a QR code.
+// The URL must be absolute http, https or ipfs (content-addressed). An
+// empty URL removes it. A project can't have both an inline cover image
+// and a URL.
+func (p *Project) SetCoverImageURL(rawURL string) error {
+	if rawURL == "" {
+		if p.pb.Extra != nil {
+			p.pb.Extra.CoverImageUrl = ""
+		}
+		return nil
+	}
+	if p.HasCoverImage() {
+		return ErrCoverImageConflict
+	}
+	if strings.IndexFunc(rawURL, unicode.IsControl) >= 0 {
+		return fmt.Errorf("%w: contains control characters", ErrInvalidCoverImageURL)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCoverImageURL, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "ipfs":
+	default:
+		return fmt.Errorf("%w: %q must use http, https or ipfs", ErrInvalidCoverImageURL, rawURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: %q has no host", ErrInvalidCoverImageURL, rawURL)
+	}
+
+	if p.pb.Extra == nil {
+		p.pb.Extra = &pb.ProjectExtraDetails{}
+	}
+	p.pb.Extra.CoverImageUrl = rawURL
+	return nil
+}
+
+// CoverImageRef returns the URL of an externally hosted cover image, or an
+// empty string if none is set
+func (p *Project) CoverImageRef() string {
+	if p.pb.Extra == nil {
+		return ""
+	}
+	return p.pb.Extra.CoverImageUrl
+}
+
+// detectImageType returns the MIME type of a supported image from its
+// header, or an empty string if the format is not recognized
+func detectImageType(data []byte) string {
+	switch {
+	case len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF:
+		return "image/jpeg"
+	case len(data) >= 4 && data[0] == 0x89 && data[1] == 0x50 && data[2] == 0x4E && data[3] == 0x47:
+		return "image/png"
+	case len(data) >= 6 && (string(data[:6]) == "GIF87a" || string(data[:6]) == "GIF89a"):
+		return "image/gif"
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return "image/webp"
+	}
+	return ""
+}
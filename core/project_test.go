@@ -1,10 +1,20 @@
 package core
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	pb "github.com/yourusername/lighthouse/core/proto"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestNewProject(t *testing.T) {
@@ -12,9 +22,9 @@ func TestNewProject(t *testing.T) {
 		title := "Test Project"
 		description := "This is a test project"
 		goalAmount := uint64(100000000) // 1 BSV
-		address := "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q"
+		address := "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6"
 
-		project, err := NewProject(title, description, goalAmount, address)
+		project, err := NewProject(title, description, goalAmount, address, NetworkMainnet)
 		require.NoError(t, err)
 		assert.NotNil(t, project)
 
@@ -28,34 +38,155 @@ func TestNewProject(t *testing.T) {
 	})
 
 	t.Run("invalid address", func(t *testing.T) {
-		project, err := NewProject("Test", "Description", 100000000, "invalid-address")
+		project, err := NewProject("Test", "Description", 100000000, "invalid-address", NetworkMainnet)
 		assert.Error(t, err)
 		assert.Nil(t, project)
 		assert.Contains(t, err.Error(), "invalid address")
 	})
 
 	t.Run("zero goal amount", func(t *testing.T) {
-		project, err := NewProject("Test", "Description", 0, "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q")
+		project, err := NewProject("Test", "Description", 0, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
 		assert.Error(t, err)
 		assert.Nil(t, project)
 		assert.Contains(t, err.Error(), "goal amount must be greater than 0")
 	})
 
+	t.Run("tiny goal", func(t *testing.T) {
+		project, err := NewProject("Test", "Description", 500, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+		assert.ErrorIs(t, err, ErrDustOutput)
+		assert.Nil(t, project)
+
+		project, err = NewProject("Test", "Description", DustThreshold, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+		require.NoError(t, err)
+		assert.Equal(t, DustThreshold, project.GoalAmount())
+	})
+
 	t.Run("empty title", func(t *testing.T) {
-		project, err := NewProject("", "Description", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q")
+		project, err := NewProject("", "Description", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
 		assert.Error(t, err)
 		assert.Nil(t, project)
 		assert.Contains(t, err.Error(), "title and description are required")
 	})
 }
 
+func TestDetectNetwork(t *testing.T) {
+	t.Run("mainnet address", func(t *testing.T) {
+		network, err := DetectNetwork("1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6")
+		require.NoError(t, err)
+		assert.Equal(t, NetworkMainnet, network)
+	})
+
+	t.Run("testnet address", func(t *testing.T) {
+		network, err := DetectNetwork("mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r")
+		require.NoError(t, err)
+		assert.Equal(t, NetworkTestnet, network)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := DetectNetwork("invalid-address")
+		assert.Error(t, err)
+	})
+
+	t.Run("bad checksum", func(t *testing.T) {
+		_, err := DetectNetwork("1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q")
+		assert.ErrorContains(t, err, "bad checksum")
+		_, err = DetectNetwork("mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8s")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewProjectNetwork(t *testing.T) {
+	t.Run("testnet project", func(t *testing.T) {
+		project, err := NewProject("Test", "Description", 100000000, "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r", NetworkTestnet)
+		require.NoError(t, err)
+		assert.Equal(t, NetworkTestnet, project.Network())
+	})
+
+	t.Run("testnet address on mainnet project", func(t *testing.T) {
+		project, err := NewProject("Test", "Description", 100000000, "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r", NetworkMainnet)
+		assert.Error(t, err)
+		assert.Nil(t, project)
+		assert.Contains(t, err.Error(), "address is for testnet")
+	})
+
+	t.Run("unsupported network", func(t *testing.T) {
+		project, err := NewProject("Test", "Description", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", "regtest")
+		assert.Error(t, err)
+		assert.Nil(t, project)
+		assert.Contains(t, err.Error(), "unsupported network")
+	})
+}
+
+func TestNewProjectWithOutputs(t *testing.T) {
+	t.Run("split payout", func(t *testing.T) {
+		project, err := NewProjectWithOutputs("Split Project", "Creator and platform split", []ProjectOutput{
+			{Address: "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", Amount: 80000000},
+			{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 20000000},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100000000), project.GoalAmount())
+		assert.Equal(t, NetworkMainnet, project.Network())
+
+		outputs, err := project.Outputs()
+		require.NoError(t, err)
+		require.Len(t, outputs, 2)
+		assert.Equal(t, uint64(80000000), outputs[0].Satoshis)
+		assert.Equal(t, uint64(20000000), outputs[1].Satoshis)
+
+		// Goal survives a roundtrip since it is derived from the outputs
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.Equal(t, project.GoalAmount(), loaded.GoalAmount())
+	})
+
+	t.Run("zero output amount", func(t *testing.T) {
+		project, err := NewProjectWithOutputs("Split Project", "Description", []ProjectOutput{
+			{Address: "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", Amount: 80000000},
+			{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 0},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, project)
+		assert.Contains(t, err.Error(), "output 1 amount must be greater than 0")
+	})
+
+	t.Run("dust output", func(t *testing.T) {
+		project, err := NewProjectWithOutputs("Split Project", "Description", []ProjectOutput{
+			{Address: "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", Amount: 80000000},
+			{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: DustThreshold - 1},
+		})
+		assert.ErrorIs(t, err, ErrDustOutput)
+		assert.Nil(t, project)
+		assert.Contains(t, err.Error(), "output 1")
+	})
+
+	t.Run("no outputs", func(t *testing.T) {
+		project, err := NewProjectWithOutputs("Split Project", "Description", nil)
+		assert.Error(t, err)
+		assert.Nil(t, project)
+		assert.Contains(t, err.Error(), "at least one output")
+	})
+
+	t.Run("mixed networks", func(t *testing.T) {
+		project, err := NewProjectWithOutputs("Split Project", "Description", []ProjectOutput{
+			{Address: "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", Amount: 80000000},
+			{Address: "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r", Amount: 20000000},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, project)
+		assert.Contains(t, err.Error(), "address is for testnet")
+	})
+}
+
 func TestProjectSerialization(t *testing.T) {
 	// Create a project
 	project, err := NewProject(
 		"Serialization Test",
 		"Testing serialization",
 		200000000, // 2 BSV
-		"1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
 	)
 	require.NoError(t, err)
 
@@ -77,12 +208,334 @@ func TestProjectSerialization(t *testing.T) {
 	assert.Equal(t, project.MinPledgeAmount(), loaded.MinPledgeAmount())
 }
 
+func TestLoadProjectVersion(t *testing.T) {
+	project, err := NewProject("Version Test", "Testing format versions", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	project.pb.Version = MaxSupportedVersion + 1
+	data, err := project.Serialize()
+	require.NoError(t, err)
+	_, err = LoadProject(data)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	assert.Contains(t, err.Error(), fmt.Sprintf("version %d", MaxSupportedVersion+1))
+
+	contractData, err := NewContract(project).Serialize()
+	require.NoError(t, err)
+	_, err = LoadContract(contractData)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	// Files from before the version was recorded still load
+	project.pb.Version = 0
+	data, err = project.Serialize()
+	require.NoError(t, err)
+	_, err = LoadProject(data)
+	assert.NoError(t, err)
+}
+
+func TestLoadProjectMissingFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		project *pb.Project
+		message string
+	}{
+		{"empty", &pb.Project{}, "missing payment details"},
+		{"version only", &pb.Project{Version: 1}, "missing payment details"},
+		{"no outputs", &pb.Project{Version: 1, Details: &pb.ProjectDetails{Network: NetworkMainnet}}, "no outputs"},
+		{"zero amount output", &pb.Project{Version: 1, Details: &pb.ProjectDetails{Network: NetworkMainnet, Outputs: []*pb.Output{{Amount: 0, Script: []byte{0x51}}}}}, "output 0 amount must be greater than 0"},
+		{"only a data output", &pb.Project{Version: 1, Details: &pb.ProjectDetails{Network: NetworkMainnet, Outputs: []*pb.Output{{Amount: 0, Script: []byte{0x00, 0x6a, 0x01, 0x01}}}}}, "goal amount must be greater than 0"},
+		{"overflowing goal", &pb.Project{Version: 1, Details: &pb.ProjectDetails{Network: NetworkMainnet, Outputs: []*pb.Output{{Amount: math.MaxUint64, Script: []byte{0x51}}, {Amount: 1, Script: []byte{0x51}}}}}, "goal amount overflows"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := proto.Marshal(tt.project)
+			require.NoError(t, err)
+
+			var project *Project
+			require.NotPanics(t, func() { project, err = LoadProject(data) })
+			assert.ErrorIs(t, err, ErrInvalidProject)
+			assert.Contains(t, err.Error(), tt.message)
+			assert.Nil(t, project)
+		})
+	}
+
+	t.Run("contract with empty project", func(t *testing.T) {
+		data, err := proto.Marshal(&pb.Contract{Project: &pb.Project{Version: 1}})
+		require.NoError(t, err)
+		_, err = LoadContract(data)
+		assert.ErrorIs(t, err, ErrInvalidProject)
+	})
+}
+
+func TestProjectSerializeCompressed(t *testing.T) {
+	project, err := NewProject("Compression Test", "Testing compression", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	cover := append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, make([]byte, 64*1024)...)
+	require.NoError(t, project.SetCoverImage(cover))
+
+	raw, err := project.Serialize()
+	require.NoError(t, err)
+	compressed, err := project.SerializeCompressed()
+	require.NoError(t, err)
+	assert.Less(t, len(compressed), len(raw)/10)
+
+	t.Run("compressed roundtrip", func(t *testing.T) {
+		loaded, err := LoadProject(compressed)
+		require.NoError(t, err)
+		reserialized, err := loaded.Serialize()
+		require.NoError(t, err)
+		assert.Equal(t, raw, reserialized)
+		assert.Equal(t, project.ID(), loaded.ID())
+		image, _, err := loaded.CoverImage()
+		require.NoError(t, err)
+		assert.Equal(t, cover, image)
+	})
+
+	t.Run("uncompressed still loads", func(t *testing.T) {
+		loaded, err := LoadProject(raw)
+		require.NoError(t, err)
+		assert.Equal(t, project.ID(), loaded.ID())
+	})
+
+	t.Run("corrupt gzip", func(t *testing.T) {
+		_, err := LoadProject(compressed[:len(compressed)/2])
+		assert.Error(t, err)
+	})
+}
+
+func TestProjectCanonicalID(t *testing.T) {
+	newProject := func(title, address string) *Project {
+		project, err := NewProject(title, "Testing canonical IDs", 100000000, address, NetworkMainnet)
+		require.NoError(t, err)
+		return project
+	}
+
+	t.Run("reproducible across creation times", func(t *testing.T) {
+		first := newProject("Canonical", "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6")
+		time.Sleep(10 * time.Millisecond)
+		second := newProject("Canonical", "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6")
+		assert.Equal(t, first.ID(), second.ID())
+		assert.Equal(t, first.CanonicalID(), first.ID())
+	})
+
+	t.Run("identifying fields change the ID", func(t *testing.T) {
+		base := newProject("Canonical", "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6")
+		assert.NotEqual(t, base.ID(), newProject("Other Title", "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6").ID())
+		assert.NotEqual(t, base.ID(), newProject("Canonical", "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH").ID())
+
+		larger, err := NewProject("Canonical", "Testing canonical IDs", 200000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+		require.NoError(t, err)
+		assert.NotEqual(t, base.ID(), larger.ID())
+	})
+
+	t.Run("metadata updates keep the ID", func(t *testing.T) {
+		project := newProject("Canonical", "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6")
+		id := project.ID()
+
+		authKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		project.SetAuthKey(authKey.PubKey().Compressed())
+		require.NoError(t, project.SetCoverImage([]byte{0xFF, 0xD8, 0xFF, 0xE0}))
+		require.NoError(t, project.SetDescription("A new description"))
+		project.SetExpiry(time.Now().Add(24 * time.Hour))
+		assert.Equal(t, id, project.ID())
+
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.Equal(t, id, loaded.ID())
+	})
+}
+
+func TestProjectClone(t *testing.T) {
+	original, err := NewProject("Clone Test", "Testing clones", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	require.NoError(t, original.SetMinPledgeAmount(50000))
+	original.SetTags("garden")
+	original.SetExpiry(time.Now().Add(24 * time.Hour))
+	authKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	original.SetAuthKey(authKey.PubKey().Compressed())
+	require.NoError(t, original.SignAuth(authKey))
+
+	time.Sleep(10 * time.Millisecond)
+	clone := original.Clone()
+
+	t.Run("copies the campaign details", func(t *testing.T) {
+		assert.Equal(t, original.Title(), clone.Title())
+		assert.Equal(t, original.Description(), clone.Description())
+		assert.Equal(t, original.GoalAmount(), clone.GoalAmount())
+		assert.Equal(t, original.MinPledgeAmount(), clone.MinPledgeAmount())
+		assert.Equal(t, original.Tags(), clone.Tags())
+		assert.Equal(t, original.pb.Details.Outputs[0].Script, clone.pb.Details.Outputs[0].Script)
+	})
+
+	t.Run("resets time, expiry and ownership", func(t *testing.T) {
+		assert.True(t, clone.pb.Details.Time.AsTime().After(original.pb.Details.Time.AsTime()))
+		assert.True(t, clone.Expires().IsZero())
+		assert.Empty(t, clone.AuthKey())
+		assert.Empty(t, clone.AuthSignature())
+	})
+
+	t.Run("new title or address changes the ID", func(t *testing.T) {
+		assert.Equal(t, original.ID(), clone.ID())
+
+		retitled := original.Clone()
+		require.NoError(t, retitled.SetTitle("Clone Test 2"))
+		assert.NotEqual(t, original.ID(), retitled.ID())
+		assert.Equal(t, retitled.CanonicalID(), retitled.ID())
+
+		readdressed := original.Clone()
+		require.NoError(t, readdressed.SetPayoutAddress("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"))
+		assert.NotEqual(t, original.ID(), readdressed.ID())
+
+		assert.Error(t, readdressed.SetPayoutAddress("mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn"))
+		assert.Error(t, retitled.SetTitle(""))
+	})
+
+	t.Run("clone is independent of the source", func(t *testing.T) {
+		id := original.ID()
+		script := append([]byte(nil), original.pb.Details.Outputs[0].Script...)
+
+		mutated := original.Clone()
+		require.NoError(t, mutated.SetTitle("Mutated"))
+		require.NoError(t, mutated.SetDescription("Changed"))
+		require.NoError(t, mutated.SetPayoutAddress("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"))
+		mutated.SetTags("other")
+
+		assert.Equal(t, "Clone Test", original.Title())
+		assert.Equal(t, "Testing clones", original.Description())
+		assert.Equal(t, []string{"garden"}, original.Tags())
+		assert.Equal(t, script, original.pb.Details.Outputs[0].Script)
+		assert.Equal(t, id, original.ID())
+		assert.NoError(t, original.VerifyAuthSignature(original.AuthSignature()))
+	})
+}
+
+func TestProjectTags(t *testing.T) {
+	project, err := NewProject("Tag Test", "Testing tags", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	id := project.ID()
+
+	project.SetTags(" Open Source ", "games", "", "open source")
+	project.SetCategory("Software")
+	assert.Equal(t, []string{"Open Source", "games"}, project.Tags())
+	assert.True(t, project.HasTag("open source"))
+	assert.False(t, project.HasTag("music"))
+	assert.Equal(t, "Software", project.Category())
+	assert.Equal(t, id, project.ID())
+
+	data, err := project.Serialize()
+	require.NoError(t, err)
+	loaded, err := LoadProject(data)
+	require.NoError(t, err)
+	assert.Equal(t, project.Tags(), loaded.Tags())
+	assert.Equal(t, "Software", loaded.Category())
+
+	project.SetTags()
+	assert.Empty(t, project.Tags())
+}
+
+func TestProjectMilestones(t *testing.T) {
+	project, err := NewProject("Milestone Test", "Testing milestones", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	id := project.ID()
+	assert.Empty(t, project.Milestones())
+
+	require.NoError(t, project.SetMilestones(
+		Milestone{Threshold: 200000000, Description: " Soundtrack "},
+		Milestone{Threshold: 150000000, Description: "Extra levels"},
+	))
+	want := []Milestone{
+		{Threshold: 150000000, Description: "Extra levels"},
+		{Threshold: 200000000, Description: "Soundtrack"},
+	}
+	assert.Equal(t, want, project.Milestones())
+	assert.Equal(t, id, project.ID())
+
+	data, err := project.Serialize()
+	require.NoError(t, err)
+	loaded, err := LoadProject(data)
+	require.NoError(t, err)
+	assert.Equal(t, want, loaded.Milestones())
+
+	t.Run("rejects invalid milestones", func(t *testing.T) {
+		assert.Error(t, project.SetMilestones(Milestone{Threshold: 0, Description: "Nothing"}))
+		assert.Error(t, project.SetMilestones(Milestone{Threshold: 150000000, Description: "  "}))
+		assert.Error(t, project.SetMilestones(
+			Milestone{Threshold: 150000000, Description: "One"},
+			Milestone{Threshold: 150000000, Description: "Two"},
+		))
+		assert.Equal(t, want, project.Milestones())
+	})
+
+	require.NoError(t, project.SetMilestones())
+	assert.Empty(t, project.Milestones())
+}
+
+func TestProjectLinks(t *testing.T) {
+	project, err := NewProject("Link Test", "Testing links", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	id := project.ID()
+	assert.Empty(t, project.Links())
+
+	t.Run("valid links", func(t *testing.T) {
+		require.NoError(t, project.AddLink(" Website ", "https://example.com"))
+		require.NoError(t, project.AddLink("Blog", "HTTP://blog.example.com/posts?tag=garden"))
+		want := []Link{
+			{Label: "Website", URL: "https://example.com"},
+			{Label: "Blog", URL: "HTTP://blog.example.com/posts?tag=garden"},
+		}
+		assert.Equal(t, want, project.Links())
+		assert.Equal(t, id, project.ID())
+		assert.Equal(t, want, project.JSON().Links)
+
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.Equal(t, want, loaded.Links())
+	})
+
+	t.Run("rejected links", func(t *testing.T) {
+		for _, tc := range []struct{ label, url string }{
+			{"XSS", "javascript:alert(1)"},
+			{"XSS", "JavaScript:alert(document.cookie)"},
+			{"XSS", " javascript:alert(1)"},
+			{"Data", "data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg=="},
+			{"FTP", "ftp://example.com/file"},
+			{"Relative", "//example.com"},
+			{"No host", "https://"},
+			{"Newline", "https://example.com/\njavascript:alert(1)"},
+			{"", "https://example.com"},
+		} {
+			err := project.AddLink(tc.label, tc.url)
+			assert.ErrorIs(t, err, ErrInvalidLink, "%s %q", tc.label, tc.url)
+		}
+		assert.Len(t, project.Links(), 2)
+	})
+
+	t.Run("hand-crafted links are filtered", func(t *testing.T) {
+		project.pb.Extra.Links = append(project.pb.Extra.Links, &pb.Link{Label: "Evil", Url: "javascript:alert(1)"})
+		assert.Len(t, project.Links(), 2)
+		project.pb.Extra.Links = project.pb.Extra.Links[:2]
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		for len(project.Links()) < MaxLinks {
+			require.NoError(t, project.AddLink("More", "https://example.com/more"))
+		}
+		assert.ErrorIs(t, project.AddLink("One too many", "https://example.com"), ErrInvalidLink)
+	})
+}
+
 func TestProjectOutputs(t *testing.T) {
 	project, err := NewProject(
 		"Output Test",
 		"Testing outputs",
 		150000000, // 1.5 BSV
-		"1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
 	)
 	require.NoError(t, err)
 
@@ -97,12 +550,71 @@ func TestProjectOutputs(t *testing.T) {
 	assert.Greater(t, len(*output.LockingScript), 0)
 }
 
+func TestProjectAddDataOutput(t *testing.T) {
+	newProject := func() *Project {
+		project, err := NewProject("Data Test", "Testing data outputs", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+		require.NoError(t, err)
+		return project
+	}
+
+	t.Run("well-formed OP_RETURN script", func(t *testing.T) {
+		project := newProject()
+		id := project.ID()
+		hash := sha256.Sum256([]byte("campaign manifest"))
+		require.NoError(t, project.AddDataOutput(hash[:]))
+
+		outputs, err := project.Outputs()
+		require.NoError(t, err)
+		require.Len(t, outputs, 2)
+		data := outputs[1]
+		assert.Equal(t, uint64(0), data.Satoshis)
+		assert.True(t, data.LockingScript.IsData())
+		assert.Equal(t, append([]byte{script.OpFALSE, script.OpRETURN, byte(len(hash))}, hash[:]...), data.LockingScript.Bytes())
+
+		// Outputs are identifying, but the goal is unchanged
+		assert.NotEqual(t, id, project.ID())
+		assert.Equal(t, uint64(100000000), project.GoalAmount())
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		project := newProject()
+		assert.ErrorIs(t, project.AddDataOutput(make([]byte, MaxDataOutputSize+1)), ErrDataOutputTooLarge)
+		assert.Error(t, project.AddDataOutput(nil))
+
+		// Over 75 bytes needs OP_PUSHDATA1
+		data := []byte(strings.Repeat("x", MaxDataOutputSize))
+		require.NoError(t, project.AddDataOutput(data))
+		outputs, err := project.Outputs()
+		require.NoError(t, err)
+		want := append([]byte{script.OpFALSE, script.OpRETURN, script.OpPUSHDATA1, byte(len(data))}, data...)
+		assert.Equal(t, want, outputs[1].LockingScript.Bytes())
+	})
+
+	t.Run("one data output", func(t *testing.T) {
+		project := newProject()
+		require.NoError(t, project.AddDataOutput([]byte("first")))
+		assert.Error(t, project.AddDataOutput([]byte("second")))
+	})
+
+	t.Run("survives serialization", func(t *testing.T) {
+		project := newProject()
+		require.NoError(t, project.AddDataOutput([]byte("anchored")))
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.Equal(t, project.ID(), loaded.ID())
+		assert.Equal(t, project.GoalAmount(), loaded.GoalAmount())
+	})
+}
+
 func TestProjectCoverImage(t *testing.T) {
 	project, err := NewProject(
 		"Image Test",
 		"Testing cover image",
 		100000000,
-		"1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
 	)
 	require.NoError(t, err)
 
@@ -116,14 +628,526 @@ func TestProjectCoverImage(t *testing.T) {
 	err = project.SetCoverImage(pngData)
 	assert.NoError(t, err)
 
+	// Test GIF header
+	gifData := []byte("GIF89a\x01\x00\x01\x00")
+	err = project.SetCoverImage(gifData)
+	assert.NoError(t, err)
+
+	// Test WebP header
+	webpData := []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+	err = project.SetCoverImage(webpData)
+	assert.NoError(t, err)
+
 	// Test invalid image data
 	invalidData := []byte{0x00, 0x01, 0x02, 0x03}
 	err = project.SetCoverImage(invalidData)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "image must be JPEG or PNG format")
+	assert.Contains(t, err.Error(), "image must be JPEG, PNG, GIF or WebP format")
 
 	// Test too small data
 	err = project.SetCoverImage([]byte{0xFF})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid image data")
-}
\ No newline at end of file
+
+	// Test oversized image
+	oversized := make([]byte, MaxCoverImageSize+1)
+	copy(oversized, pngData)
+	err = project.SetCoverImage(oversized)
+	assert.ErrorIs(t, err, ErrCoverImageTooLarge)
+
+	// The last accepted image is kept
+	data, mimeType, err := project.CoverImage()
+	require.NoError(t, err)
+	assert.Equal(t, webpData, data)
+	assert.Equal(t, "image/webp", mimeType)
+}
+
+func TestProjectCoverImageType(t *testing.T) {
+	project, err := NewProject("Image Test", "Testing cover image", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+
+	_, _, err = project.CoverImage()
+	assert.ErrorIs(t, err, ErrNoCoverImage)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		mimeType string
+	}{
+		{"jpeg", []byte{0xFF, 0xD8, 0xFF, 0xE0}, "image/jpeg"},
+		{"png", []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A}, "image/png"},
+		{"gif87a", []byte("GIF87a\x00\x00"), "image/gif"},
+		{"webp", []byte("RIFF\x00\x00\x00\x00WEBP"), "image/webp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, project.SetCoverImage(tt.data))
+			_, mimeType, err := project.CoverImage()
+			require.NoError(t, err)
+			assert.Equal(t, tt.mimeType, mimeType)
+		})
+	}
+}
+
+func TestProjectCoverImageURL(t *testing.T) {
+	project, err := NewProject("Image URL Test", "Testing hosted cover image", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	originalID := project.ID()
+
+	t.Run("valid URLs", func(t *testing.T) {
+		for _, u := range []string{
+			"https://example.org/cover.png",
+			"http://example.org/cover.jpg?size=large",
+			"ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+		} {
+			require.NoError(t, project.SetCoverImageURL(u), u)
+			assert.Equal(t, u, project.CoverImageRef())
+		}
+		assert.Equal(t, originalID, project.ID())
+	})
+
+	t.Run("invalid URLs", func(t *testing.T) {
+		for _, u := range []string{
+			"example.org/cover.png",
+			"/cover.png",
+			"ftp://example.org/cover.png",
+			"javascript:alert(1)",
+			"https:///cover.png",
+			"https://example.org/\ncover.png",
+		} {
+			assert.ErrorIs(t, project.SetCoverImageURL(u), ErrInvalidCoverImageURL, u)
+		}
+	})
+
+	t.Run("survives roundtrip and shows in JSON", func(t *testing.T) {
+		require.NoError(t, project.SetCoverImageURL("https://example.org/cover.png"))
+		data, err := project.Serialize()
+		require.NoError(t, err)
+
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.org/cover.png", loaded.CoverImageRef())
+		assert.Equal(t, "https://example.org/cover.png", loaded.JSON().CoverURL)
+		assert.False(t, loaded.JSON().HasCover)
+	})
+
+	t.Run("inline and URL are exclusive", func(t *testing.T) {
+		image := []byte{0xFF, 0xD8, 0xFF, 0xE0}
+		assert.ErrorIs(t, project.SetCoverImage(image), ErrCoverImageConflict)
+		assert.False(t, project.HasCoverImage())
+
+		require.NoError(t, project.SetCoverImageURL(""))
+		assert.Empty(t, project.CoverImageRef())
+		require.NoError(t, project.SetCoverImage(image))
+		assert.ErrorIs(t, project.SetCoverImageURL("https://example.org/cover.png"), ErrCoverImageConflict)
+		assert.Empty(t, project.CoverImageRef())
+	})
+
+	t.Run("project with both is rejected", func(t *testing.T) {
+		both := proto.Clone(project.pb).(*pb.Project)
+		both.Extra.CoverImageUrl = "https://example.org/cover.png"
+		data, err := proto.Marshal(both)
+		require.NoError(t, err)
+
+		_, err = LoadProject(data)
+		assert.ErrorIs(t, err, ErrInvalidProject)
+	})
+}
+
+func TestProjectMinPledgeAmount(t *testing.T) {
+	project, err := NewProject(
+		"Min Pledge Test",
+		"Testing minimum pledge",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	t.Run("custom minimum survives roundtrip", func(t *testing.T) {
+		originalID := project.ID()
+		err := project.SetMinPledgeAmount(500000)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(500000), project.MinPledgeAmount())
+		assert.Equal(t, originalID, project.ID())
+
+		data, err := project.Serialize()
+		require.NoError(t, err)
+
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(500000), loaded.MinPledgeAmount())
+		assert.Equal(t, project.ID(), loaded.ID())
+	})
+
+	t.Run("zero minimum", func(t *testing.T) {
+		err := project.SetMinPledgeAmount(0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must be greater than 0")
+	})
+
+	t.Run("minimum above goal", func(t *testing.T) {
+		err := project.SetMinPledgeAmount(200000000)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds goal amount")
+		assert.Equal(t, uint64(500000), project.MinPledgeAmount())
+	})
+}
+
+func TestProjectMinPledgePercent(t *testing.T) {
+	project, err := NewProject(
+		"Min Pledge Percent Test",
+		"Testing percentage minimum pledge",
+		123456789,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		pct  float64
+		want uint64
+	}{
+		{1, 1234568},
+		{0.1, 123457},
+		{12.5, 15432099},
+		{50, 61728395},
+		{100, 123456789},
+		{0.0000001, 1},
+	}
+	for _, tt := range tests {
+		require.NoError(t, project.SetMinPledgePercent(tt.pct), "pct %v", tt.pct)
+		assert.Equal(t, tt.want, project.MinPledgeAmount(), "pct %v", tt.pct)
+	}
+
+	t.Run("survives roundtrip", func(t *testing.T) {
+		require.NoError(t, project.SetMinPledgePercent(10))
+		data, err := project.Serialize()
+		require.NoError(t, err)
+
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(12345679), loaded.MinPledgeAmount())
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		for _, pct := range []float64{0, -5, 100.01, math.NaN(), math.Inf(1)} {
+			err := project.SetMinPledgePercent(pct)
+			assert.Error(t, err, "pct %v", pct)
+		}
+		assert.Equal(t, uint64(12345679), project.MinPledgeAmount())
+	})
+}
+
+func TestProjectStatus(t *testing.T) {
+	project, err := NewProject("Status Test", "Testing project status", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	originalID := project.ID()
+
+	assert.Equal(t, ProjectActive, project.Status())
+	assert.NoError(t, project.CheckAcceptingPledges())
+	assert.Equal(t, "active", project.JSON().Status)
+
+	for _, status := range []ProjectStatus{ProjectPaused, ProjectCancelled, ProjectActive} {
+		project.SetStatus(status)
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.Equal(t, status, loaded.Status())
+		assert.Equal(t, originalID, loaded.ID())
+
+		parsed, err := ParseProjectStatus(status.String())
+		require.NoError(t, err)
+		assert.Equal(t, status, parsed)
+	}
+
+	project.SetStatus(ProjectPaused)
+	assert.ErrorIs(t, project.CheckAcceptingPledges(), ErrProjectClosed)
+	assert.Equal(t, "paused", project.JSON().Status)
+
+	parsed, err := ParseProjectStatus("Cancelled")
+	require.NoError(t, err)
+	assert.Equal(t, ProjectCancelled, parsed)
+	_, err = ParseProjectStatus("closed")
+	assert.Error(t, err)
+}
+
+func TestProjectExpiry(t *testing.T) {
+	project, err := NewProject(
+		"Expiry Test",
+		"Testing expiry",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	// No expiry by default
+	assert.True(t, project.Expires().IsZero())
+	assert.False(t, project.IsExpired())
+
+	t.Run("future expiry", func(t *testing.T) {
+		expires := time.Now().Add(7 * 24 * time.Hour)
+		project.SetExpiry(expires)
+		assert.False(t, project.IsExpired())
+		assert.Equal(t, expires.Unix(), project.Expires().Unix())
+	})
+
+	t.Run("past expiry survives roundtrip", func(t *testing.T) {
+		project.SetExpiry(time.Now().Add(-time.Hour))
+		assert.True(t, project.IsExpired())
+
+		data, err := project.Serialize()
+		require.NoError(t, err)
+
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.True(t, loaded.IsExpired())
+		assert.Equal(t, project.Expires().Unix(), loaded.Expires().Unix())
+	})
+}
+
+func TestProjectMarshalJSON(t *testing.T) {
+	project, err := NewProjectWithOutputs("JSON Test", "Testing the JSON form", []ProjectOutput{
+		{Address: "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", Amount: 80000000},
+		{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 20000000},
+	})
+	require.NoError(t, err)
+	require.NoError(t, project.SetMinPledgeAmount(50000))
+	project.SetExpiry(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC))
+	project.SetCategory("Software")
+	project.SetTags("games")
+	require.NoError(t, project.SetMilestones(Milestone{Threshold: 150000000, Description: "Soundtrack"}))
+
+	data, err := json.Marshal(project)
+	require.NoError(t, err)
+
+	// Frontends depend on these names
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"id", "title", "description", "network", "goal", "goalBsv", "minPledge", "expires",
+		"isExpired", "status", "outputs", "hasCoverImage", "category", "tags", "milestones",
+	}, names)
+
+	assert.JSONEq(t, `"2030-01-02T03:04:05Z"`, string(fields["expires"]))
+	assert.JSONEq(t, `"1.00000000"`, string(fields["goalBsv"]))
+	assert.JSONEq(t, `100000000`, string(fields["goal"]))
+	assert.JSONEq(t, `false`, string(fields["hasCoverImage"]))
+	assert.JSONEq(t, `"active"`, string(fields["status"]))
+	// Addresses are re-encoded from the output scripts
+	outputs, err := project.Outputs()
+	require.NoError(t, err)
+	payout, err := outputAddress(*outputs[0].LockingScript, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`[
+		{"address": %q, "amount": 80000000},
+		{"address": "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", "amount": 20000000}
+	]`, payout), string(fields["outputs"]))
+	assert.JSONEq(t, `[{"threshold": 150000000, "description": "Soundtrack"}]`, string(fields["milestones"]))
+
+	t.Run("optional fields are omitted", func(t *testing.T) {
+		project, err := NewProject("Bare", "No extras", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+		require.NoError(t, err)
+		data, err := json.Marshal(project)
+		require.NoError(t, err)
+
+		var fields map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(data, &fields))
+		for _, name := range []string{"expires", "category", "tags", "milestones"} {
+			assert.NotContains(t, fields, name)
+		}
+		assert.NotContains(t, string(data), "coverImage\"")
+	})
+}
+
+func TestProjectOutputAddresses(t *testing.T) {
+	project, err := NewProjectWithOutputs("Address Test", "Testing output addresses", []ProjectOutput{
+		{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 60000000},
+		{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", Amount: 40000000},
+	})
+	require.NoError(t, err)
+
+	// A bare OP_RETURN output has no address
+	project.pb.Details.Outputs[1].Script = []byte{0x6a, 0x01, 0x00}
+
+	addresses, err := project.OutputAddresses()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", ""}, addresses)
+
+	testnet, err := NewProject("Testnet Address Test", "Testing testnet addresses", 100000000, "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r", NetworkTestnet)
+	require.NoError(t, err)
+	addresses, err = testnet.OutputAddresses()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r"}, addresses)
+}
+
+func TestProjectURI(t *testing.T) {
+	project, err := NewProject(
+		"URI Test",
+		"Testing share URIs",
+		100000000,
+		"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	uri := project.URI()
+	assert.True(t, strings.HasPrefix(uri, "lighthouse:"+project.ID()+"?"))
+	assert.Contains(t, uri, "goal=100000000")
+	assert.Contains(t, uri, "address=1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH")
+
+	// The URI references the project rather than embedding the cover image
+	cover := append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, make([]byte, 4096)...)
+	require.NoError(t, project.SetCoverImage(cover))
+	assert.Less(t, len(project.URI()), 256)
+}
+
+func TestProjectAuthSignature(t *testing.T) {
+	newProject := func() *Project {
+		project, err := NewProject("Auth Test", "Testing auth signatures", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+		require.NoError(t, err)
+		return project
+	}
+
+	authKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	t.Run("signed message verifies", func(t *testing.T) {
+		project := newProject()
+		project.SetAuthKey(authKey.PubKey().Compressed())
+
+		sig, err := project.SignAuthMessage(authKey, []byte("challenge"))
+		require.NoError(t, err)
+		assert.NoError(t, project.VerifyAuthMessage([]byte("challenge"), sig))
+		assert.ErrorIs(t, project.VerifyAuthMessage([]byte("other challenge"), sig), ErrInvalidAuthSignature)
+	})
+
+	t.Run("signed update verifies", func(t *testing.T) {
+		project := newProject()
+		project.SetAuthKey(authKey.PubKey().Compressed())
+		require.NoError(t, project.SetDescription("Updated description"))
+
+		oldID := project.ID()
+		require.NoError(t, project.SignAuth(authKey))
+		assert.Equal(t, oldID, project.ID())
+		assert.NoError(t, project.VerifyAuthSignature(project.AuthSignature()))
+
+		// The signature survives serialization
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		loaded, err := LoadProject(data)
+		require.NoError(t, err)
+		assert.NoError(t, loaded.VerifyAuthSignature(loaded.AuthSignature()))
+	})
+
+	t.Run("changes after signing invalidate the signature", func(t *testing.T) {
+		project := newProject()
+		project.SetAuthKey(authKey.PubKey().Compressed())
+		require.NoError(t, project.SignAuth(authKey))
+
+		require.NoError(t, project.SetDescription("Tampered description"))
+		assert.ErrorIs(t, project.VerifyAuthSignature(project.AuthSignature()), ErrInvalidAuthSignature)
+	})
+
+	t.Run("wrong key cannot sign", func(t *testing.T) {
+		project := newProject()
+		project.SetAuthKey(authKey.PubKey().Compressed())
+
+		otherKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		assert.Error(t, project.SignAuth(otherKey))
+		assert.Empty(t, project.AuthSignature())
+	})
+
+	t.Run("project without auth key", func(t *testing.T) {
+		project := newProject()
+		assert.ErrorIs(t, project.SignAuth(authKey), ErrNoAuthKey)
+		_, err := project.SignAuthMessage(authKey, []byte("challenge"))
+		assert.ErrorIs(t, err, ErrNoAuthKey)
+		assert.ErrorIs(t, project.VerifyAuthSignature([]byte{0x30}), ErrNoAuthKey)
+	})
+}
+
+func TestProjectExportSigned(t *testing.T) {
+	authKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	project, err := NewProject("Signed Test", "Testing signed bundles", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+	require.NoError(t, err)
+	project.SetAuthKey(authKey.PubKey().Compressed())
+
+	signed, err := project.ExportSigned(authKey)
+	require.NoError(t, err)
+
+	// resign re-marshals a bundle after edit changes it
+	resign := func(edit func(bundle *pb.SignedProject)) []byte {
+		var bundle pb.SignedProject
+		require.NoError(t, proto.Unmarshal(signed, &bundle))
+		edit(&bundle)
+		data, err := proto.Marshal(&bundle)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		loaded, err := LoadSignedProject(signed)
+		require.NoError(t, err)
+		assert.Equal(t, project.ID(), loaded.ID())
+		assert.Equal(t, project.Title(), loaded.Title())
+	})
+
+	t.Run("wrong key cannot export", func(t *testing.T) {
+		otherKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = project.ExportSigned(otherKey)
+		assert.Error(t, err)
+	})
+
+	t.Run("project without auth key", func(t *testing.T) {
+		unkeyed, err := NewProject("Unkeyed", "No auth key", 100000000, "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6", NetworkMainnet)
+		require.NoError(t, err)
+		_, err = unkeyed.ExportSigned(authKey)
+		assert.ErrorIs(t, err, ErrNoAuthKey)
+	})
+
+	t.Run("tampered project", func(t *testing.T) {
+		data := resign(func(bundle *pb.SignedProject) {
+			inner, err := LoadProject(bundle.Project)
+			require.NoError(t, err)
+			require.NoError(t, inner.SetDescription("Tampered description"))
+			bundle.Project, err = inner.Serialize()
+			require.NoError(t, err)
+		})
+		_, err := LoadSignedProject(data)
+		assert.ErrorIs(t, err, ErrInvalidAuthSignature)
+	})
+
+	t.Run("signature from a different key", func(t *testing.T) {
+		otherKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		// Signed correctly, but by a key the project doesn't name
+		data := resign(func(bundle *pb.SignedProject) {
+			signer, err := LoadProject(bundle.Project)
+			require.NoError(t, err)
+			signer.SetAuthKey(otherKey.PubKey().Compressed())
+			bundle.Signature, err = signer.SignAuthMessage(otherKey, append([]byte(signedProjectDomain), bundle.Project...))
+			require.NoError(t, err)
+			bundle.AuthKey = otherKey.PubKey().Compressed()
+		})
+		_, err = LoadSignedProject(data)
+		assert.ErrorIs(t, err, ErrInvalidAuthSignature)
+	})
+
+	t.Run("plain project file", func(t *testing.T) {
+		data, err := project.Serialize()
+		require.NoError(t, err)
+		_, err = LoadSignedProject(data)
+		assert.ErrorIs(t, err, ErrNotSignedProject)
+	})
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+)
+
+// BuildRefunds creates one unsigned transaction per pledge that spends the
+// pledge's inputs back to its refund address, or to the address of its first
+// P2PKH input when none was given. The pledger still has to sign the refund, since
+// only they hold the input keys. A pledge with change spends an output of its
+// change transaction, which only exists once that is broadcast for the claim,
+// so its refund spends the change transaction's inputs instead: the change
+// comes back to the refund address too. It fails if the contract can be
+// claimed.
+func (c *Contract) BuildRefunds() ([]*transaction.Transaction, error) {
+	if c.CanClaim() {
+		return nil, errors.New("contract has reached its goal and can be claimed")
+	}
+
+	mainnet := c.project.Network() != NetworkTestnet
+
+	var refunds []*transaction.Transaction
+	for _, pledge := range c.pledges {
+		refund, err := c.buildRefund(pledge, mainnet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build refund for pledge %s: %w", pledge.ID(), err)
+		}
+		refunds = append(refunds, refund)
+	}
+
+	return refunds, nil
+}
+
+// buildRefund creates the refund transaction for a single pledge
+func (c *Contract) buildRefund(pledge *Pledge, mainnet bool) (*transaction.Transaction, error) {
+	lockingScript, err := refundLockingScript(pledge, mainnet)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, inputTotal := refundInputs(pledge)
+	tx := transaction.NewTransaction()
+	for _, input := range inputs {
+		// Fresh inputs: the pledge signatures commit to the project outputs
+		tx.AddInput(&transaction.TransactionInput{
+			SourceTXID:       input.SourceTXID,
+			SourceTxOutIndex: input.SourceTxOutIndex,
+			SequenceNumber:   finalSequence,
+		})
+	}
+
+	output := &transaction.TransactionOutput{
+		Satoshis:      inputTotal,
+		LockingScript: lockingScript,
+	}
+	tx.AddOutput(output)
+
+	fee := EstimateFee(tx, c.feeRate)
+	if inputTotal <= fee {
+		return nil, fmt.Errorf("pledge inputs of %d satoshis do not cover fee of %d satoshis", inputTotal, fee)
+	}
+	output.Satoshis = inputTotal - fee
+
+	return tx, nil
+}
+
+// refundInputs returns the inputs a pledge's refund spends and their total
+// value: the pledge's own inputs, or its change transaction's when it has one
+func refundInputs(pledge *Pledge) ([]*transaction.TransactionInput, uint64) {
+	changeTx := pledge.ChangeTransaction()
+	if changeTx == nil {
+		return pledge.Transaction().Inputs, pledge.InputTotal()
+	}
+
+	total := uint64(0)
+	for _, input := range changeTx.Inputs {
+		source := input.SourceTxOutput()
+		if source == nil {
+			// A reloaded change transaction doesn't carry its input values,
+			// so fall back to its outputs and leave its fee to the refund's
+			total = 0
+			for _, output := range changeTx.Outputs {
+				total += output.Satoshis
+			}
+			break
+		}
+		total += source.Satoshis
+	}
+	return changeTx.Inputs, total
+}
+
+// refundLockingScript returns the script refunds for a pledge are paid to
+func refundLockingScript(pledge *Pledge, mainnet bool) (*script.Script, error) {
+	var address *script.Address
+	var err error
+	if refund := pledge.RefundAddress(); refund != "" {
+		// A pledge file may be hand-edited, so check it as SetRefundAddress does
+		if _, err := DetectNetwork(refund); err != nil {
+			return nil, fmt.Errorf("invalid refund address: %w", err)
+		}
+		address, err = script.NewAddressFromString(refund)
+		if err != nil {
+			return nil, fmt.Errorf("invalid refund address: %w", err)
+		}
+	} else {
+		address, err = defaultRefundAddress(pledge, mainnet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p2pkh.Lock(address)
+}
+
+// defaultRefundAddress derives a refund address from the first of the
+// inputs the refund spends that reveals its public key
+func defaultRefundAddress(pledge *Pledge, mainnet bool) (*script.Address, error) {
+	inputs, _ := refundInputs(pledge)
+	if len(inputs) == 0 {
+		return nil, errors.New("no refund address and pledge has no inputs")
+	}
+
+	var lastErr error
+	for _, input := range inputs {
+		address, err := inputAddress(input, mainnet)
+		if err == nil {
+			return address, nil
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("no refund address and cannot derive one from inputs: %w", lastErr)
+}
+
+// inputAddress recovers the address spent by a signed P2PKH input from the
+// public key in its unlocking script
+func inputAddress(input *transaction.TransactionInput, mainnet bool) (*script.Address, error) {
+	if input.UnlockingScript == nil {
+		return nil, errors.New("input is not signed")
+	}
+
+	// <signature> <public key>
+	chunks, err := input.UnlockingScript.Chunks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse unlocking script: %w", err)
+	}
+	if len(chunks) != 2 {
+		return nil, errors.New("unlocking script is not P2PKH")
+	}
+
+	pubKey, err := ec.ParsePubKey(chunks[1].Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return script.NewAddressFromPublicKey(pubKey, mainnet)
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractBuildRefunds(t *testing.T) {
+	project, err := NewProject(
+		"Refund Test",
+		"Testing refunds",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	t.Run("refunds go to refund address or input address", func(t *testing.T) {
+		refundAddress := "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"
+		withRefund := createSignedTestPledge(t, project, 30000000)
+		require.NoError(t, withRefund.SetRefundAddress(refundAddress))
+
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		withoutRefund, err := NewPledge(project, 20000000, withFeeAllowance(t, project, createTestKeyUTXOs(t, privKey, 20000000)))
+		require.NoError(t, err)
+		require.NoError(t, withoutRefund.Sign([]*ec.PrivateKey{privKey}))
+
+		contract := NewContract(project)
+		require.NoError(t, contract.AddPledge(withRefund))
+		require.NoError(t, contract.AddPledge(withoutRefund))
+
+		refunds, err := contract.BuildRefunds()
+		require.NoError(t, err)
+		require.Len(t, refunds, 2)
+
+		addr, err := script.NewAddressFromString(refundAddress)
+		require.NoError(t, err)
+		expected, err := p2pkh.Lock(addr)
+		require.NoError(t, err)
+		require.Len(t, refunds[0].Outputs, 1)
+		assert.Equal(t, expected.Bytes(), refunds[0].Outputs[0].LockingScript.Bytes())
+
+		pledgerAddr, err := script.NewAddressFromPublicKey(privKey.PubKey(), true)
+		require.NoError(t, err)
+		expected, err = p2pkh.Lock(pledgerAddr)
+		require.NoError(t, err)
+		require.Len(t, refunds[1].Outputs, 1)
+		assert.Equal(t, expected.Bytes(), refunds[1].Outputs[0].LockingScript.Bytes())
+
+		for i, pledge := range []*Pledge{withRefund, withoutRefund} {
+			fee := EstimateFee(refunds[i], DefaultFeeRate)
+			assert.Equal(t, pledge.InputTotal()-fee, refunds[i].Outputs[0].Satoshis)
+
+			require.Len(t, refunds[i].Inputs, len(pledge.Transaction().Inputs))
+			input := refunds[i].Inputs[0]
+			assert.Equal(t, pledge.Transaction().Inputs[0].SourceTXID.String(), input.SourceTXID.String())
+			assert.Nil(t, input.UnlockingScript)
+		}
+	})
+
+	t.Run("pledge with change refunds the change inputs", func(t *testing.T) {
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		address, err := script.NewAddressFromPublicKey(privKey.PubKey(), true)
+		require.NoError(t, err)
+		pledge, err := NewPledgeWithChange(project, 50000000, createTestKeyUTXOs(t, privKey, 100000000), address.AddressString, DefaultFeeRate)
+		require.NoError(t, err)
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{privKey}))
+
+		data, err := pledge.Serialize()
+		require.NoError(t, err)
+		reloaded, err := LoadPledge(data)
+		require.NoError(t, err)
+
+		changeTx := pledge.ChangeTransaction()
+		changeOutputs := uint64(0)
+		for _, output := range changeTx.Outputs {
+			changeOutputs += output.Satoshis
+		}
+
+		for name, tc := range map[string]struct {
+			pledge     *Pledge
+			inputTotal uint64
+		}{
+			"created":  {pledge, 100000000},
+			"reloaded": {reloaded, changeOutputs},
+		} {
+			contract := NewContract(project)
+			require.NoError(t, contract.AddPledge(tc.pledge))
+
+			refunds, err := contract.BuildRefunds()
+			require.NoError(t, err, name)
+			require.Len(t, refunds, 1, name)
+			refund := refunds[0]
+
+			// The pledge's own input spends the unbroadcast change output
+			require.Len(t, refund.Inputs, len(changeTx.Inputs), name)
+			assert.Equal(t, changeTx.Inputs[0].SourceTXID.String(), refund.Inputs[0].SourceTXID.String(), name)
+			assert.Equal(t, changeTx.Inputs[0].SourceTxOutIndex, refund.Inputs[0].SourceTxOutIndex, name)
+
+			expected, err := p2pkh.Lock(address)
+			require.NoError(t, err)
+			require.Len(t, refund.Outputs, 1, name)
+			assert.Equal(t, expected.Bytes(), refund.Outputs[0].LockingScript.Bytes(), name)
+			assert.Equal(t, tc.inputTotal-EstimateFee(refund, DefaultFeeRate), refund.Outputs[0].Satoshis, name)
+		}
+	})
+
+	t.Run("bad refund address", func(t *testing.T) {
+		pledge := createSignedTestPledge(t, project, 30000000)
+		id := pledge.ID()
+
+		err := pledge.SetRefundAddress("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMh")
+		assert.ErrorIs(t, err, ErrInvalidRefundAddress)
+
+		// Testnet address on a mainnet pledge
+		err = pledge.SetRefundAddress("mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn")
+		assert.ErrorIs(t, err, ErrInvalidRefundAddress)
+
+		assert.Empty(t, pledge.RefundAddress())
+		assert.Equal(t, id, pledge.ID())
+	})
+
+	t.Run("refund address derived from inputs", func(t *testing.T) {
+		unsignedKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		signedKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		utxos := withFeeAllowance(t, project, append(createTestKeyUTXOs(t, unsignedKey, 10000000), createTestKeyUTXOs(t, signedKey, 10000000)...))
+
+		pledge, err := NewPledge(project, 20000000, utxos)
+		require.NoError(t, err)
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{unsignedKey, signedKey}))
+
+		// Skips inputs that don't reveal a public key
+		pledge.Transaction().Inputs[0].UnlockingScript = nil
+		address, err := defaultRefundAddress(pledge, true)
+		require.NoError(t, err)
+		expected, err := script.NewAddressFromPublicKey(signedKey.PubKey(), true)
+		require.NoError(t, err)
+		assert.Equal(t, expected.AddressString, address.AddressString)
+
+		pledge.Transaction().Inputs[1].UnlockingScript = nil
+		_, err = defaultRefundAddress(pledge, true)
+		assert.Error(t, err)
+	})
+
+	t.Run("claimable contract", func(t *testing.T) {
+		contract := NewContract(project)
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 100000000)))
+
+		_, err := contract.BuildRefunds()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,227 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SelectPledgesForGoal picks a subset of the contract's pledges that meets
+// the goal with as little overshoot as a greedy search finds. Pledges are
+// indivisible, so when the contract is over-funded this leaves out pledges
+// that aren't needed rather than collecting more than the goal.
+//
+// The result keeps the order pledges were added in.
+func (c *Contract) SelectPledgesForGoal() ([]*Pledge, error) {
+	goal := c.project.GoalAmount()
+	if c.TotalPledged() < goal {
+		return nil, fmt.Errorf("funding goal not reached: %d/%d", c.TotalPledged(), goal)
+	}
+
+	sorted := sortedByAmount(c.pledges)
+
+	// Greedy filling can get stuck behind a large pledge, so retry without
+	// the largest pledges and keep the best result
+	var chosen map[*Pledge]bool
+	remaining := c.TotalPledged()
+	for start := range sorted {
+		if remaining < goal {
+			break
+		}
+		candidate := fillToGoal(sorted[start:], goal)
+		if chosen == nil || sumAmounts(candidate) < sumAmounts(chosen) {
+			chosen = candidate
+		}
+		remaining -= sorted[start].Amount()
+	}
+
+	// A single pledge covering the goal on its own may overshoot less
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if sorted[i].Amount() >= goal {
+			if sorted[i].Amount() < sumAmounts(chosen) {
+				chosen = map[*Pledge]bool{sorted[i]: true}
+			}
+			break
+		}
+	}
+
+	return c.inContractOrder(chosen), nil
+}
+
+// SelectMinimalPledges picks the pledges that meet the goal with the fewest
+// transaction inputs, keeping the claim transaction and its fee small.
+// Among selections with that many inputs it prefers less overshoot. Ties
+// go to larger pledges, then lower IDs, so the result is deterministic.
+//
+// The result keeps the order pledges were added in.
+func (c *Contract) SelectMinimalPledges() ([]*Pledge, error) {
+	goal := c.project.GoalAmount()
+	if c.TotalPledged() < goal {
+		return nil, fmt.Errorf("funding goal not reached: %d/%d", c.TotalPledged(), goal)
+	}
+
+	sorted := sortedByAmount(c.pledges)
+	costs := make([]int, len(sorted))
+	for i, pledge := range sorted {
+		costs[i] = inputCost(pledge)
+	}
+
+	// The largest pledges alone reach the goal, so the fewest inputs that
+	// can is at most what they cost. Capping the table there keeps it the
+	// size of a claim rather than of every input in the contract.
+	maxCost := 0
+	for i, reached := 0, uint64(0); reached < goal; i++ {
+		reached += sorted[i].Amount()
+		maxCost += costs[i]
+	}
+
+	// 0/1 knapsack over input count: best[k] is the largest total reachable
+	// with at most k inputs, and took[i][k] records whether pledge i was
+	// used to reach it
+	best := make([]uint64, maxCost+1)
+	took := make([][]bool, len(sorted))
+	for i, pledge := range sorted {
+		took[i] = make([]bool, maxCost+1)
+		for k := maxCost; k >= costs[i]; k-- {
+			if total := best[k-costs[i]] + pledge.Amount(); total > best[k] {
+				best[k] = total
+				took[i][k] = true
+			}
+		}
+	}
+
+	budget := 0
+	for best[budget] < goal {
+		budget++
+	}
+
+	chosen := make(map[*Pledge]bool)
+	for i, k := len(sorted)-1, budget; i >= 0; i-- {
+		if took[i][k] {
+			chosen[sorted[i]] = true
+			k -= costs[i]
+		}
+	}
+
+	// The knapsack maximizes the total, so make the swap that cuts the
+	// overshoot most, without adding inputs, until none helps
+	sum := sumAmounts(chosen)
+	for {
+		swapOut, swapIn, bestSum := -1, -1, sum
+		for i, out := range sorted {
+			if !chosen[out] {
+				continue
+			}
+			need := uint64(0)
+			if rest := sum - out.Amount(); rest < goal {
+				need = goal - rest
+			}
+			// The smallest unchosen pledge that still meets the goal
+			for j := len(sorted) - 1; j >= 0; j-- {
+				in := sorted[j]
+				if chosen[in] || costs[j] > costs[i] || in.Amount() < need {
+					continue
+				}
+				if newSum := sum - out.Amount() + in.Amount(); newSum < bestSum {
+					swapOut, swapIn, bestSum = i, j, newSum
+				}
+				break
+			}
+		}
+		if swapOut < 0 {
+			break
+		}
+		delete(chosen, sorted[swapOut])
+		chosen[sorted[swapIn]] = true
+		sum = bestSum
+	}
+
+	return c.inContractOrder(chosen), nil
+}
+
+// inputCost is how many claim transaction inputs a pledge contributes
+func inputCost(pledge *Pledge) int {
+	if n := len(pledge.Transaction().Inputs); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// sortedByAmount returns pledges largest first, ties broken by ID so
+// selection is deterministic
+func sortedByAmount(pledges []*Pledge) []*Pledge {
+	sorted := append([]*Pledge(nil), pledges...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Amount() != sorted[j].Amount() {
+			return sorted[i].Amount() > sorted[j].Amount()
+		}
+		return sorted[i].ID() < sorted[j].ID()
+	})
+	return sorted
+}
+
+// inContractOrder lists the chosen pledges in the order they were added
+func (c *Contract) inContractOrder(chosen map[*Pledge]bool) []*Pledge {
+	var selected []*Pledge
+	for _, pledge := range c.pledges {
+		if chosen[pledge] {
+			selected = append(selected, pledge)
+		}
+	}
+	return selected
+}
+
+// fillToGoal greedily selects pledges, sorted largest first, until they
+// reach goal. The pledges must sum to at least goal.
+func fillToGoal(sorted []*Pledge, goal uint64) map[*Pledge]bool {
+	chosen := make(map[*Pledge]bool)
+	sum := uint64(0)
+
+	// Take every pledge that still fits under the goal
+	for _, pledge := range sorted {
+		if sum+pledge.Amount() <= goal {
+			chosen[pledge] = true
+			sum += pledge.Amount()
+		}
+	}
+
+	// Close the gap with the smallest pledge that does, or else the largest
+	// one left and try again
+	for sum < goal {
+		var pick *Pledge
+		for i := len(sorted) - 1; i >= 0; i-- {
+			if !chosen[sorted[i]] && sum+sorted[i].Amount() >= goal {
+				pick = sorted[i]
+				break
+			}
+		}
+		if pick == nil {
+			for _, pledge := range sorted {
+				if !chosen[pledge] {
+					pick = pledge
+					break
+				}
+			}
+		}
+		chosen[pick] = true
+		sum += pick.Amount()
+	}
+
+	// Drop pledges that are no longer needed, smallest first
+	for i := len(sorted) - 1; i >= 0; i-- {
+		pledge := sorted[i]
+		if chosen[pledge] && sum-pledge.Amount() >= goal {
+			delete(chosen, pledge)
+			sum -= pledge.Amount()
+		}
+	}
+	return chosen
+}
+
+// sumAmounts totals the amounts of a set of pledges
+func sumAmounts(pledges map[*Pledge]bool) uint64 {
+	total := uint64(0)
+	for pledge := range pledges {
+		total += pledge.Amount()
+	}
+	return total
+}
@@ -0,0 +1,200 @@
+package core
+
+import (
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectPledgesForGoal(t *testing.T) {
+	project, err := NewProject(
+		"Selection Test",
+		"Testing pledge selection",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	newContract := func(amounts ...uint64) *Contract {
+		contract := NewContract(project)
+		contract.SetFeeRate(0)
+		for _, amount := range amounts {
+			require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, amount)))
+		}
+		return contract
+	}
+	amounts := func(pledges []*Pledge) []uint64 {
+		var result []uint64
+		for _, pledge := range pledges {
+			result = append(result, pledge.Amount())
+		}
+		return result
+	}
+
+	t.Run("exact subset among many pledges", func(t *testing.T) {
+		contract := newContract(10000000, 60000000, 25000000, 50000000, 30000000, 40000000)
+		selected, err := contract.SelectPledgesForGoal()
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{60000000, 40000000}, amounts(selected))
+	})
+
+	t.Run("minimal overshoot", func(t *testing.T) {
+		// Taking the largest pledge first can't reach the goal exactly
+		contract := newContract(70000000, 45000000, 35000000, 20000000)
+		selected, err := contract.SelectPledgesForGoal()
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{45000000, 35000000, 20000000}, amounts(selected))
+
+		contract = newContract(70000000, 45000000, 40000000)
+		selected, err = contract.SelectPledgesForGoal()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(110000000), sumAmounts(pledgeSet(selected)))
+	})
+
+	t.Run("single pledge beats a larger combination", func(t *testing.T) {
+		contract := newContract(90000000, 80000000, 101000000)
+		selected, err := contract.SelectPledgesForGoal()
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{101000000}, amounts(selected))
+	})
+
+	t.Run("goal not reached", func(t *testing.T) {
+		_, err := newContract(40000000, 50000000).SelectPledgesForGoal()
+		assert.Error(t, err)
+	})
+
+	t.Run("combine spends only the selection", func(t *testing.T) {
+		contract := newContract(60000000, 30000000, 40000000)
+
+		// Spending every pledge would over-fund the contract
+		assert.True(t, contract.CanClaim())
+		assert.Nil(t, contract.ClaimedPledges())
+		tx, err := contract.Combine()
+		require.NoError(t, err)
+		assert.Len(t, tx.Inputs, 2)
+		assert.Equal(t, []uint64{60000000, 40000000}, amounts(contract.ClaimedPledges()))
+
+		contract.SetSelectPledgesForGoal(true)
+		tx, err = contract.Combine()
+		require.NoError(t, err)
+		assert.Len(t, tx.Inputs, 2)
+	})
+}
+
+func TestSelectMinimalPledges(t *testing.T) {
+	project, err := NewProject(
+		"Minimal Selection Test",
+		"Testing minimal pledge selection",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	newContract := func(amounts ...uint64) *Contract {
+		contract := NewContract(project)
+		contract.SetFeeRate(0)
+		for _, amount := range amounts {
+			require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, amount)))
+		}
+		return contract
+	}
+	amounts := func(pledges []*Pledge) []uint64 {
+		var result []uint64
+		for _, pledge := range pledges {
+			result = append(result, pledge.Amount())
+		}
+		return result
+	}
+
+	t.Run("fewer larger pledges", func(t *testing.T) {
+		contract := newContract(12000000, 12000000, 60000000, 12000000, 12000000, 45000000, 12000000)
+		selected, err := contract.SelectMinimalPledges()
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{60000000, 45000000}, amounts(selected))
+	})
+
+	t.Run("least overshoot for the fewest pledges", func(t *testing.T) {
+		contract := newContract(90000000, 60000000, 50000000, 15000000)
+		selected, err := contract.SelectMinimalPledges()
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{90000000, 15000000}, amounts(selected))
+	})
+
+	t.Run("counts inputs rather than pledges", func(t *testing.T) {
+		// A single pledge funded from three UTXOs costs more than two pledges
+		privKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		var utxos []*transaction.UTXO
+		var keys []*ec.PrivateKey
+		for _, satoshis := range []uint64{40000000, 40000000, 20000000} {
+			utxos = append(utxos, createTestKeyUTXOs(t, privKey, satoshis)...)
+			keys = append(keys, privKey)
+		}
+		split, err := NewPledge(project, 100000000, withFeeAllowance(t, project, utxos))
+		require.NoError(t, err)
+		require.NoError(t, split.Sign(keys))
+
+		contract := newContract(60000000)
+		require.NoError(t, contract.AddPledge(split))
+		require.NoError(t, contract.AddPledge(createSignedTestPledge(t, project, 50000000)))
+
+		selected, err := contract.SelectMinimalPledges()
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{60000000, 50000000}, amounts(selected))
+	})
+
+	t.Run("deterministic between equal pledges", func(t *testing.T) {
+		pledges := []*Pledge{
+			createSignedTestPledge(t, project, 50000000),
+			createSignedTestPledge(t, project, 50000000),
+			createSignedTestPledge(t, project, 50000000),
+		}
+		var ids [][]string
+		for _, order := range [][]int{{0, 1, 2}, {2, 1, 0}, {1, 2, 0}} {
+			contract := NewContract(project)
+			for _, i := range order {
+				require.NoError(t, contract.AddPledge(pledges[i]))
+			}
+			selected, err := contract.SelectMinimalPledges()
+			require.NoError(t, err)
+			require.Len(t, selected, 2)
+
+			var selectedIDs []string
+			for _, pledge := range sortedByAmount(selected) {
+				selectedIDs = append(selectedIDs, pledge.ID())
+			}
+			ids = append(ids, selectedIDs)
+		}
+		assert.Equal(t, ids[0], ids[1])
+		assert.Equal(t, ids[0], ids[2])
+	})
+
+	t.Run("goal not reached", func(t *testing.T) {
+		_, err := newContract(40000000, 50000000).SelectMinimalPledges()
+		assert.Error(t, err)
+	})
+
+	t.Run("claim preview reports the selection", func(t *testing.T) {
+		contract := newContract(20000000, 60000000, 20000000, 40000000)
+		contract.SetSelectMinimalPledges(true)
+		preview, err := contract.ClaimPreview()
+		require.NoError(t, err)
+		assert.Equal(t, 2, preview.PledgeCount)
+		assert.Equal(t, 4, preview.Available)
+		assert.Equal(t, 2, preview.InputCount)
+	})
+}
+
+// pledgeSet converts a pledge slice to a set
+func pledgeSet(pledges []*Pledge) map[*Pledge]bool {
+	set := make(map[*Pledge]bool)
+	for _, pledge := range pledges {
+		set[pledge] = true
+	}
+	return set
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+)
+
+// Signer produces the unlocking script for one input of a pledge
+// transaction. Pledge inputs must be signed SIGHASH_ALL|ANYONECANPAY|FORKID
+// so they stay valid once combined, which Pledge.SignWith checks. Keys can
+// live elsewhere, e.g. in a hardware wallet or behind a signing service.
+type Signer interface {
+	SignInput(tx *transaction.Transaction, index uint32) (*script.Script, error)
+}
+
+// pledgeSigHashFlag is the sighash flag every pledge input is signed with
+const pledgeSigHashFlag = sighash.AllForkID | sighash.AnyOneCanPay
+
+// WIFSigner signs P2PKH pledge inputs with a private key held in memory
+type WIFSigner struct {
+	Key *ec.PrivateKey
+}
+
+// NewWIFSigner decodes a WIF private key into a signer
+func NewWIFSigner(wif string) (*WIFSigner, error) {
+	key, err := ec.PrivateKeyFromWif(wif)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WIF: %w", err)
+	}
+	return &WIFSigner{Key: key}, nil
+}
+
+// SignInput signs input index of tx with SIGHASH_ALL|ANYONECANPAY|FORKID
+func (s *WIFSigner) SignInput(tx *transaction.Transaction, index uint32) (*script.Script, error) {
+	if s.Key == nil {
+		return nil, errors.New("signer has no key")
+	}
+
+	flag := pledgeSigHashFlag
+	unlocker, err := p2pkh.Unlock(s.Key, &flag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unlocker: %w", err)
+	}
+	return unlocker.Sign(tx, index)
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSigner stands in for an external signer, recording which inputs it
+// was asked to sign
+type mockSigner struct {
+	key     *ec.PrivateKey
+	flag    sighash.Flag
+	err     error
+	indexes []uint32
+}
+
+func (m *mockSigner) SignInput(tx *transaction.Transaction, index uint32) (*script.Script, error) {
+	m.indexes = append(m.indexes, index)
+	if m.err != nil {
+		return nil, m.err
+	}
+	unlocker, err := p2pkh.Unlock(m.key, &m.flag)
+	if err != nil {
+		return nil, err
+	}
+	return unlocker.Sign(tx, index)
+}
+
+func TestPledgeSignWith(t *testing.T) {
+	project, err := NewProject(
+		"Signer Test",
+		"Testing external signers",
+		100000000,
+		"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+		NetworkMainnet,
+	)
+	require.NoError(t, err)
+
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	newPledge := func() *Pledge {
+		utxos := withFeeAllowance(t, project, append(createTestKeyUTXOs(t, key, 10000000), createTestKeyUTXOs(t, key, 15000000)...))
+		pledge, err := NewPledge(project, 25000000, utxos)
+		require.NoError(t, err)
+		return pledge
+	}
+
+	t.Run("external signer", func(t *testing.T) {
+		pledge := newPledge()
+		first := &mockSigner{key: key, flag: pledgeSigHashFlag}
+		second := &mockSigner{key: key, flag: pledgeSigHashFlag}
+		require.NoError(t, pledge.SignWith(first, second))
+
+		assert.Equal(t, []uint32{0}, first.indexes)
+		assert.Equal(t, []uint32{1}, second.indexes)
+		assert.NoError(t, pledge.Validate())
+		assert.NoError(t, pledge.VerifySignatures())
+	})
+
+	t.Run("matches signing with keys", func(t *testing.T) {
+		pledge := newPledge()
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{key, key}))
+		assert.NoError(t, pledge.Validate())
+	})
+
+	t.Run("signer error", func(t *testing.T) {
+		pledge := newPledge()
+		failing := &mockSigner{err: errors.New("device disconnected")}
+		err := pledge.SignWith(&WIFSigner{Key: key}, failing)
+		assert.ErrorContains(t, err, "input 1")
+		assert.ErrorContains(t, err, "device disconnected")
+	})
+
+	t.Run("signer without ANYONECANPAY", func(t *testing.T) {
+		pledge := newPledge()
+		plain := &mockSigner{key: key, flag: sighash.AllForkID}
+		err := pledge.SignWith(plain, plain)
+		assert.ErrorIs(t, err, ErrIncompatibleSigHash)
+	})
+
+	t.Run("too few signers", func(t *testing.T) {
+		pledge := newPledge()
+		assert.ErrorContains(t, pledge.SignWith(&WIFSigner{Key: key}), "no signer for input 1")
+	})
+
+	t.Run("pledge with change", func(t *testing.T) {
+		address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+		require.NoError(t, err)
+		pledge, err := NewPledgeWithChange(project, 10000000, createTestKeyUTXOs(t, key, 50000000), address.AddressString, DefaultFeeRate)
+		require.NoError(t, err)
+		assert.Error(t, pledge.SignWith(&WIFSigner{Key: key}))
+	})
+}
+
+func TestNewWIFSigner(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	signer, err := NewWIFSigner(key.Wif())
+	require.NoError(t, err)
+	assert.Equal(t, key.PubKey().Compressed(), signer.Key.PubKey().Compressed())
+
+	_, err = NewWIFSigner("not a wif")
+	assert.Error(t, err)
+}
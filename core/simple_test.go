@@ -14,7 +14,8 @@ func TestProjectBasics(t *testing.T) {
 			"Simple Test",
 			"A simple test project",
 			100000000, // 1 BSV
-			"1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+			"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+			NetworkMainnet,
 		)
 		require.NoError(t, err)
 		assert.NotNil(t, project)
@@ -45,7 +46,8 @@ func TestContractBasics(t *testing.T) {
 			"Contract Test",
 			"Test contract functionality",
 			200000000, // 2 BSV
-			"1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+			"1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
+			NetworkMainnet,
 		)
 		require.NoError(t, err)
 
@@ -85,7 +87,7 @@ func TestProjectValidation(t *testing.T) {
 			title:       "Valid Project",
 			description: "A valid project description",
 			goal:        100000000,
-			address:     "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+			address:     "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
 			shouldError: false,
 		},
 		{
@@ -93,7 +95,7 @@ func TestProjectValidation(t *testing.T) {
 			title:       "",
 			description: "Description",
 			goal:        100000000,
-			address:     "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+			address:     "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
 			shouldError: true,
 			errorMsg:    "title and description are required",
 		},
@@ -102,7 +104,7 @@ func TestProjectValidation(t *testing.T) {
 			title:       "Title",
 			description: "",
 			goal:        100000000,
-			address:     "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+			address:     "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
 			shouldError: true,
 			errorMsg:    "title and description are required",
 		},
@@ -111,7 +113,7 @@ func TestProjectValidation(t *testing.T) {
 			title:       "Title",
 			description: "Description",
 			goal:        0,
-			address:     "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q",
+			address:     "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6",
 			shouldError: true,
 			errorMsg:    "goal amount must be greater than 0",
 		},
@@ -128,7 +130,7 @@ func TestProjectValidation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			project, err := NewProject(tc.title, tc.description, tc.goal, tc.address)
+			project, err := NewProject(tc.title, tc.description, tc.goal, tc.address, NetworkMainnet)
 			
 			if tc.shouldError {
 				assert.Error(t, err)
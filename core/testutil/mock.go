@@ -0,0 +1,129 @@
+// Package testutil provides in-memory stand-ins for the network-facing
+// parts of core, so claim, broadcast and validation flows can be tested
+// deterministically without a node or API.
+package testutil
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/yourusername/lighthouse/core"
+)
+
+var (
+	_ core.UTXOChecker = (*MockUTXOChecker)(nil)
+	_ core.Broadcaster = (*MockBroadcaster)(nil)
+)
+
+// MockUTXOChecker is a core.UTXOChecker that answers from memory. Every
+// output is unspent until marked spent, and every lookup is recorded.
+type MockUTXOChecker struct {
+	mu    sync.Mutex
+	spent map[string]bool
+	err   error
+	calls []string
+}
+
+// NewMockUTXOChecker creates a checker that reports every output unspent
+func NewMockUTXOChecker() *MockUTXOChecker {
+	return &MockUTXOChecker{spent: make(map[string]bool)}
+}
+
+// Spend marks an output as spent
+func (m *MockUTXOChecker) Spend(txid string, vout uint32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spent[outpoint(txid, vout)] = true
+}
+
+// SpendInputs marks every output tx spends as spent, as if tx had been mined
+func (m *MockUTXOChecker) SpendInputs(tx *transaction.Transaction) {
+	for _, input := range tx.Inputs {
+		m.Spend(input.SourceTXID.String(), input.SourceTxOutIndex)
+	}
+}
+
+// SetError makes every lookup fail with err, or succeed again if err is nil
+func (m *MockUTXOChecker) SetError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+// IsUnspent records the lookup and reports whether the output was marked
+// spent. Every output is taken to exist and match expected.
+func (m *MockUTXOChecker) IsUnspent(txid string, vout uint32, expected *transaction.TransactionOutput) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, outpoint(txid, vout))
+	if m.err != nil {
+		return false, m.err
+	}
+	return !m.spent[outpoint(txid, vout)], nil
+}
+
+// Calls returns the outputs looked up so far, as "txid:vout", in order
+func (m *MockUTXOChecker) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// MockBroadcaster is a core.Broadcaster that records transactions instead
+// of sending them
+type MockBroadcaster struct {
+	mu        sync.Mutex
+	responses []error
+	err       error
+	txs       []*transaction.Transaction
+}
+
+// NewMockBroadcaster creates a broadcaster that accepts every transaction
+func NewMockBroadcaster() *MockBroadcaster {
+	return &MockBroadcaster{}
+}
+
+// SetError makes every broadcast fail with err, or succeed again if err is
+// nil, once any queued responses are used up
+func (m *MockBroadcaster) SetError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+// QueueResponses sets the results of the next broadcasts, in order: nil
+// accepts the transaction and an error fails it
+func (m *MockBroadcaster) QueueResponses(errs ...error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses = append(m.responses, errs...)
+}
+
+// Broadcast records tx and returns its txid, or the configured error
+func (m *MockBroadcaster) Broadcast(tx *transaction.Transaction) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.txs = append(m.txs, tx)
+
+	err := m.err
+	if len(m.responses) > 0 {
+		err, m.responses = m.responses[0], m.responses[1:]
+	}
+	if err != nil {
+		return "", err
+	}
+	return tx.TxID().String(), nil
+}
+
+// Broadcasts returns every transaction passed to Broadcast, including
+// failed attempts, in order
+func (m *MockBroadcaster) Broadcasts() []*transaction.Transaction {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*transaction.Transaction(nil), m.txs...)
+}
+
+func outpoint(txid string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txid, vout)
+}
@@ -0,0 +1,138 @@
+package testutil_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/lighthouse/core"
+	"github.com/yourusername/lighthouse/core/testutil"
+)
+
+func TestMockUTXOChecker(t *testing.T) {
+	checker := testutil.NewMockUTXOChecker()
+	txid := "aa" + hex.EncodeToString(make([]byte, 31))
+
+	unspent, err := checker.IsUnspent(txid, 0, nil)
+	require.NoError(t, err)
+	assert.True(t, unspent)
+
+	checker.Spend(txid, 0)
+	unspent, err = checker.IsUnspent(txid, 0, nil)
+	require.NoError(t, err)
+	assert.False(t, unspent)
+	unspent, err = checker.IsUnspent(txid, 1, nil)
+	require.NoError(t, err)
+	assert.True(t, unspent)
+
+	checker.SetError(errors.New("network down"))
+	_, err = checker.IsUnspent(txid, 1, nil)
+	assert.EqualError(t, err, "network down")
+
+	assert.Equal(t, []string{txid + ":0", txid + ":0", txid + ":1", txid + ":1"}, checker.Calls())
+}
+
+func TestMockBroadcaster(t *testing.T) {
+	broadcaster := testutil.NewMockBroadcaster()
+	tx := transaction.NewTransaction()
+
+	txid, err := broadcaster.Broadcast(tx)
+	require.NoError(t, err)
+	assert.Equal(t, tx.TxID().String(), txid)
+
+	// Queued responses come first, then the standing error
+	broadcaster.QueueResponses(core.ErrBroadcastRejected, nil)
+	broadcaster.SetError(errors.New("timeout"))
+	_, err = broadcaster.Broadcast(tx)
+	assert.ErrorIs(t, err, core.ErrBroadcastRejected)
+	_, err = broadcaster.Broadcast(tx)
+	assert.NoError(t, err)
+	_, err = broadcaster.Broadcast(tx)
+	assert.EqualError(t, err, "timeout")
+
+	assert.Len(t, broadcaster.Broadcasts(), 4)
+}
+
+// TestClaimCycle runs a campaign from project creation to a broadcast claim
+// against the mocks
+func TestClaimCycle(t *testing.T) {
+	project, err := core.NewProject("Mock Test", "Testing the full claim cycle", 100000000, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", core.NetworkMainnet)
+	require.NoError(t, err)
+
+	// pledge signs a pledge from a fresh key funded with exactly the
+	// amount plus its fee allowance
+	pledge := func(amount uint64) *core.Pledge {
+		key, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+		require.NoError(t, err)
+		lockingScript, err := p2pkh.Lock(address)
+		require.NoError(t, err)
+		allowance, err := core.FeeAllowance(project, 1, core.DefaultFeeRate)
+		require.NoError(t, err)
+
+		txid := make([]byte, 32)
+		_, err = rand.Read(txid)
+		require.NoError(t, err)
+		utxo, err := transaction.NewUTXO(hex.EncodeToString(txid), 0, lockingScript.String(), amount+allowance)
+		require.NoError(t, err)
+
+		pledge, err := core.NewPledge(project, amount, []*transaction.UTXO{utxo})
+		require.NoError(t, err)
+		require.NoError(t, pledge.Sign([]*ec.PrivateKey{key}))
+		return pledge
+	}
+
+	checker := testutil.NewMockUTXOChecker()
+	broadcaster := testutil.NewMockBroadcaster()
+
+	contract := core.NewContract(project)
+	pledges := []*core.Pledge{pledge(60000000), pledge(30000000), pledge(10000000)}
+	for _, p := range pledges {
+		require.NoError(t, contract.AddPledge(p))
+	}
+
+	// One pledger spends their coins elsewhere before the claim
+	revoked := pledges[2].Transaction().Inputs[0]
+	checker.Spend(revoked.SourceTXID.String(), revoked.SourceTxOutIndex)
+	invalidated, err := contract.ValidatePledges(checker)
+	require.NoError(t, err)
+	assert.Equal(t, []string{pledges[2].ID()}, invalidated)
+	assert.Len(t, checker.Calls(), 3)
+	assert.False(t, contract.CanClaim())
+
+	// A replacement pledge completes the goal
+	require.NoError(t, contract.AddPledge(pledge(10000000)))
+	invalidated, err = contract.ValidatePledges(checker)
+	require.NoError(t, err)
+	assert.Empty(t, invalidated)
+	require.True(t, contract.CanClaim())
+
+	tx, err := contract.CombineSorted()
+	require.NoError(t, err)
+	require.NoError(t, contract.VerifyCombined(tx))
+
+	// The first attempt is rejected, the retry goes through
+	broadcaster.QueueResponses(errors.New("connection reset"))
+	_, err = broadcaster.Broadcast(tx)
+	assert.Error(t, err)
+	txid, err := broadcaster.Broadcast(tx)
+	require.NoError(t, err)
+	assert.Equal(t, tx.TxID().String(), txid)
+	require.Len(t, broadcaster.Broadcasts(), 2)
+	assert.Same(t, tx, broadcaster.Broadcasts()[1])
+
+	// Once mined, every pledge is spent
+	checker.SpendInputs(tx)
+	invalidated, err = contract.ValidatePledges(checker)
+	require.NoError(t, err)
+	assert.Len(t, invalidated, 3)
+	assert.Empty(t, contract.Pledges())
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultWhatsOnChainAPI is the base URL of the WhatsOnChain mainnet API
+const DefaultWhatsOnChainAPI = "https://api.whatsonchain.com/v1/bsv/main"
+
+// WhatsOnChainTestnetAPI is the base URL of the WhatsOnChain testnet API
+const WhatsOnChainTestnetAPI = "https://api.whatsonchain.com/v1/bsv/test"
+
+// UTXOChecker reports whether a transaction output is still unspent. An
+// output that doesn't exist, or whose value or locking script differs from
+// expected, is reported as not unspent. expected may be nil, and its locking
+// script may be nil when unknown.
+type UTXOChecker interface {
+	IsUnspent(txid string, vout uint32, expected *transaction.TransactionOutput) (bool, error)
+}
+
+// InputStatus reports whether the output a pledge input spends is unspent
+type InputStatus struct {
+	Outpoint string `json:"outpoint"`
+	Unspent  bool   `json:"unspent"`
+}
+
+// DefaultLookupTimeout bounds each WhatsOnChain lookup
+const DefaultLookupTimeout = 30 * time.Second
+
+// WhatsOnChainUTXOChecker looks up output spend status through the WhatsOnChain API
+type WhatsOnChainUTXOChecker struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewWhatsOnChainUTXOChecker creates a checker using the given API base URL
+func NewWhatsOnChainUTXOChecker(baseURL string) *WhatsOnChainUTXOChecker {
+	if baseURL == "" {
+		baseURL = DefaultWhatsOnChainAPI
+	}
+	return &WhatsOnChainUTXOChecker{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: DefaultLookupTimeout},
+	}
+}
+
+// IsUnspent fetches the transaction to confirm the output exists and
+// matches expected, then queries the spent endpoint. WhatsOnChain answers
+// that endpoint with 404 when no transaction spends the output, but also
+// when the output doesn't exist, so existence has to be checked first.
+func (w *WhatsOnChainUTXOChecker) IsUnspent(txid string, vout uint32, expected *transaction.TransactionOutput) (bool, error) {
+	output, err := w.output(txid, vout)
+	if err != nil || output == nil {
+		return false, err
+	}
+	if expected != nil {
+		if output.Satoshis != expected.Satoshis {
+			return false, nil
+		}
+		if expected.LockingScript != nil && !bytes.Equal(output.LockingScript.Bytes(), expected.LockingScript.Bytes()) {
+			return false, nil
+		}
+	}
+
+	url := fmt.Sprintf("%s/tx/%s/%d/spent", w.baseURL, txid, vout)
+	resp, err := w.client.Get(url)
+	if err != nil {
+		return false, fmt.Errorf("failed to reach UTXO endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return true, nil
+	case http.StatusOK:
+		return false, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("UTXO lookup failed (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
+
+// output fetches a transaction and returns its output vout, or nil if
+// either doesn't exist
+func (w *WhatsOnChainUTXOChecker) output(txid string, vout uint32) (*transaction.TransactionOutput, error) {
+	url := fmt.Sprintf("%s/tx/%s/hex", w.baseURL, txid)
+	resp, err := w.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach transaction endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTxHexSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction %s: %w", txid, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, nil
+	case http.StatusOK:
+	default:
+		return nil, fmt.Errorf("transaction lookup failed (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	tx, err := transaction.NewTransactionFromHex(strings.Trim(strings.TrimSpace(string(body)), `"`))
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction %s: %w", txid, err)
+	}
+	// Don't trust the API to have returned the transaction asked for
+	if tx.TxID().String() != txid {
+		return nil, fmt.Errorf("transaction lookup for %s returned %s", txid, tx.TxID())
+	}
+	if int(vout) >= len(tx.Outputs) {
+		return nil, nil
+	}
+	return tx.Outputs[vout], nil
+}
+
+// maxTxHexSize caps how much of a transaction lookup is read
+const maxTxHexSize = 20 << 20
+
+// ErrInsufficientFunds is returned when the available UTXOs can't cover a
+// pledge amount plus its fee
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// p2pkhInputSize is the serialized size of a signed P2PKH input: txid,
+// vout, script length, unlocking script and sequence
+const p2pkhInputSize = 32 + 4 + 1 + p2pkhUnlockingScriptSize + 4
+
+// SelectUTXOs picks UTXOs, largest first, until they cover target plus the
+// fee (at feeRate sat/KB) for the inputs they add to the claim transaction.
+// Largest first keeps the input count, and so the fee, low.
+func SelectUTXOs(available []*transaction.UTXO, target uint64, feeRate uint64) ([]*transaction.UTXO, error) {
+	sorted := make([]*transaction.UTXO, 0, len(available))
+	for _, utxo := range available {
+		if utxo != nil && utxo.Satoshis > 0 {
+			sorted = append(sorted, utxo)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Satoshis != sorted[j].Satoshis {
+			return sorted[i].Satoshis > sorted[j].Satoshis
+		}
+		if c := strings.Compare(sorted[i].TxID.String(), sorted[j].TxID.String()); c != 0 {
+			return c < 0
+		}
+		return sorted[i].Vout < sorted[j].Vout
+	})
+
+	var selected []*transaction.UTXO
+	total := uint64(0)
+	for _, utxo := range sorted {
+		selected = append(selected, utxo)
+		total += utxo.Satoshis
+		if total >= target+inputsFee(len(selected), feeRate) {
+			return selected, nil
+		}
+	}
+
+	need := target + inputsFee(len(selected), feeRate)
+	return nil, fmt.Errorf("%w: have %d satoshis in %d UTXOs, need %d (%d plus fee)", ErrInsufficientFunds, total, len(selected), need, target)
+}
+
+// inputsFee is the fee for n P2PKH inputs at feeRate sat/KB, rounded up
+func inputsFee(n int, feeRate uint64) uint64 {
+	return (uint64(n)*p2pkhInputSize*feeRate + 999) / 1000
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhatsOnChainUTXOChecker(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	lockingScript := createTestKeyUTXOs(t, key, 50000)[0].LockingScript
+	source := transaction.NewTransaction()
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 50000, LockingScript: lockingScript})
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 20000, LockingScript: lockingScript})
+	txid := source.TxID().String()
+	other := transaction.NewTransaction()
+	other.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: lockingScript})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx/" + txid + "/hex":
+			w.Write([]byte(source.Hex()))
+		case "/tx/" + strings.Repeat("bb", 32) + "/hex":
+			w.Write([]byte(other.Hex()))
+		case "/tx/" + strings.Repeat("cc", 32) + "/hex":
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+		case "/tx/" + txid + "/0/spent":
+			http.NotFound(w, r)
+		case "/tx/" + txid + "/1/spent":
+			w.Write([]byte(`{"txid":"dd","vin":0}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	checker := NewWhatsOnChainUTXOChecker(server.URL + "/")
+	expected := func(satoshis uint64, lockingScript *script.Script) *transaction.TransactionOutput {
+		return &transaction.TransactionOutput{Satoshis: satoshis, LockingScript: lockingScript}
+	}
+
+	tests := []struct {
+		name     string
+		txid     string
+		vout     uint32
+		expected *transaction.TransactionOutput
+		unspent  bool
+	}{
+		{"unspent output", txid, 0, expected(50000, lockingScript), true},
+		{"value only", txid, 0, expected(50000, nil), true},
+		{"nothing expected", txid, 0, nil, true},
+		{"spent output", txid, 1, expected(20000, lockingScript), false},
+		{"missing transaction", strings.Repeat("aa", 32), 0, nil, false},
+		{"missing output", txid, 2, nil, false},
+		{"different value", txid, 0, expected(60000, lockingScript), false},
+		{"different script", txid, 0, expected(50000, &script.Script{script.OpTRUE}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unspent, err := checker.IsUnspent(tt.txid, tt.vout, tt.expected)
+			require.NoError(t, err)
+			assert.Equal(t, tt.unspent, unspent)
+		})
+	}
+
+	t.Run("unexpected status", func(t *testing.T) {
+		_, err := checker.IsUnspent(strings.Repeat("cc", 32), 0, nil)
+		assert.ErrorContains(t, err, "rate limited")
+	})
+
+	t.Run("wrong transaction returned", func(t *testing.T) {
+		_, err := checker.IsUnspent(strings.Repeat("bb", 32), 0, nil)
+		assert.ErrorContains(t, err, "returned")
+	})
+
+	t.Run("client has a timeout", func(t *testing.T) {
+		assert.Equal(t, DefaultLookupTimeout, checker.client.Timeout)
+	})
+}
+
+func TestSelectUTXOs(t *testing.T) {
+	utxos := func(amounts ...uint64) []*transaction.UTXO {
+		var result []*transaction.UTXO
+		for i, amount := range amounts {
+			result = append(result, &transaction.UTXO{TxID: &chainhash.Hash{byte(i + 1)}, Satoshis: amount})
+		}
+		return result
+	}
+	amounts := func(selected []*transaction.UTXO) []uint64 {
+		var result []uint64
+		for _, utxo := range selected {
+			result = append(result, utxo.Satoshis)
+		}
+		return result
+	}
+
+	t.Run("largest first", func(t *testing.T) {
+		selected, err := SelectUTXOs(utxos(1000, 50000, 20000, 30000), 60000, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{50000, 30000}, amounts(selected))
+	})
+
+	t.Run("covers the input fee", func(t *testing.T) {
+		// One input costs 148 bytes, so 8 satoshis at 50 sat/KB
+		assert.Equal(t, uint64(8), inputsFee(1, DefaultFeeRate))
+
+		selected, err := SelectUTXOs(utxos(60000, 5000), 60000, DefaultFeeRate)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{60000, 5000}, amounts(selected))
+
+		selected, err = SelectUTXOs(utxos(60008, 5000), 60000, DefaultFeeRate)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{60008}, amounts(selected))
+	})
+
+	t.Run("skips empty outputs", func(t *testing.T) {
+		selected, err := SelectUTXOs(append(utxos(0, 70000), nil), 60000, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{70000}, amounts(selected))
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		_, err := SelectUTXOs(utxos(30000, 30000), 60000, DefaultFeeRate)
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+		assert.ErrorContains(t, err, "have 60000 satoshis in 2 UTXOs, need 60015")
+
+		_, err = SelectUTXOs(nil, 1, 0)
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+	})
+}
@@ -10,7 +10,7 @@ echo "🏗️  Creating a sample crowdfunding project..."
 # Create a community garden project
 ./bin/lighthouse project create "Community Garden Project" \
     --goal 5.0 \
-    --address "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q" \
+    --address "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6" \
     --description "Help us build a beautiful community garden in our neighborhood! This space will provide fresh vegetables, a place for kids to learn about nature, and bring our community together." \
     --min-pledge 0.001
 
@@ -17,7 +17,7 @@ cd examples/projects
 echo "📦 Creating open source software project..."
 ../../bin/lighthouse project create "BSV Wallet Library" \
     --goal 10.0 \
-    --address "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q" \
+    --address "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6" \
     --description "Fund development of a comprehensive BSV wallet library with full SPV support, advanced script templates, and easy-to-use APIs for developers." \
     --min-pledge 0.01 \
     --output "bsv-wallet-library.lighthouse"
@@ -26,7 +26,7 @@ echo "📦 Creating open source software project..."
 echo "📚 Creating educational content project..."
 ../../bin/lighthouse project create "BSV Developer Course" \
     --goal 3.5 \
-    --address "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q" \
+    --address "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6" \
     --description "Create comprehensive video tutorials and documentation teaching BSV development, from basics to advanced topics including smart contracts and overlay networks." \
     --min-pledge 0.005 \
     --output "bsv-education.lighthouse"
@@ -35,7 +35,7 @@ echo "📚 Creating educational content project..."
 echo "🔧 Creating hardware project..."
 ../../bin/lighthouse project create "BSV Hardware Wallet" \
     --goal 25.0 \
-    --address "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q" \
+    --address "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6" \
     --description "Design and manufacture secure BSV hardware wallets with advanced features including multi-signature support, custom scripts, and easy recovery." \
     --min-pledge 0.1 \
     --output "bsv-hardware-wallet.lighthouse"
@@ -44,7 +44,7 @@ echo "🔧 Creating hardware project..."
 echo "🎉 Creating community event project..."
 ../../bin/lighthouse project create "BSV Conference 2024" \
     --goal 8.0 \
-    --address "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q" \
+    --address "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6" \
     --description "Fund the annual BSV developer conference featuring workshops, presentations, and networking opportunities for the global BSV community." \
     --min-pledge 0.02 \
     --output "bsv-conference.lighthouse"
@@ -53,7 +53,7 @@ echo "🎉 Creating community event project..."
 echo "🔬 Creating research project..."
 ../../bin/lighthouse project create "Scaling Research" \
     --goal 15.0 \
-    --address "1NKNazRR5jKgGqELVHDK47JAZrqtAWWy5q" \
+    --address "1NKNazRR5jKgGqELVHDK47JAZrqt8MwRS6" \
     --description "Research project investigating BSV network scaling solutions, analyzing performance metrics, and developing optimization strategies for enterprise adoption." \
     --min-pledge 0.05 \
     --output "scaling-research.lighthouse"
@@ -4,6 +4,8 @@ go 1.24.3
 
 require (
 	github.com/bsv-blockchain/go-sdk v0.0.0
+	github.com/gorilla/websocket v1.5.3
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/spf13/cobra v1.8.0
 	github.com/stretchr/testify v1.9.0
 	google.golang.org/protobuf v1.32.0
@@ -22,6 +22,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ProjectState lets a creator halt a campaign before it expires
+type ProjectState int32
+
+const (
+	// Taking pledges
+	ProjectState_PROJECT_STATE_ACTIVE ProjectState = 0
+	// Not taking new pledges for now; existing pledges can still be claimed
+	ProjectState_PROJECT_STATE_PAUSED ProjectState = 1
+	// Closed for good; pledges are rejected and the project can't be claimed
+	ProjectState_PROJECT_STATE_CANCELLED ProjectState = 2
+)
+
+// Enum value maps for ProjectState.
+var (
+	ProjectState_name = map[int32]string{
+		0: "PROJECT_STATE_ACTIVE",
+		1: "PROJECT_STATE_PAUSED",
+		2: "PROJECT_STATE_CANCELLED",
+	}
+	ProjectState_value = map[string]int32{
+		"PROJECT_STATE_ACTIVE":    0,
+		"PROJECT_STATE_PAUSED":    1,
+		"PROJECT_STATE_CANCELLED": 2,
+	}
+)
+
+func (x ProjectState) Enum() *ProjectState {
+	p := new(ProjectState)
+	*p = x
+	return p
+}
+
+func (x ProjectState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProjectState) Descriptor() protoreflect.EnumDescriptor {
+	return file_lighthouse_proto_enumTypes[0].Descriptor()
+}
+
+func (ProjectState) Type() protoreflect.EnumType {
+	return &file_lighthouse_proto_enumTypes[0]
+}
+
+func (x ProjectState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProjectState.Descriptor instead.
+func (ProjectState) EnumDescriptor() ([]byte, []int) {
+	return file_lighthouse_proto_rawDescGZIP(), []int{0}
+}
+
 // Project represents a crowdfunding campaign
 type Project struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -206,8 +259,18 @@ type ProjectExtraDetails struct {
 	AuthKey []byte `protobuf:"bytes,3,opt,name=auth_key,json=authKey,proto3" json:"auth_key,omitempty"`
 	// Minimum pledge amount in satoshis
 	MinPledgeAmount uint64 `protobuf:"varint,4,opt,name=min_pledge_amount,json=minPledgeAmount,proto3" json:"min_pledge_amount,omitempty"`
-	// Project category/tags
-	Tags          []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Project tags
+	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Project category, e.g. for directory listings
+	Category string `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	// Stretch goals, in ascending threshold order
+	Milestones []*Milestone `protobuf:"bytes,7,rep,name=milestones,proto3" json:"milestones,omitempty"`
+	// Website and social links, in display order
+	Links []*Link `protobuf:"bytes,8,rep,name=links,proto3" json:"links,omitempty"`
+	// Cover image hosted elsewhere, instead of cover_image
+	CoverImageUrl string `protobuf:"bytes,9,opt,name=cover_image_url,json=coverImageUrl,proto3" json:"cover_image_url,omitempty"`
+	// Whether the project is taking pledges
+	Status        ProjectState `protobuf:"varint,10,opt,name=status,proto3,enum=lighthouse.ProjectState" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -277,6 +340,41 @@ func (x *ProjectExtraDetails) GetTags() []string {
 	return nil
 }
 
+func (x *ProjectExtraDetails) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ProjectExtraDetails) GetMilestones() []*Milestone {
+	if x != nil {
+		return x.Milestones
+	}
+	return nil
+}
+
+func (x *ProjectExtraDetails) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *ProjectExtraDetails) GetCoverImageUrl() string {
+	if x != nil {
+		return x.CoverImageUrl
+	}
+	return ""
+}
+
+func (x *ProjectExtraDetails) GetStatus() ProjectState {
+	if x != nil {
+		return x.Status
+	}
+	return ProjectState_PROJECT_STATE_ACTIVE
+}
+
 // Output represents a transaction output
 type Output struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -347,6 +445,20 @@ type Pledge struct {
 	Time *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
 	// Refund address if project fails
 	RefundAddress string `protobuf:"bytes,6,opt,name=refund_address,json=refundAddress,proto3" json:"refund_address,omitempty"`
+	// Amount pledged in satoshis
+	Amount uint64 `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Project outputs this pledge commits to
+	Outputs []*Output `protobuf:"bytes,8,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// nLockTime block height (0 = none)
+	LockTime uint32 `protobuf:"varint,9,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	// Network (mainnet/testnet), matching the project
+	Network string `protobuf:"bytes,10,opt,name=network,proto3" json:"network,omitempty"`
+	// ContactInfo encrypted to the project auth key (ECIES)
+	EncryptedContact []byte `protobuf:"bytes,11,opt,name=encrypted_contact,json=encryptedContact,proto3" json:"encrypted_contact,omitempty"`
+	// Signed transaction splitting the pledge off larger UTXOs, returning
+	// the change to the pledger. Its first output funds the pledge input,
+	// so it must be broadcast before the claim.
+	ChangeTx      []byte `protobuf:"bytes,12,opt,name=change_tx,json=changeTx,proto3" json:"change_tx,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -423,6 +535,48 @@ func (x *Pledge) GetRefundAddress() string {
 	return ""
 }
 
+func (x *Pledge) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Pledge) GetOutputs() []*Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *Pledge) GetLockTime() uint32 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+func (x *Pledge) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Pledge) GetEncryptedContact() []byte {
+	if x != nil {
+		return x.EncryptedContact
+	}
+	return nil
+}
+
+func (x *Pledge) GetChangeTx() []byte {
+	if x != nil {
+		return x.ChangeTx
+	}
+	return nil
+}
+
 // Input for a pledge transaction
 type Input struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -433,9 +587,13 @@ type Input struct {
 	// Unlocking script with SIGHASH_ANYONECANPAY signature
 	UnlockScript []byte `protobuf:"bytes,3,opt,name=unlock_script,json=unlockScript,proto3" json:"unlock_script,omitempty"`
 	// Sequence number
-	Sequence      uint32 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Sequence uint32 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Value of the output being spent, in satoshis
+	Satoshis uint64 `protobuf:"varint,5,opt,name=satoshis,proto3" json:"satoshis,omitempty"`
+	// Locking script of the output being spent, needed to verify the signature
+	SourceLockingScript []byte `protobuf:"bytes,6,opt,name=source_locking_script,json=sourceLockingScript,proto3" json:"source_locking_script,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Input) Reset() {
@@ -496,6 +654,20 @@ func (x *Input) GetSequence() uint32 {
 	return 0
 }
 
+func (x *Input) GetSatoshis() uint64 {
+	if x != nil {
+		return x.Satoshis
+	}
+	return 0
+}
+
+func (x *Input) GetSourceLockingScript() []byte {
+	if x != nil {
+		return x.SourceLockingScript
+	}
+	return nil
+}
+
 // Contact information for pledger
 type ContactInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -551,6 +723,134 @@ func (x *ContactInfo) GetEmail() string {
 	return ""
 }
 
+// Contract bundles a project with its accepted pledges
+type Contract struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Project being funded
+	Project *Project `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// Accepted pledges, in the order they were added
+	Pledges []*Pledge `protobuf:"bytes,2,rep,name=pledges,proto3" json:"pledges,omitempty"`
+	// Fee rate in satoshis per kilobyte used when combining
+	FeeRate       uint64 `protobuf:"varint,3,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contract) Reset() {
+	*x = Contract{}
+	mi := &file_lighthouse_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contract) ProtoMessage() {}
+
+func (x *Contract) ProtoReflect() protoreflect.Message {
+	mi := &file_lighthouse_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contract.ProtoReflect.Descriptor instead.
+func (*Contract) Descriptor() ([]byte, []int) {
+	return file_lighthouse_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Contract) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *Contract) GetPledges() []*Pledge {
+	if x != nil {
+		return x.Pledges
+	}
+	return nil
+}
+
+func (x *Contract) GetFeeRate() uint64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+// SignedProject is a project signed by its creator for distribution
+type SignedProject struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Serialized Project, exactly as signed
+	Project []byte `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// DER signature by auth_key over the project bytes
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Creator's public key, which must match the project's auth key
+	AuthKey       []byte `protobuf:"bytes,3,opt,name=auth_key,json=authKey,proto3" json:"auth_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignedProject) Reset() {
+	*x = SignedProject{}
+	mi := &file_lighthouse_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedProject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedProject) ProtoMessage() {}
+
+func (x *SignedProject) ProtoReflect() protoreflect.Message {
+	mi := &file_lighthouse_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedProject.ProtoReflect.Descriptor instead.
+func (*SignedProject) Descriptor() ([]byte, []int) {
+	return file_lighthouse_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SignedProject) GetProject() []byte {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *SignedProject) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *SignedProject) GetAuthKey() []byte {
+	if x != nil {
+		return x.AuthKey
+	}
+	return nil
+}
+
 // ProjectStatus for server responses
 type ProjectStatus struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -570,7 +870,7 @@ type ProjectStatus struct {
 
 func (x *ProjectStatus) Reset() {
 	*x = ProjectStatus{}
-	mi := &file_lighthouse_proto_msgTypes[7]
+	mi := &file_lighthouse_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -582,7 +882,7 @@ func (x *ProjectStatus) String() string {
 func (*ProjectStatus) ProtoMessage() {}
 
 func (x *ProjectStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_lighthouse_proto_msgTypes[7]
+	mi := &file_lighthouse_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -595,7 +895,7 @@ func (x *ProjectStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProjectStatus.ProtoReflect.Descriptor instead.
 func (*ProjectStatus) Descriptor() ([]byte, []int) {
-	return file_lighthouse_proto_rawDescGZIP(), []int{7}
+	return file_lighthouse_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ProjectStatus) GetProject() *Project {
@@ -633,6 +933,116 @@ func (x *ProjectStatus) GetClaimTx() []byte {
 	return nil
 }
 
+// Milestone is a staged funding goal within a project
+type Milestone struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Total pledged, in satoshis, at which the milestone is reached
+	Threshold uint64 `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// What the milestone unlocks
+	Description   string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Milestone) Reset() {
+	*x = Milestone{}
+	mi := &file_lighthouse_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Milestone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Milestone) ProtoMessage() {}
+
+func (x *Milestone) ProtoReflect() protoreflect.Message {
+	mi := &file_lighthouse_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Milestone.ProtoReflect.Descriptor instead.
+func (*Milestone) Descriptor() ([]byte, []int) {
+	return file_lighthouse_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Milestone) GetThreshold() uint64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Milestone) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Link is a labeled http(s) URL shown with a project
+type Link struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Display text, e.g. "Website"
+	Label string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	// http or https URL
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_lighthouse_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_lighthouse_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_lighthouse_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Link) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Link) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
 var File_lighthouse_proto protoreflect.FileDescriptor
 
 const file_lighthouse_proto_rawDesc = "" +
@@ -652,17 +1062,25 @@ const file_lighthouse_proto_rawDesc = "" +
 	"\x04memo\x18\x05 \x01(\tR\x04memo\x12\x1f\n" +
 	"\vpayment_url\x18\x06 \x01(\tR\n" +
 	"paymentUrl\x12#\n" +
-	"\rmerchant_data\x18\a \x01(\fR\fmerchantData\"\xa7\x01\n" +
+	"\rmerchant_data\x18\a \x01(\fR\fmerchantData\"\xfc\x02\n" +
 	"\x13ProjectExtraDetails\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1f\n" +
 	"\vcover_image\x18\x02 \x01(\fR\n" +
 	"coverImage\x12\x19\n" +
 	"\bauth_key\x18\x03 \x01(\fR\aauthKey\x12*\n" +
 	"\x11min_pledge_amount\x18\x04 \x01(\x04R\x0fminPledgeAmount\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\"8\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x125\n" +
+	"\n" +
+	"milestones\x18\a \x03(\v2\x15.lighthouse.MilestoneR\n" +
+	"milestones\x12&\n" +
+	"\x05links\x18\b \x03(\v2\x10.lighthouse.LinkR\x05links\x12&\n" +
+	"\x0fcover_image_url\x18\t \x01(\tR\rcoverImageUrl\x120\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x18.lighthouse.ProjectStateR\x06status\"8\n" +
 	"\x06Output\x12\x16\n" +
 	"\x06amount\x18\x01 \x01(\x04R\x06amount\x12\x16\n" +
-	"\x06script\x18\x02 \x01(\fR\x06script\"\xf0\x01\n" +
+	"\x06script\x18\x02 \x01(\fR\x06script\"\xb7\x03\n" +
 	"\x06Pledge\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\fR\tprojectId\x12)\n" +
@@ -670,21 +1088,48 @@ const file_lighthouse_proto_rawDesc = "" +
 	"\acontact\x18\x03 \x01(\v2\x17.lighthouse.ContactInfoR\acontact\x12\x12\n" +
 	"\x04memo\x18\x04 \x01(\tR\x04memo\x12.\n" +
 	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12%\n" +
-	"\x0erefund_address\x18\x06 \x01(\tR\rrefundAddress\"\x84\x01\n" +
+	"\x0erefund_address\x18\x06 \x01(\tR\rrefundAddress\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x04R\x06amount\x12,\n" +
+	"\aoutputs\x18\b \x03(\v2\x12.lighthouse.OutputR\aoutputs\x12\x1b\n" +
+	"\tlock_time\x18\t \x01(\rR\blockTime\x12\x18\n" +
+	"\anetwork\x18\n" +
+	" \x01(\tR\anetwork\x12+\n" +
+	"\x11encrypted_contact\x18\v \x01(\fR\x10encryptedContact\x12\x1b\n" +
+	"\tchange_tx\x18\f \x01(\fR\bchangeTx\"\xd4\x01\n" +
 	"\x05Input\x12\x17\n" +
 	"\atx_hash\x18\x01 \x01(\fR\x06txHash\x12!\n" +
 	"\foutput_index\x18\x02 \x01(\rR\voutputIndex\x12#\n" +
 	"\runlock_script\x18\x03 \x01(\fR\funlockScript\x12\x1a\n" +
-	"\bsequence\x18\x04 \x01(\rR\bsequence\"7\n" +
+	"\bsequence\x18\x04 \x01(\rR\bsequence\x12\x1a\n" +
+	"\bsatoshis\x18\x05 \x01(\x04R\bsatoshis\x122\n" +
+	"\x15source_locking_script\x18\x06 \x01(\fR\x13sourceLockingScript\"7\n" +
 	"\vContactInfo\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\"\xc6\x01\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"\x82\x01\n" +
+	"\bContract\x12-\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.lighthouse.ProjectR\aproject\x12,\n" +
+	"\apledges\x18\x02 \x03(\v2\x12.lighthouse.PledgeR\apledges\x12\x19\n" +
+	"\bfee_rate\x18\x03 \x01(\x04R\afeeRate\"b\n" +
+	"\rSignedProject\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\fR\aproject\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\fR\tsignature\x12\x19\n" +
+	"\bauth_key\x18\x03 \x01(\fR\aauthKey\"\xc6\x01\n" +
 	"\rProjectStatus\x12-\n" +
 	"\aproject\x18\x01 \x01(\v2\x13.lighthouse.ProjectR\aproject\x12,\n" +
 	"\apledges\x18\x02 \x03(\v2\x12.lighthouse.PledgeR\apledges\x12#\n" +
 	"\rtotal_pledged\x18\x03 \x01(\x04R\ftotalPledged\x12\x18\n" +
 	"\aclaimed\x18\x04 \x01(\bR\aclaimed\x12\x19\n" +
-	"\bclaim_tx\x18\x05 \x01(\fR\aclaimTxB\x0eZ\f./core/protob\x06proto3"
+	"\bclaim_tx\x18\x05 \x01(\fR\aclaimTx\"K\n" +
+	"\tMilestone\x12\x1c\n" +
+	"\tthreshold\x18\x01 \x01(\x04R\tthreshold\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\".\n" +
+	"\x04Link\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url*_\n" +
+	"\fProjectState\x12\x18\n" +
+	"\x14PROJECT_STATE_ACTIVE\x10\x00\x12\x18\n" +
+	"\x14PROJECT_STATE_PAUSED\x10\x01\x12\x1b\n" +
+	"\x17PROJECT_STATE_CANCELLED\x10\x02B\x0eZ\f./core/protob\x06proto3"
 
 var (
 	file_lighthouse_proto_rawDescOnce sync.Once
@@ -698,34 +1143,46 @@ func file_lighthouse_proto_rawDescGZIP() []byte {
 	return file_lighthouse_proto_rawDescData
 }
 
-var file_lighthouse_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_lighthouse_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_lighthouse_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_lighthouse_proto_goTypes = []any{
-	(*Project)(nil),               // 0: lighthouse.Project
-	(*ProjectDetails)(nil),        // 1: lighthouse.ProjectDetails
-	(*ProjectExtraDetails)(nil),   // 2: lighthouse.ProjectExtraDetails
-	(*Output)(nil),                // 3: lighthouse.Output
-	(*Pledge)(nil),                // 4: lighthouse.Pledge
-	(*Input)(nil),                 // 5: lighthouse.Input
-	(*ContactInfo)(nil),           // 6: lighthouse.ContactInfo
-	(*ProjectStatus)(nil),         // 7: lighthouse.ProjectStatus
-	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(ProjectState)(0),             // 0: lighthouse.ProjectState
+	(*Project)(nil),               // 1: lighthouse.Project
+	(*ProjectDetails)(nil),        // 2: lighthouse.ProjectDetails
+	(*ProjectExtraDetails)(nil),   // 3: lighthouse.ProjectExtraDetails
+	(*Output)(nil),                // 4: lighthouse.Output
+	(*Pledge)(nil),                // 5: lighthouse.Pledge
+	(*Input)(nil),                 // 6: lighthouse.Input
+	(*ContactInfo)(nil),           // 7: lighthouse.ContactInfo
+	(*Contract)(nil),              // 8: lighthouse.Contract
+	(*SignedProject)(nil),         // 9: lighthouse.SignedProject
+	(*ProjectStatus)(nil),         // 10: lighthouse.ProjectStatus
+	(*Milestone)(nil),             // 11: lighthouse.Milestone
+	(*Link)(nil),                  // 12: lighthouse.Link
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
 }
 var file_lighthouse_proto_depIdxs = []int32{
-	1,  // 0: lighthouse.Project.details:type_name -> lighthouse.ProjectDetails
-	2,  // 1: lighthouse.Project.extra:type_name -> lighthouse.ProjectExtraDetails
-	3,  // 2: lighthouse.ProjectDetails.outputs:type_name -> lighthouse.Output
-	8,  // 3: lighthouse.ProjectDetails.time:type_name -> google.protobuf.Timestamp
-	8,  // 4: lighthouse.ProjectDetails.expires:type_name -> google.protobuf.Timestamp
-	5,  // 5: lighthouse.Pledge.inputs:type_name -> lighthouse.Input
-	6,  // 6: lighthouse.Pledge.contact:type_name -> lighthouse.ContactInfo
-	8,  // 7: lighthouse.Pledge.time:type_name -> google.protobuf.Timestamp
-	0,  // 8: lighthouse.ProjectStatus.project:type_name -> lighthouse.Project
-	4,  // 9: lighthouse.ProjectStatus.pledges:type_name -> lighthouse.Pledge
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	2,  // 0: lighthouse.Project.details:type_name -> lighthouse.ProjectDetails
+	3,  // 1: lighthouse.Project.extra:type_name -> lighthouse.ProjectExtraDetails
+	4,  // 2: lighthouse.ProjectDetails.outputs:type_name -> lighthouse.Output
+	13, // 3: lighthouse.ProjectDetails.time:type_name -> google.protobuf.Timestamp
+	13, // 4: lighthouse.ProjectDetails.expires:type_name -> google.protobuf.Timestamp
+	11, // 5: lighthouse.ProjectExtraDetails.milestones:type_name -> lighthouse.Milestone
+	12, // 6: lighthouse.ProjectExtraDetails.links:type_name -> lighthouse.Link
+	0,  // 7: lighthouse.ProjectExtraDetails.status:type_name -> lighthouse.ProjectState
+	6,  // 8: lighthouse.Pledge.inputs:type_name -> lighthouse.Input
+	7,  // 9: lighthouse.Pledge.contact:type_name -> lighthouse.ContactInfo
+	13, // 10: lighthouse.Pledge.time:type_name -> google.protobuf.Timestamp
+	4,  // 11: lighthouse.Pledge.outputs:type_name -> lighthouse.Output
+	1,  // 12: lighthouse.Contract.project:type_name -> lighthouse.Project
+	5,  // 13: lighthouse.Contract.pledges:type_name -> lighthouse.Pledge
+	1,  // 14: lighthouse.ProjectStatus.project:type_name -> lighthouse.Project
+	5,  // 15: lighthouse.ProjectStatus.pledges:type_name -> lighthouse.Pledge
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_lighthouse_proto_init() }
@@ -738,13 +1195,14 @@ func file_lighthouse_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lighthouse_proto_rawDesc), len(file_lighthouse_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_lighthouse_proto_goTypes,
 		DependencyIndexes: file_lighthouse_proto_depIdxs,
+		EnumInfos:         file_lighthouse_proto_enumTypes,
 		MessageInfos:      file_lighthouse_proto_msgTypes,
 	}.Build()
 	File_lighthouse_proto = out.File